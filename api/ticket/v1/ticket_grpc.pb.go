@@ -0,0 +1,228 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.4
+// source: ticket/v1/ticket.proto
+
+package ticketv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	TicketService_CreateTicketOption_FullMethodName       = "/ticket.v1.TicketService/CreateTicketOption"
+	TicketService_GetTicket_FullMethodName                = "/ticket.v1.TicketService/GetTicket"
+	TicketService_ListTicketOptions_FullMethodName        = "/ticket.v1.TicketService/ListTicketOptions"
+	TicketService_PurchaseFromTicketOption_FullMethodName = "/ticket.v1.TicketService/PurchaseFromTicketOption"
+)
+
+// TicketServiceClient is the client API for TicketService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TicketServiceClient interface {
+	// CreateTicketOption creates a ticket option with an allocation of tickets available to purchase.
+	CreateTicketOption(ctx context.Context, in *CreateTicketOptionRequest, opts ...grpc.CallOption) (*TicketOption, error)
+	// GetTicket returns the ticket option with the given id.
+	GetTicket(ctx context.Context, in *GetTicketRequest, opts ...grpc.CallOption) (*TicketOption, error)
+	// ListTicketOptions returns ticket options ordered by id, one page at a time.
+	ListTicketOptions(ctx context.Context, in *ListTicketOptionsRequest, opts ...grpc.CallOption) (*ListTicketOptionsResponse, error)
+	// PurchaseFromTicketOption purchases a quantity of tickets from the allocation of a ticket option.
+	PurchaseFromTicketOption(ctx context.Context, in *PurchaseFromTicketOptionRequest, opts ...grpc.CallOption) (*PurchaseFromTicketOptionResponse, error)
+}
+
+type ticketServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTicketServiceClient(cc grpc.ClientConnInterface) TicketServiceClient {
+	return &ticketServiceClient{cc}
+}
+
+func (c *ticketServiceClient) CreateTicketOption(ctx context.Context, in *CreateTicketOptionRequest, opts ...grpc.CallOption) (*TicketOption, error) {
+	out := new(TicketOption)
+	err := c.cc.Invoke(ctx, TicketService_CreateTicketOption_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) GetTicket(ctx context.Context, in *GetTicketRequest, opts ...grpc.CallOption) (*TicketOption, error) {
+	out := new(TicketOption)
+	err := c.cc.Invoke(ctx, TicketService_GetTicket_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ListTicketOptions(ctx context.Context, in *ListTicketOptionsRequest, opts ...grpc.CallOption) (*ListTicketOptionsResponse, error) {
+	out := new(ListTicketOptionsResponse)
+	err := c.cc.Invoke(ctx, TicketService_ListTicketOptions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) PurchaseFromTicketOption(ctx context.Context, in *PurchaseFromTicketOptionRequest, opts ...grpc.CallOption) (*PurchaseFromTicketOptionResponse, error) {
+	out := new(PurchaseFromTicketOptionResponse)
+	err := c.cc.Invoke(ctx, TicketService_PurchaseFromTicketOption_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TicketServiceServer is the server API for TicketService service.
+// All implementations must embed UnimplementedTicketServiceServer
+// for forward compatibility
+type TicketServiceServer interface {
+	// CreateTicketOption creates a ticket option with an allocation of tickets available to purchase.
+	CreateTicketOption(context.Context, *CreateTicketOptionRequest) (*TicketOption, error)
+	// GetTicket returns the ticket option with the given id.
+	GetTicket(context.Context, *GetTicketRequest) (*TicketOption, error)
+	// ListTicketOptions returns ticket options ordered by id, one page at a time.
+	ListTicketOptions(context.Context, *ListTicketOptionsRequest) (*ListTicketOptionsResponse, error)
+	// PurchaseFromTicketOption purchases a quantity of tickets from the allocation of a ticket option.
+	PurchaseFromTicketOption(context.Context, *PurchaseFromTicketOptionRequest) (*PurchaseFromTicketOptionResponse, error)
+	mustEmbedUnimplementedTicketServiceServer()
+}
+
+// UnimplementedTicketServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTicketServiceServer struct {
+}
+
+func (UnimplementedTicketServiceServer) CreateTicketOption(context.Context, *CreateTicketOptionRequest) (*TicketOption, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTicketOption not implemented")
+}
+func (UnimplementedTicketServiceServer) GetTicket(context.Context, *GetTicketRequest) (*TicketOption, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTicket not implemented")
+}
+func (UnimplementedTicketServiceServer) ListTicketOptions(context.Context, *ListTicketOptionsRequest) (*ListTicketOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTicketOptions not implemented")
+}
+func (UnimplementedTicketServiceServer) PurchaseFromTicketOption(context.Context, *PurchaseFromTicketOptionRequest) (*PurchaseFromTicketOptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseFromTicketOption not implemented")
+}
+func (UnimplementedTicketServiceServer) mustEmbedUnimplementedTicketServiceServer() {}
+
+// UnsafeTicketServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TicketServiceServer will
+// result in compilation errors.
+type UnsafeTicketServiceServer interface {
+	mustEmbedUnimplementedTicketServiceServer()
+}
+
+func RegisterTicketServiceServer(s grpc.ServiceRegistrar, srv TicketServiceServer) {
+	s.RegisterService(&TicketService_ServiceDesc, srv)
+}
+
+func _TicketService_CreateTicketOption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTicketOptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).CreateTicketOption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_CreateTicketOption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).CreateTicketOption(ctx, req.(*CreateTicketOptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_GetTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).GetTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_GetTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).GetTicket(ctx, req.(*GetTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ListTicketOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTicketOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ListTicketOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_ListTicketOptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ListTicketOptions(ctx, req.(*ListTicketOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_PurchaseFromTicketOption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseFromTicketOptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).PurchaseFromTicketOption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketService_PurchaseFromTicketOption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).PurchaseFromTicketOption(ctx, req.(*PurchaseFromTicketOptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TicketService_ServiceDesc is the grpc.ServiceDesc for TicketService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TicketService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ticket.v1.TicketService",
+	HandlerType: (*TicketServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTicketOption",
+			Handler:    _TicketService_CreateTicketOption_Handler,
+		},
+		{
+			MethodName: "GetTicket",
+			Handler:    _TicketService_GetTicket_Handler,
+		},
+		{
+			MethodName: "ListTicketOptions",
+			Handler:    _TicketService_ListTicketOptions_Handler,
+		},
+		{
+			MethodName: "PurchaseFromTicketOption",
+			Handler:    _TicketService_PurchaseFromTicketOption_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ticket/v1/ticket.proto",
+}
@@ -0,0 +1,38 @@
+// Command ticketctl operates the ticket service from the command line, against the database the
+// API uses. Run it without arguments to see its commands.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/database"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketctl"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// The environment may be set without a .env.dev, as in production.
+	_ = godotenv.Load(".env.dev")
+
+	connectionPool, err := database.Setup()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticketSvc := service.NewDefaultService(repository.NewDefaultRepository(connectionPool))
+	cli := ticketctl.New(ticketSvc, os.Stdout)
+
+	if err = cli.Run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
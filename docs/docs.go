@@ -1,5 +1,5 @@
-// Package docs GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
@@ -19,20 +19,163 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/ticket/{id}": {
+        "/audit": {
             "get": {
-                "description": "Get specified ticket with ID from available tickets",
+                "description": "List the audit log oldest first. Pass the id of the last item as after_id to get the next page",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ticket"
+                    "audit"
                 ],
-                "summary": "Get ticket by ticket id",
+                "summary": "List audit entries",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target as kind:id, or a kind alone such as ticket_option",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who made the changes",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Made at or after, in RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Made before, in RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Ticket ID",
+                        "description": "Page size, defaults to 50, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return audit entries with a greater id",
+                        "name": "after_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ticket.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkins": {
+            "post": {
+                "description": "Mark an issued ticket as used at a gate. A ticket can only be checked in once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkin"
+                ],
+                "summary": "Check in a ticket",
+                "parameters": [
+                    {
+                        "description": "Checkin Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CheckinRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.IssuedTicket"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or forged code",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown code",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Already used",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Refunded",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Ticket for another event",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/checkins/stats": {
+            "get": {
+                "description": "Count issued, checked in and not yet arrived tickets of an event, with check-ins per gate",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkin"
+                ],
+                "summary": "Get attendance of an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event (ticket option) ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -42,7 +185,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ticket.Ticket"
+                            "$ref": "#/definitions/ticket.CheckinStats"
                         }
                     },
                     "400": {
@@ -66,9 +209,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/ticket_options": {
+        "/issued_tickets/signing_key": {
+            "get": {
+                "description": "Get the Ed25519 public key (base64) that verifies issued ticket codes offline",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issued_ticket"
+                ],
+                "summary": "Get the ticket code signing key",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SigningKeyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/issued_tickets/{code}/qr.png": {
+            "get": {
+                "description": "Render the code of an issued ticket as a PNG QR code",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "issued_ticket"
+                ],
+                "summary": "Get the QR code of an issued ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Issued ticket code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/issued_tickets/{code}/resale_listings": {
             "post": {
-                "description": "Create a ticket_option with an allocation of tickets available to purchase",
+                "description": "Offer an issued ticket to other users. The price may not exceed the resale cap on the price the ticket was bought at",
                 "consumes": [
                     "application/json"
                 ],
@@ -76,17 +277,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ticket"
+                    "resale"
                 ],
-                "summary": "Create Ticket Option",
+                "summary": "List an issued ticket for resale",
                 "parameters": [
                     {
-                        "description": "Create Ticket Option Request Body",
+                        "type": "string",
+                        "description": "Issued ticket code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resale Listing Request Body",
                         "name": "requestBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateTicketOptionRequestBody"
+                            "$ref": "#/definitions/handler.CreateResaleListingRequestBody"
                         }
                     }
                 ],
@@ -94,7 +302,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/ticket.Ticket"
+                            "$ref": "#/definitions/ticket.ResaleListing"
                         }
                     },
                     "400": {
@@ -103,6 +311,36 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Already used or listed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Refunded",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Price above cap",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -112,40 +350,108 @@ const docTemplate = `{
                 }
             }
         },
-        "/ticket_options/{id}/purchases": {
+        "/issued_tickets/{code}/transfer": {
             "post": {
-                "description": "Purchase a quantity of tickets from the allocation of the given ticket_option",
+                "description": "Give an issued ticket to another user. The old code stops working and the ticket is returned with its new code",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "ticket"
+                    "issued_ticket"
                 ],
-                "summary": "Purchase from Ticket Option",
+                "summary": "Transfer an issued ticket",
                 "parameters": [
                     {
-                        "description": "Purchase Ticket Option Request Body",
+                        "type": "string",
+                        "description": "Issued ticket code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer Request Body",
                         "name": "requestBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreatePurchaseTicketOptionRequestBody"
+                            "$ref": "#/definitions/handler.TransferIssuedTicketRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.IssuedTicket"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Already used or too close to the event",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Refunded",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/issued_tickets/{code}/transfers": {
+            "get": {
+                "description": "List every change of hands of the ticket currently holding the code, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issued_ticket"
+                ],
+                "summary": "Get the transfer history of an issued ticket",
+                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Ticket ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Issued ticket code",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ticket.TicketTransfer"
+                            }
+                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "string"
                         }
@@ -158,13 +464,2405 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.CreatePurchaseTicketOptionRequestBody": {
-            "type": "object",
-            "properties": {
-                "quantity": {
+        },
+        "/orders": {
+            "post": {
+                "description": "Place a pending order for tickets of several ticket options at once. Either every item is held off its allocation or none is, until the order is paid, cancelled or expires",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Place an order",
+                "parameters": [
+                    {
+                        "description": "Order Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateOrderRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "An item is outside its sale window",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Get an order with its items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/cancel": {
+            "post": {
+                "description": "Cancel a pending order and give its tickets back to their ticket options",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Cancel an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not pending",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/pay": {
+            "post": {
+                "description": "Mark a pending order as paid and issue the tickets of all its items, unless it has expired",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Pay an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not pending or has expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/refund": {
+            "post": {
+                "description": "Refund a paid order, voiding its tickets and giving them back to their ticket options",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Refund an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not paid",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchases/{id}/refund": {
+            "post": {
+                "description": "Refund the given purchase and return its tickets to the ticket_option allocation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ticket"
+                ],
+                "summary": "Refund a purchase",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Purchase"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchases/{id}/tickets": {
+            "get": {
+                "description": "List the individual tickets issued for a purchase, one per purchased unit, with their codes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issued_ticket"
+                ],
+                "summary": "Get the tickets of a purchase",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ticket.IssuedTicket"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/sales": {
+            "get": {
+                "description": "Quantity sold, revenue, refunds, remaining allocation and sell-through of each ticket_option, over the\nwhole period or split by day or hour in UTC. Sales and refunds count when they were made. The report is\nstreamed as it is read, as a JSON array or as CSV with a header row",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Sales report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Report only this ticket option",
+                        "name": "ticket_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sales and refunds made at or after, in RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sales and refunds made before, in RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day or hour to split the report by",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ticket.SalesRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/resale_listings/{id}/cancel": {
+            "post": {
+                "description": "Take a listing down. Only its seller can",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resale"
+                ],
+                "summary": "Cancel a resale listing",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Resale listing ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancel Resale Listing Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CancelResaleListingRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.ResaleListing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/resale_listings/{id}/purchase": {
+            "post": {
+                "description": "Buy a listed ticket. It is transferred to the buyer under a new code, returned in the listing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resale"
+                ],
+                "summary": "Buy a resale listing",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Resale listing ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Buy Resale Listing Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.BuyResaleListingRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.ResaleListing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "No longer available or too close to the event",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Refunded",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket/{id}": {
+            "get": {
+                "description": "Get specified ticket with ID, with its capacity and how many of it are sold, held and available",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ticket"
+                ],
+                "summary": "Get ticket by ticket id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the ticket the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Ticket"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options": {
+            "get": {
+                "description": "List ticket_options ordered by id. Pass the id of the last item as after_id to get the next page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ticket"
+                ],
+                "summary": "List Ticket Options",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, defaults to 50, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return ticket options with a greater id",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return only ticket options on sale now",
+                        "name": "on_sale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ticket.Ticket"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a ticket_option with an allocation of tickets available to purchase",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ticket"
+                ],
+                "summary": "Create Ticket Option",
+                "parameters": [
+                    {
+                        "description": "Create Ticket Option Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTicketOptionRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Ticket"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/availability/stream": {
+            "get": {
+                "description": "Server-Sent Events stream of the remaining allocation of a ticket_option. The current allocation is sent first,\nthen one \"availability\" event per purchase, refund or expired hold. Reconnecting clients sending Last-Event-ID receive\nthe updates they missed. A comment line is sent as heartbeat while idle.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "ticket"
+                ],
+                "summary": "Stream ticket availability",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/best_available": {
+            "post": {
+                "description": "Hold the given quantity of seats side by side in one row, closest to the best point of the seat map. Held seats can be purchased by their holder only until the hold expires",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seating"
+                ],
+                "summary": "Hold the best available seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket option ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Best Available Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.HoldBestAvailableRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.SeatHold"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Outside the sale window",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "No row has that many seats available together",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/ledger": {
+            "get": {
+                "description": "List every change of the tickets left of a ticket option oldest first. Pass the id of the last item as after_id to get the next page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ticket_options"
+                ],
+                "summary": "List allocation ledger entries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket option ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, defaults to 50, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return ledger entries with a greater id",
+                        "name": "after_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ticket.LedgerEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/price": {
+            "get": {
+                "description": "Price a quantity of tickets as a purchase made now would be. The price is not held",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Quote the price of tickets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket option ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantity, 1 by default",
+                        "name": "quantity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.PriceQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/price_tiers": {
+            "post": {
+                "description": "Override the face value early-bird until a date, after a number of tickets sold or last-minute from a date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Add a price tier to a ticket option",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket option ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price Tier Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreatePriceTierRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.PriceTier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/price_tiers/{tier_id}": {
+            "delete": {
+                "tags": [
+                    "pricing"
+                ],
+                "summary": "Remove a price tier from a ticket option",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket option ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Price tier ID",
+                        "name": "tier_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/purchases": {
+            "post": {
+                "description": "Purchase a quantity of tickets from the allocation of the given ticket_option",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ticket"
+                ],
+                "summary": "Purchase from Ticket Option",
+                "parameters": [
+                    {
+                        "description": "Purchase Ticket Option Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreatePurchaseTicketOptionRequestBody"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Purchase"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Outside the sale window",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/resale_listings": {
+            "get": {
+                "description": "List the listings that can still be bought, cheapest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resale"
+                ],
+                "summary": "List the resale listings of a ticket option",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket option ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ticket.ResaleListing"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/seat_purchases": {
+            "post": {
+                "description": "Purchase all of the given seats or, if any of them is taken, none",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seating"
+                ],
+                "summary": "Purchase specific seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket option ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Seat Purchase Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PurchaseSeatsRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.Purchase"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Outside the sale window",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A seat is taken",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options/{id}/seats": {
+            "get": {
+                "description": "Get the seat map of a ticket option with the status of every seat",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seating"
+                ],
+                "summary": "Get the seats of a ticket option",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket option ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.SeatMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Lay out sections and rows of seats, up to 500 per row and 100000 in all. The ticket option then sells these seats only and its allocation becomes the number of seats",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seating"
+                ],
+                "summary": "Create the seat map of a ticket option",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket option ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Seat Map Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateSeatMapRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.SeatMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticket_options:bulk": {
+            "post": {
+                "description": "Create ticket_options from a JSON array of Create Ticket Option Request Bodies, or from CSV with a header\nrow naming the same fields. In all_or_nothing mode nothing is created unless every row can be, and the\nreport is answered with 422. In best_effort mode every row that can be created is",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ticket"
+                ],
+                "summary": "Create Ticket Options in Bulk",
+                "parameters": [
+                    {
+                        "description": "Ticket options to create",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.CreateTicketOptionRequestBody"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "all_or_nothing (default) or best_effort",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Some rows of a best_effort import failed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BulkResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BulkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "post": {
+                "description": "Subscribe a URL to the ticket lifecycle events of the organizer. Deliveries are signed with HMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" using the secret",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Create Webhook",
+                "parameters": [
+                    {
+                        "description": "Create Webhook Request Body",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateWebhookRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "List deliveries of the given webhook of the organizer, newest first, with every delivery attempt",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ticket.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "Queue the given delivery to be sent again, regardless of its current status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Redeliver a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/ticket.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BulkRowItem"
+                    }
+                }
+            }
+        },
+        "handler.BulkRowItem": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "ticket": {
+                    "$ref": "#/definitions/ticket.Ticket"
+                }
+            }
+        },
+        "handler.BuyResaleListingRequestBody": {
+            "type": "object",
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CancelResaleListingRequestBody": {
+            "type": "object",
+            "properties": {
+                "seller_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CheckinRequestBody": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "gate_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateOrderRequestBody": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OrderItemRequestBody"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreatePriceTierRequestBody": {
+            "type": "object",
+            "properties": {
+                "after_sold": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/ticket.PriceTierKind"
+                },
+                "price": {
+                    "description": "Price is in minor units of the currency.",
+                    "type": "integer"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreatePurchaseTicketOptionRequestBody": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateResaleListingRequestBody": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "description": "Price is in minor units of the currency.",
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateSeatMapRequestBody": {
+            "type": "object",
+            "properties": {
+                "best": {
+                    "$ref": "#/definitions/ticket.SeatPoint"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.SectionLayout"
+                    }
+                }
+            }
+        },
+        "handler.CreateTicketOptionRequestBody": {
+            "type": "object",
+            "properties": {
+                "allocation": {
+                    "type": "integer"
+                },
+                "desc": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price is the face value of one ticket in minor units of the currency.",
+                    "type": "integer"
+                },
+                "sale_ends_at": {
+                    "type": "string"
+                },
+                "sale_starts_at": {
+                    "description": "SaleStartsAt and SaleEndsAt are optional, in RFC 3339.",
+                    "type": "string"
+                },
+                "starts_at": {
+                    "description": "StartsAt is optional, in RFC 3339.",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateWebhookRequestBody": {
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.EventType"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HoldBestAvailableRequestBody": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OrderItemRequestBody": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "ticket_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.PurchaseSeatsRequestBody": {
+            "type": "object",
+            "properties": {
+                "seat_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SigningKeyResponse": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handler.TransferIssuedTicketRequestBody": {
+            "type": "object",
+            "properties": {
+                "to_user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.AuditAction": {
+            "type": "string",
+            "enum": [
+                "ticket_option.created",
+                "ticket_option.updated",
+                "ticket_option.allocation_adjusted",
+                "ticket_option.allocation_sharded",
+                "seat_map.created",
+                "seats.held",
+                "price_tier.created",
+                "price_tier.deleted",
+                "purchase.created",
+                "purchase.refunded",
+                "order.created",
+                "order.status_changed",
+                "issued_ticket.checked_in",
+                "issued_ticket.transferred",
+                "resale_listing.created",
+                "resale_listing.sold",
+                "resale_listing.cancelled",
+                "webhook.created",
+                "webhook_delivery.redelivered"
+            ],
+            "x-enum-varnames": [
+                "AuditTicketOptionCreated",
+                "AuditTicketOptionUpdated",
+                "AuditAllocationAdjusted",
+                "AuditAllocationSharded",
+                "AuditSeatMapCreated",
+                "AuditSeatsHeld",
+                "AuditPriceTierCreated",
+                "AuditPriceTierDeleted",
+                "AuditPurchaseCreated",
+                "AuditPurchaseRefunded",
+                "AuditOrderCreated",
+                "AuditOrderStatusChanged",
+                "AuditIssuedTicketCheckedIn",
+                "AuditIssuedTicketTransferred",
+                "AuditResaleListingCreated",
+                "AuditResaleListingSold",
+                "AuditResaleListingCancelled",
+                "AuditWebhookCreated",
+                "AuditWebhookDeliveryRedelivered"
+            ]
+        },
+        "ticket.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/ticket.AuditAction"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organizer_id": {
+                    "description": "OrganizerID is the organizer the change was made for, 0 for changes made outside of any.",
+                    "type": "integer"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.CheckinStats": {
+            "type": "object",
+            "properties": {
+                "by_gate": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "checked_in": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "issued": {
+                    "type": "integer"
+                },
+                "not_arrived": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ticket.EventType": {
+            "type": "string",
+            "enum": [
+                "TicketOptionCreated",
+                "TicketPurchased",
+                "TicketSoldOut",
+                "PurchaseRefunded",
+                "TicketTransferred",
+                "ResaleListingSold",
+                "OrderStatusChanged"
+            ],
+            "x-enum-varnames": [
+                "EventTicketOptionCreated",
+                "EventTicketPurchased",
+                "EventTicketSoldOut",
+                "EventPurchaseRefunded",
+                "EventTicketTransferred",
+                "EventResaleListingSold",
+                "EventOrderStatusChanged"
+            ]
+        },
+        "ticket.IssuedTicket": {
+            "type": "object",
+            "properties": {
+                "checked_in_at": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "gate_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "purchase_id": {
+                    "type": "integer"
+                },
+                "seat_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/ticket.IssuedTicketStatus"
+                },
+                "ticket_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.IssuedTicketStatus": {
+            "type": "string",
+            "enum": [
+                "valid",
+                "used",
+                "refunded"
+            ],
+            "x-enum-varnames": [
+                "IssuedTicketValid",
+                "IssuedTicketUsed",
+                "IssuedTicketRefunded"
+            ]
+        },
+        "ticket.LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delta": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/ticket.LedgerKind"
+                },
+                "organizer_id": {
+                    "type": "integer"
+                },
+                "purchase_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "ticket_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ticket.LedgerKind": {
+            "type": "string",
+            "enum": [
+                "initial",
+                "purchase",
+                "refund",
+                "hold",
+                "release",
+                "adjustment"
+            ],
+            "x-enum-varnames": [
+                "LedgerInitial",
+                "LedgerPurchase",
+                "LedgerRefund",
+                "LedgerHold",
+                "LedgerRelease",
+                "LedgerAdjustment"
+            ]
+        },
+        "ticket.Order": {
+            "type": "object",
+            "properties": {
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.OrderItem"
+                    }
+                },
+                "organizer_id": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/ticket.OrderStatus"
+                },
+                "total_price": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.OrderItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "purchase_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "ticket_id": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ticket.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "paid",
+                "cancelled",
+                "refunded"
+            ],
+            "x-enum-varnames": [
+                "OrderPending",
+                "OrderPaid",
+                "OrderCancelled",
+                "OrderRefunded"
+            ]
+        },
+        "ticket.PriceQuote": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "ticket_id": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unit_prices": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "ticket.PriceTier": {
+            "type": "object",
+            "properties": {
+                "after_sold": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/ticket.PriceTierKind"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "ticket_id": {
+                    "type": "integer"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.PriceTierKind": {
+            "type": "string",
+            "enum": [
+                "early_bird",
+                "sold_step",
+                "last_minute"
+            ],
+            "x-enum-varnames": [
+                "PriceTierEarlyBird",
+                "PriceTierSoldStep",
+                "PriceTierLastMinute"
+            ]
+        },
+        "ticket.Purchase": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issuedTickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.IssuedTicket"
+                    }
+                },
+                "orderID": {
+                    "description": "OrderID is set on the purchases that are the items of an order.",
+                    "type": "integer"
+                },
+                "organizerID": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "refundedAt": {
+                    "type": "string"
+                },
+                "ticketID": {
+                    "type": "integer"
+                },
+                "totalPrice": {
+                    "description": "TotalPrice is what the purchase was quoted at, in minor units, locked in when it was made.",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.ResaleListing": {
+            "type": "object",
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_ticket": {
+                    "$ref": "#/definitions/ticket.IssuedTicket"
+                },
+                "issued_ticket_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "sold_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/ticket.ResaleListingStatus"
+                },
+                "ticket_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.ResaleListingStatus": {
+            "type": "string",
+            "enum": [
+                "listed",
+                "sold",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "ResaleListingListed",
+                "ResaleListingSold",
+                "ResaleListingCancelled"
+            ]
+        },
+        "ticket.RowLayout": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "seats": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ticket.SalesRow": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "refunded": {
+                    "type": "integer"
+                },
+                "refunds": {
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "Remaining and Capacity are Ticket.Available and Ticket.Capacity now.",
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "integer"
+                },
+                "sell_through": {
+                    "description": "SellThrough is the percentage of Capacity the period sold net of refunds.",
+                    "type": "number"
+                },
+                "sold": {
+                    "description": "Sold and Refunded count tickets sold and refunded in the period.",
+                    "type": "integer"
+                },
+                "ticket_id": {
+                    "type": "integer"
+                },
+                "ticket_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.Seat": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "held_until": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "purchase_id": {
+                    "type": "integer"
+                },
+                "row": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/ticket.SeatStatus"
+                },
+                "ticket_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "integer"
+                },
+                "y": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ticket.SeatHold": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.Seat"
+                    }
+                },
+                "ticket_id": {
                     "type": "integer"
                 },
                 "user_id": {
@@ -172,34 +2870,285 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.CreateTicketOptionRequestBody": {
+        "ticket.SeatMap": {
             "type": "object",
             "properties": {
-                "allocation": {
+                "available": {
                     "type": "integer"
                 },
-                "desc": {
+                "best": {
+                    "$ref": "#/definitions/ticket.SeatPoint"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.SeatSection"
+                    }
+                },
+                "ticket_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ticket.SeatPoint": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "integer"
+                },
+                "y": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ticket.SeatRow": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.Seat"
+                    }
+                }
+            }
+        },
+        "ticket.SeatSection": {
+            "type": "object",
+            "properties": {
+                "name": {
                     "type": "string"
                 },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.SeatRow"
+                    }
+                }
+            }
+        },
+        "ticket.SeatStatus": {
+            "type": "string",
+            "enum": [
+                "available",
+                "held",
+                "sold"
+            ],
+            "x-enum-varnames": [
+                "SeatAvailable",
+                "SeatHeld",
+                "SeatSold"
+            ]
+        },
+        "ticket.SectionLayout": {
+            "type": "object",
+            "properties": {
                 "name": {
                     "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.RowLayout"
+                    }
                 }
             }
         },
         "ticket.Ticket": {
             "type": "object",
             "properties": {
-                "allocation": {
+                "available": {
+                    "type": "integer"
+                },
+                "capacity": {
+                    "description": "Capacity is how many tickets the ticket option has in all, of which Sold are sold, Held are\nset aside for buyers and Available are left, so that Available = Capacity - Sold - Held.\nAvailable caches what the ledger entries of the ticket option add up to.",
                     "type": "integer"
                 },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
                 "desc": {
                     "type": "string"
                 },
+                "held": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
+                },
+                "organizer_id": {
+                    "description": "OrganizerID owns the ticket option. Ticket options made before there were organizers belong to\nDefaultOrganizerID. Names are unique per organizer.",
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "Price is the face value of one ticket in minor units of the currency.",
+                    "type": "integer"
+                },
+                "price_tiers": {
+                    "description": "PriceTiers override Price while they apply.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.PriceTier"
+                    }
+                },
+                "sale_ends_at": {
+                    "type": "string"
+                },
+                "sale_starts_at": {
+                    "description": "SaleStartsAt and SaleEndsAt bound when the ticket option can be purchased, from SaleStartsAt up to\nbut not including SaleEndsAt. Either may be nil to leave that side of the window open.",
+                    "type": "string"
+                },
+                "seated": {
+                    "description": "Seated ticket options sell the seats of their seat map rather than general admission.",
+                    "type": "boolean"
+                },
+                "shards": {
+                    "description": "Shards is how many AllocationShard the available tickets are split across for purchases to\ntake from concurrently, or 0 when they are all counted here.",
+                    "type": "integer"
+                },
+                "sold": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "description": "StartsAt is when the event admitting this ticket option begins, if scheduled.",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.TicketTransfer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "from_user_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_ticket_id": {
+                    "type": "integer"
+                },
+                "to_user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ticket.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempt_log": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.WebhookDeliveryAttempt"
+                    }
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "event_type": {
+                    "$ref": "#/definitions/ticket.EventType"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/ticket.WebhookDeliveryStatus"
+                },
+                "subscription_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ticket.WebhookDeliveryAttempt": {
+            "type": "object",
+            "properties": {
+                "attempted_at": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ticket.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "WebhookDeliveryPending",
+                "WebhookDeliverySucceeded",
+                "WebhookDeliveryFailed"
+            ]
+        },
+        "ticket.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ticket.EventType"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organizer_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         }
@@ -0,0 +1,57 @@
+package ticket
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditTicketOptionCreated        AuditAction = "ticket_option.created"
+	AuditTicketOptionUpdated        AuditAction = "ticket_option.updated"
+	AuditAllocationAdjusted         AuditAction = "ticket_option.allocation_adjusted"
+	AuditAllocationSharded          AuditAction = "ticket_option.allocation_sharded"
+	AuditSeatMapCreated             AuditAction = "seat_map.created"
+	AuditSeatsHeld                  AuditAction = "seats.held"
+	AuditPriceTierCreated           AuditAction = "price_tier.created"
+	AuditPriceTierDeleted           AuditAction = "price_tier.deleted"
+	AuditPurchaseCreated            AuditAction = "purchase.created"
+	AuditPurchaseRefunded           AuditAction = "purchase.refunded"
+	AuditOrderCreated               AuditAction = "order.created"
+	AuditOrderStatusChanged         AuditAction = "order.status_changed"
+	AuditIssuedTicketCheckedIn      AuditAction = "issued_ticket.checked_in"
+	AuditIssuedTicketTransferred    AuditAction = "issued_ticket.transferred"
+	AuditResaleListingCreated       AuditAction = "resale_listing.created"
+	AuditResaleListingSold          AuditAction = "resale_listing.sold"
+	AuditResaleListingCancelled     AuditAction = "resale_listing.cancelled"
+	AuditWebhookCreated             AuditAction = "webhook.created"
+	AuditWebhookDeliveryRedelivered AuditAction = "webhook_delivery.redelivered"
+)
+
+// AuditEntry records one change: who made it, in which request, to what, and the fields it changed.
+// Target reads kind:id, e.g. ticket_option:12. Before and After hold the changed fields only; Before
+// is empty for what was created and After for what was deleted. Entries are never updated or deleted.
+type AuditEntry struct {
+	ID int `gorm:"primaryKey" json:"id"`
+	// OrganizerID is the organizer the change was made for, 0 for changes made outside of any.
+	OrganizerID int             `gorm:"not null;default:0;index" json:"organizer_id"`
+	Actor       string          `gorm:"not null;index" json:"actor"`
+	Action      AuditAction     `gorm:"not null" json:"action"`
+	Target      string          `gorm:"not null;index" json:"target"`
+	Before      json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After       json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	RequestID   string          `json:"request_id,omitempty"`
+	CreatedAt   time.Time       `gorm:"not null;index" json:"created_at"`
+}
+
+// AuditFilter selects a page of audit entries ordered by id. Target matches a whole target, or every
+// target of a kind when it has no id. From and To bound CreatedAt, To excluded.
+type AuditFilter struct {
+	Target  string
+	Actor   string
+	From    *time.Time
+	To      *time.Time
+	Limit   int
+	AfterID int
+}
@@ -0,0 +1,101 @@
+// Package audit carries who makes a change through the context and computes what the change was.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// SystemActor is the actor of changes made without a caller, such as by background jobs.
+const SystemActor = "system"
+
+// Origin is who made a request and how to find it in the logs.
+type Origin struct {
+	Actor     string
+	RequestID string
+}
+
+type originKey struct{}
+
+// WithOrigin returns a copy of ctx carrying origin.
+func WithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+// OriginFrom returns the origin carried by ctx. The actor is SystemActor if none is carried.
+func OriginFrom(ctx context.Context) Origin {
+	origin, _ := ctx.Value(originKey{}).(Origin)
+	if origin.Actor == "" {
+		origin.Actor = SystemActor
+	}
+
+	return origin
+}
+
+// Diff returns the top-level JSON fields that differ between before and after, as they were and
+// as they are. A nil before or after stands for something that did not exist, so all the fields of
+// the other one are returned. Either result is nil when it has no fields.
+func Diff(before, after interface{}) (json.RawMessage, json.RawMessage, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changedBefore := map[string]json.RawMessage{}
+	for key, value := range beforeFields {
+		if other, ok := afterFields[key]; !ok || !bytes.Equal(value, other) {
+			changedBefore[key] = value
+		}
+	}
+
+	changedAfter := map[string]json.RawMessage{}
+	for key, value := range afterFields {
+		if other, ok := beforeFields[key]; !ok || !bytes.Equal(value, other) {
+			changedAfter[key] = value
+		}
+	}
+
+	beforeJSON, err := encode(changedBefore)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	afterJSON, err := encode(changedAfter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return beforeJSON, afterJSON, nil
+}
+
+func fields(v interface{}) (map[string]json.RawMessage, error) {
+	result := map[string]json.RawMessage{}
+	if v == nil {
+		return result, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func encode(changed map[string]json.RawMessage) (json.RawMessage, error) {
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(changed)
+}
@@ -0,0 +1,103 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Keep_Only_Changed_Fields_When_Diff(t *testing.T) {
+	type record struct {
+		Status string `json:"status"`
+		UserID string `json:"user_id"`
+		Count  int    `json:"count"`
+	}
+
+	type testCase struct {
+		name           string
+		before         interface{}
+		after          interface{}
+		expectedBefore string
+		expectedAfter  string
+	}
+
+	testCases := []testCase{
+		{
+			name:           "changed fields only",
+			before:         record{Status: "valid", UserID: "a", Count: 1},
+			after:          record{Status: "checked_in", UserID: "a", Count: 1},
+			expectedBefore: `{"status":"valid"}`,
+			expectedAfter:  `{"status":"checked_in"}`,
+		},
+		{
+			name:          "created",
+			before:        nil,
+			after:         record{Status: "valid", UserID: "a"},
+			expectedAfter: `{"status":"valid","user_id":"a","count":0}`,
+		},
+		{
+			name:           "deleted",
+			before:         map[string]interface{}{"id": 1},
+			after:          nil,
+			expectedBefore: `{"id":1}`,
+		},
+		{
+			name:   "unchanged",
+			before: record{Status: "valid"},
+			after:  record{Status: "valid"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// When
+			before, after, err := audit.Diff(test.before, test.after)
+
+			// Then
+			assert.Nil(t, err)
+			assertJSON(t, test.expectedBefore, before)
+			assertJSON(t, test.expectedAfter, after)
+		})
+	}
+}
+
+func Test_Should_Return_Error_When_Diff_Is_Not_An_Object(t *testing.T) {
+	// When
+	_, _, err := audit.Diff(nil, []int{1})
+
+	// Then
+	assert.NotNil(t, err)
+}
+
+func Test_Should_Carry_Origin_Through_Context(t *testing.T) {
+	// Given
+	ctx := audit.WithOrigin(context.TODO(), audit.Origin{Actor: "admin", RequestID: "request-1"})
+
+	// When
+	origin := audit.OriginFrom(ctx)
+
+	// Then
+	assert.Equal(t, audit.Origin{Actor: "admin", RequestID: "request-1"}, origin)
+}
+
+func Test_Should_Fall_Back_To_System_Actor_When_Context_Has_No_Origin(t *testing.T) {
+	// When
+	origin := audit.OriginFrom(context.TODO())
+
+	// Then
+	assert.Equal(t, audit.Origin{Actor: audit.SystemActor}, origin)
+}
+
+func assertJSON(t *testing.T, expected string, actual json.RawMessage) {
+	t.Helper()
+
+	if expected == "" {
+		assert.Nil(t, actual)
+		return
+	}
+
+	assert.JSONEq(t, expected, string(actual))
+}
@@ -0,0 +1,138 @@
+package availability
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+// Update is the remaining allocation of a ticket option at a point in time. ID increases with every
+// update across all ticket options and is used as the SSE event id.
+type Update struct {
+	ID         uint64    `json:"-"`
+	TicketID   int       `json:"ticket_id"`
+	Allocation int       `json:"allocation"`
+	At         time.Time `json:"at"`
+}
+
+type topic struct {
+	subscribers map[chan Update]struct{}
+	history     []Update
+	// evictedUpTo is the id of the newest update dropped from history; a client that
+	// has seen it can be caught up from history alone.
+	evictedUpTo uint64
+}
+
+// Broadcaster fans availability updates out to in-process subscribers and keeps a short
+// per-ticket history so reconnecting clients can resume from their Last-Event-ID.
+type Broadcaster struct {
+	mu          sync.Mutex
+	seq         uint64
+	topics      map[int]*topic
+	historySize int
+	now         func() time.Time
+}
+
+func NewBroadcaster(historySize int) *Broadcaster {
+	return &Broadcaster{
+		topics:      map[int]*topic{},
+		historySize: historySize,
+		now:         time.Now,
+	}
+}
+
+// NotifyAvailability implements service.AvailabilityNotifier.
+func (b *Broadcaster) NotifyAvailability(t ticket.Ticket) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	update := Update{ID: b.seq, TicketID: t.ID, Allocation: t.Available, At: b.now()}
+
+	tp := b.topic(t.ID)
+	tp.history = append(tp.history, update)
+	if len(tp.history) > b.historySize {
+		tp.evictedUpTo = tp.history[0].ID
+		tp.history = tp.history[1:]
+	}
+
+	for ch := range tp.subscribers {
+		// Every update carries the absolute allocation, so a slow subscriber only needs the latest one.
+		select {
+		case ch <- update:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- update
+		}
+	}
+}
+
+// Subscription is a live feed of updates for one ticket option.
+type Subscription struct {
+	// Replay holds the updates missed since the requested Last-Event-ID. It is only
+	// meaningful when Resumed is true.
+	Replay []Update
+	// Resumed reports whether Replay covers everything since the requested Last-Event-ID.
+	// When false the caller should send a fresh snapshot instead.
+	Resumed bool
+	// LastID is the id of the newest update at subscription time; use it as the id of a snapshot.
+	LastID  uint64
+	Updates <-chan Update
+
+	unsubscribe func()
+}
+
+func (s *Subscription) Close() {
+	s.unsubscribe()
+}
+
+// Subscribe starts a feed for ticketID. lastEventID is the Last-Event-ID sent by a reconnecting
+// client, or zero for a fresh connection.
+func (b *Broadcaster) Subscribe(ticketID int, lastEventID uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Update, 1)
+	tp := b.topic(ticketID)
+	tp.subscribers[ch] = struct{}{}
+
+	subscription := &Subscription{
+		LastID:  b.seq,
+		Updates: ch,
+		unsubscribe: func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(tp.subscribers, ch)
+			// A topic nothing was ever published to has nothing to replay, and may be of a ticket
+			// option that does not exist, so it is not kept once its last subscriber leaves.
+			if len(tp.subscribers) == 0 && len(tp.history) == 0 && b.topics[ticketID] == tp {
+				delete(b.topics, ticketID)
+			}
+		},
+	}
+
+	if lastEventID > 0 && lastEventID <= b.seq && lastEventID >= tp.evictedUpTo {
+		subscription.Resumed = true
+		for _, update := range tp.history {
+			if update.ID > lastEventID {
+				subscription.Replay = append(subscription.Replay, update)
+			}
+		}
+	}
+
+	return subscription
+}
+
+func (b *Broadcaster) topic(ticketID int) *topic {
+	tp, ok := b.topics[ticketID]
+	if !ok {
+		tp = &topic{subscribers: map[chan Update]struct{}{}}
+		b.topics[ticketID] = tp
+	}
+
+	return tp
+}
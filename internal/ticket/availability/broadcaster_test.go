@@ -0,0 +1,111 @@
+package availability_test
+
+import (
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/availability"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Deliver_Updates_Only_To_Subscribers_Of_The_Ticket(t *testing.T) {
+	// Given
+	broadcaster := availability.NewBroadcaster(10)
+	subscription := broadcaster.Subscribe(1, 0)
+	defer subscription.Close()
+	other := broadcaster.Subscribe(2, 0)
+	defer other.Close()
+
+	// When
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 40})
+
+	// Then
+	update := <-subscription.Updates
+	assert.Equal(t, uint64(1), update.ID)
+	assert.Equal(t, 1, update.TicketID)
+	assert.Equal(t, 40, update.Allocation)
+	assert.Len(t, other.Updates, 0)
+}
+
+func Test_Should_Keep_Only_Latest_Update_For_Slow_Subscriber(t *testing.T) {
+	// Given
+	broadcaster := availability.NewBroadcaster(10)
+	subscription := broadcaster.Subscribe(1, 0)
+	defer subscription.Close()
+
+	// When
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 40})
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 30})
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 20})
+
+	// Then
+	update := <-subscription.Updates
+	assert.Equal(t, 20, update.Allocation)
+	assert.Len(t, subscription.Updates, 0)
+}
+
+func Test_Should_Replay_Missed_Updates_When_Resuming_From_Last_Event_ID(t *testing.T) {
+	// Given
+	broadcaster := availability.NewBroadcaster(3)
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 50}) // id 1
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 2, Available: 9})  // id 2
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 45}) // id 3
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 40}) // id 4
+
+	t.Run("Test_Should_Replay_Updates_After_Last_Event_ID", func(t *testing.T) {
+		// When
+		subscription := broadcaster.Subscribe(1, 1)
+		defer subscription.Close()
+
+		// Then
+		assert.True(t, subscription.Resumed)
+		assert.Equal(t, uint64(4), subscription.LastID)
+		assert.Len(t, subscription.Replay, 2)
+		assert.Equal(t, 45, subscription.Replay[0].Allocation)
+		assert.Equal(t, 40, subscription.Replay[1].Allocation)
+	})
+
+	t.Run("Test_Should_Not_Resume_When_Last_Event_ID_Is_Unknown", func(t *testing.T) {
+		// When
+		subscription := broadcaster.Subscribe(1, 99)
+		defer subscription.Close()
+
+		// Then
+		assert.False(t, subscription.Resumed)
+	})
+
+	t.Run("Test_Should_Not_Resume_When_Missed_Updates_Were_Evicted", func(t *testing.T) {
+		// Given
+		broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 35}) // id 5, evicts id 1
+		broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 30}) // id 6, evicts id 3
+
+		// When
+		evicted := broadcaster.Subscribe(1, 2)
+		defer evicted.Close()
+		retained := broadcaster.Subscribe(1, 3)
+		defer retained.Close()
+
+		// Then
+		assert.False(t, evicted.Resumed)
+		assert.True(t, retained.Resumed)
+		assert.Len(t, retained.Replay, 3)
+	})
+}
+
+func Test_Should_Drop_Topic_Without_Updates_When_Last_Subscriber_Leaves(t *testing.T) {
+	// Given
+	broadcaster := availability.NewBroadcaster(10)
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 40})
+	first := broadcaster.Subscribe(404, 0)
+	second := broadcaster.Subscribe(404, 0)
+	published := broadcaster.Subscribe(1, 0)
+
+	// When
+	first.Close()
+	assert.Equal(t, 2, broadcaster.Topics())
+	second.Close()
+	published.Close()
+
+	// Then
+	assert.Equal(t, 1, broadcaster.Topics())
+}
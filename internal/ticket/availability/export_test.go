@@ -0,0 +1,9 @@
+package availability
+
+// Topics returns how many ticket options b keeps a topic for.
+func (b *Broadcaster) Topics() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.topics)
+}
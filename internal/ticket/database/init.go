@@ -11,25 +11,166 @@ import (
 
 var db *gorm.DB
 
+// Setup connects to the database of DATABASE_DRIVER, which is postgres unless it is sqlite. The
+// primary Postgres database is waited for up to POSTGRES_CONNECT_TIMEOUT, with the pool sized as
+// poolConfigFromEnv reads.
 func Setup() (*gorm.DB, error) {
+	var err error
+
+	switch os.Getenv("DATABASE_DRIVER") {
+	case "", DriverPostgres:
+	case DriverSQLite:
+		db, err = openSQLite()
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrSettingIsInvalid, "DATABASE_DRIVER")
+	}
+
+	pool, err := poolConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, err := durationFromEnv("POSTGRES_CONNECT_TIMEOUT", DefaultConnectTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err = connect(os.Getenv("POSTGRES_HOST"), os.Getenv("POSTGRES_PORT"), pool, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func open(host, port string, pool poolConfig, config *gorm.Config) (*gorm.DB, error) {
 	constr := fmt.Sprintf(
 		"host=%s port=%s  user=%s password=%s dbname=%s sslmode=disable",
-		os.Getenv("POSTGRES_HOST"),
-		os.Getenv("POSTGRES_PORT"),
+		host,
+		port,
 		os.Getenv("POSTGRES_USER"),
 		os.Getenv("POSTGRES_PASSWORD"),
 		os.Getenv("POSTGRES_DB"))
 
-	var err error
-	db, err = gorm.Open(postgres.Open(constr), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(constr), config)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = pool.apply(db); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
 
+// Migrate brings the schema up to date. SQLite databases were never made with an older schema, so
+// only Postgres ones are upgraded from those.
 func Migrate() {
-	db.AutoMigrate(&ticket.Ticket{})   //nolint:errcheck
-	db.AutoMigrate(&ticket.Purchase{}) //nolint:errcheck
+	postgres := db.Dialector.Name() == DriverPostgres
+
+	// allocation used to be checked with allocation>0, which made it impossible to sell the last ticket.
+	if postgres && db.Migrator().HasConstraint(&ticket.Ticket{}, "chk_tickets_allocation") {
+		db.Migrator().DropConstraint(&ticket.Ticket{}, "chk_tickets_allocation") //nolint:errcheck
+	}
+
+	// Everything made before there were organizers belongs to the default one, and ticket names are
+	// unique per organizer instead of across all of them. The default organizer has no token until
+	// "ticketctl organizers issue-token" issues one.
+	db.AutoMigrate(&ticket.Organizer{})                                                 //nolint:errcheck
+	db.FirstOrCreate(&ticket.Organizer{ID: ticket.DefaultOrganizerID, Name: "default"}) //nolint:errcheck
+	if postgres {
+		db.Exec("SELECT setval(pg_get_serial_sequence('organizers', 'id'), MAX(id)) FROM organizers") //nolint:errcheck
+		db.Exec("ALTER TABLE IF EXISTS tickets DROP CONSTRAINT IF EXISTS tickets_name_key")           //nolint:errcheck
+	}
+
+	// allocation was both what ticket options had in all and what they had left. It is what they
+	// have left, and capacity, sold and held are counted next to it.
+	if postgres && db.Migrator().HasColumn(&ticket.Ticket{}, "allocation") {
+		db.Migrator().RenameColumn(&ticket.Ticket{}, "allocation", "available")                      //nolint:errcheck
+		db.Exec("ALTER TABLE tickets DROP CONSTRAINT IF EXISTS chk_tickets_allocation_non_negative") //nolint:errcheck
+	}
+
+	db.AutoMigrate(&ticket.Ticket{})                 //nolint:errcheck
+	db.AutoMigrate(&ticket.AllocationShard{})        //nolint:errcheck
+	db.AutoMigrate(&ticket.PriceTier{})              //nolint:errcheck
+	db.AutoMigrate(&ticket.Order{})                  //nolint:errcheck
+	db.AutoMigrate(&ticket.OrderItem{})              //nolint:errcheck
+	db.AutoMigrate(&ticket.Purchase{})               //nolint:errcheck
+	db.AutoMigrate(&ticket.Seat{})                   //nolint:errcheck
+	db.AutoMigrate(&ticket.IssuedTicket{})           //nolint:errcheck
+	db.AutoMigrate(&ticket.TicketTransfer{})         //nolint:errcheck
+	db.AutoMigrate(&ticket.ResaleListing{})          //nolint:errcheck
+	db.AutoMigrate(&ticket.OutboxMessage{})          //nolint:errcheck
+	db.AutoMigrate(&ticket.WebhookSubscription{})    //nolint:errcheck
+	db.AutoMigrate(&ticket.WebhookDelivery{})        //nolint:errcheck
+	db.AutoMigrate(&ticket.WebhookDeliveryAttempt{}) //nolint:errcheck
+	db.AutoMigrate(&ticket.AuditEntry{})             //nolint:errcheck
+	db.AutoMigrate(&ticket.LedgerEntry{})            //nolint:errcheck
+
+	// The audit log and the ledger are append-only, whoever connects to the database.
+	if !postgres {
+		for _, table := range []string{"audit_entries", "ledger_entries"} {
+			for _, trigger := range appendOnlySQLite(table) {
+				db.Exec(trigger) //nolint:errcheck
+			}
+		}
+		return
+	}
+
+	db.Exec(appendOnly("audit_entries"))  //nolint:errcheck
+	db.Exec(appendOnly("ledger_entries")) //nolint:errcheck
+
+	db.Exec(ledgerOpeningBalances) //nolint:errcheck
+	db.Exec(ticketCounters)        //nolint:errcheck
+}
+
+func appendOnly(table string) string {
+	return fmt.Sprintf(`
+CREATE OR REPLACE FUNCTION %[1]s_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION '%[1]s is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS %[1]s_append_only ON %[1]s;
+CREATE TRIGGER %[1]s_append_only BEFORE UPDATE OR DELETE ON %[1]s
+	FOR EACH ROW EXECUTE FUNCTION %[1]s_append_only();
+`, table)
 }
+
+// ledgerOpeningBalances starts the ledger of the ticket options made before there was one with what
+// they had left then. Held seats used to stay in the allocation, so they are taken off it by a hold.
+const ledgerOpeningBalances = `
+WITH unledgered AS (
+	SELECT t.id, t.organizer_id, t.available,
+		(SELECT COUNT(*) FROM seats s WHERE s.ticket_id = t.id AND s.status = 'held') AS held
+	FROM tickets t
+	WHERE NOT EXISTS (SELECT 1 FROM ledger_entries l WHERE l.ticket_id = t.id)
+), opening AS (
+	INSERT INTO ledger_entries (organizer_id, ticket_id, kind, delta, reason, created_at)
+	SELECT organizer_id, id, 'initial', available, 'opening balance', now() FROM unledgered
+	UNION ALL
+	SELECT organizer_id, id, 'hold', -held, 'opening balance', now() FROM unledgered WHERE held > 0
+)
+UPDATE tickets t SET available = t.available - u.held FROM unledgered u WHERE t.id = u.id AND u.held > 0
+`
+
+// ticketCounters counts the sold and held tickets of the ticket options made before they were
+// counted, which had none, and gives them the capacity those and their available tickets make up.
+const ticketCounters = `
+WITH counted AS (
+	SELECT t.id,
+		(SELECT COALESCE(SUM(p.quantity), 0) FROM tickets_purchases p
+			WHERE p.ticket_id = t.id AND p.refunded_at IS NULL AND p.deleted_at IS NULL) AS sold,
+		(SELECT COUNT(*) FROM seats s WHERE s.ticket_id = t.id AND s.status = 'held') AS held
+	FROM tickets t
+	WHERE t.capacity = 0 AND t.sold = 0 AND t.held = 0
+)
+UPDATE tickets t SET sold = c.sold, held = c.held, capacity = t.available + c.sold + c.held
+FROM counted c WHERE t.id = c.id
+`
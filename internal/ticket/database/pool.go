@@ -0,0 +1,129 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultMaxOpenConns    = 25
+	DefaultMaxIdleConns    = 25
+	DefaultConnMaxLifetime = 30 * time.Minute
+	DefaultConnMaxIdleTime = 5 * time.Minute
+	// DefaultConnectTimeout is how long Setup keeps trying to connect to a database that is starting.
+	DefaultConnectTimeout = 30 * time.Second
+
+	initialConnectBackoff = 250 * time.Millisecond
+	maxConnectBackoff     = 5 * time.Second
+)
+
+var ErrSettingIsInvalid = errors.New("database setting is invalid")
+
+// poolConfig sizes the connection pool of a database, as database/sql does.
+type poolConfig struct {
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime time.Duration
+	connMaxIdleTime time.Duration
+}
+
+// poolConfigFromEnv reads POSTGRES_MAX_OPEN_CONNS, POSTGRES_MAX_IDLE_CONNS, POSTGRES_CONN_MAX_LIFETIME
+// and POSTGRES_CONN_MAX_IDLE_TIME, each of which defaults when it is not set.
+func poolConfigFromEnv() (poolConfig, error) {
+	config := poolConfig{
+		maxOpenConns:    DefaultMaxOpenConns,
+		maxIdleConns:    DefaultMaxIdleConns,
+		connMaxLifetime: DefaultConnMaxLifetime,
+		connMaxIdleTime: DefaultConnMaxIdleTime,
+	}
+
+	var err error
+	if config.maxOpenConns, err = intFromEnv("POSTGRES_MAX_OPEN_CONNS", config.maxOpenConns); err != nil {
+		return config, err
+	}
+	if config.maxIdleConns, err = intFromEnv("POSTGRES_MAX_IDLE_CONNS", config.maxIdleConns); err != nil {
+		return config, err
+	}
+	if config.connMaxLifetime, err = durationFromEnv("POSTGRES_CONN_MAX_LIFETIME", config.connMaxLifetime); err != nil {
+		return config, err
+	}
+	if config.connMaxIdleTime, err = durationFromEnv("POSTGRES_CONN_MAX_IDLE_TIME", config.connMaxIdleTime); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+func (p poolConfig) apply(db *gorm.DB) error {
+	conn, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	conn.SetMaxOpenConns(p.maxOpenConns)
+	conn.SetMaxIdleConns(p.maxIdleConns)
+	conn.SetConnMaxLifetime(p.connMaxLifetime)
+	conn.SetConnMaxIdleTime(p.connMaxIdleTime)
+
+	return nil
+}
+
+// connect opens the database at host:port, and tries again with exponential backoff until it
+// answers or timeout has passed, as it may still be starting next to the API.
+func connect(host, port string, pool poolConfig, timeout time.Duration) (*gorm.DB, error) {
+	deadline := time.Now().Add(timeout)
+	backoff := initialConnectBackoff
+
+	for {
+		db, err := open(host, port, pool, &gorm.Config{})
+		if err == nil {
+			return db, nil
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return nil, err
+		}
+
+		log.Warnf("could not connect to the database, trying again in %s: %v", backoff, err)
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
+	}
+}
+
+func intFromEnv(name string, fallback int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrSettingIsInvalid, name)
+	}
+
+	return n, nil
+}
+
+func durationFromEnv(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrSettingIsInvalid, name)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,60 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// Pool Unit Tests
+
+func Test_Should_Return_Error_When_Pool_Setting_Is_Invalid(t *testing.T) {
+	type testCase struct {
+		name  string
+		value string
+	}
+
+	testCases := []testCase{
+		{name: "POSTGRES_MAX_OPEN_CONNS", value: "many"},
+		{name: "POSTGRES_MAX_IDLE_CONNS", value: "-1"},
+		{name: "POSTGRES_CONN_MAX_LIFETIME", value: "30"},
+		{name: "POSTGRES_CONN_MAX_IDLE_TIME", value: "-5m"},
+		{name: "POSTGRES_CONNECT_TIMEOUT", value: "soon"},
+		{name: "DATABASE_DRIVER", value: "mysql"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			t.Setenv(test.name, test.value)
+
+			// When
+			db, err := database.Setup()
+
+			// Then
+			assert.Nil(t, db)
+			assert.True(t, errors.Is(err, database.ErrSettingIsInvalid))
+			assert.Contains(t, err.Error(), test.name)
+		})
+	}
+}
+
+func Test_Should_Keep_Trying_To_Connect_Until_Timeout(t *testing.T) {
+	// Given
+	t.Setenv("POSTGRES_HOST", "127.0.0.1")
+	t.Setenv("POSTGRES_PORT", "1")
+	t.Setenv("POSTGRES_CONNECT_TIMEOUT", "1s")
+
+	// When
+	started := time.Now()
+	db, err := database.Setup()
+
+	// Then
+	assert.Nil(t, db)
+	assert.NotNil(t, err)
+	assert.GreaterOrEqual(t, time.Since(started), 750*time.Millisecond)
+	assert.Less(t, time.Since(started), 5*time.Second)
+}
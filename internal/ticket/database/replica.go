@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+// ReplicaCheckInterval is how often CheckReplicasEvery is meant to ping the read replicas.
+const ReplicaCheckInterval = 5 * time.Second
+
+var ErrReplicaHostIsInvalid = errors.New("replica hosts should be host:port pairs separated by commas")
+
+// SetupReplicas connects to the read replicas listed in POSTGRES_REPLICA_HOSTS as host:port pairs
+// separated by commas, with the credentials and pool settings of the primary. There are none when it
+// is not set. Replicas are not waited for: those that do not answer are left out by CheckReplicas.
+func SetupReplicas() ([]*gorm.DB, error) {
+	hosts := os.Getenv("POSTGRES_REPLICA_HOSTS")
+	if hosts == "" {
+		return nil, nil
+	}
+
+	pool, err := poolConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	var replicas []*gorm.DB
+	for _, hostPort := range strings.Split(hosts, ",") {
+		host, port, err := net.SplitHostPort(strings.TrimSpace(hostPort))
+		if err != nil {
+			return nil, ErrReplicaHostIsInvalid
+		}
+
+		replica, err := open(host, port, pool, &gorm.Config{DisableAutomaticPing: true})
+		if err != nil {
+			return nil, err
+		}
+		replicas = append(replicas, replica)
+	}
+
+	return replicas, nil
+}
+
+// Cluster is a primary with the read replicas it streams to. Reads that can do with a moment old
+// data go to replicas in turn, and fall back to the primary while none of them is healthy.
+type Cluster struct {
+	primary  *gorm.DB
+	replicas []*replica
+	next     uint32
+}
+
+type replica struct {
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// NewCluster returns the Cluster of primary and replicas. Replicas are taken to be healthy until
+// CheckReplicas finds otherwise.
+func NewCluster(primary *gorm.DB, replicas ...*gorm.DB) *Cluster {
+	cluster := &Cluster{primary: primary}
+	for _, db := range replicas {
+		r := &replica{db: db}
+		r.healthy.Store(true)
+		cluster.replicas = append(cluster.replicas, r)
+	}
+
+	return cluster
+}
+
+func (c *Cluster) Primary() *gorm.DB {
+	return c.primary
+}
+
+// Reader returns the next healthy replica, or the primary when there is none.
+func (c *Cluster) Reader() *gorm.DB {
+	for range c.replicas {
+		r := c.replicas[atomic.AddUint32(&c.next, 1)%uint32(len(c.replicas))]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+
+	return c.primary
+}
+
+// CheckReplicas pings every replica, and only reads from those that answer.
+func (c *Cluster) CheckReplicas(ctx context.Context) {
+	for i, r := range c.replicas {
+		healthy := ping(ctx, r.db) == nil
+		if r.healthy.Swap(healthy) != healthy {
+			if healthy {
+				log.Infof("read replica %d is healthy again", i)
+			} else {
+				log.Warnf("read replica %d is unhealthy, reading from the others or the primary", i)
+			}
+		}
+	}
+}
+
+// CheckReplicasEvery calls CheckReplicas now and every interval until ctx is cancelled.
+func (c *Cluster) CheckReplicasEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.CheckReplicas(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func ping(ctx context.Context, db *gorm.DB) error {
+	conn, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	return conn.PingContext(timeoutCtx)
+}
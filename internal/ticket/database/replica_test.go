@@ -0,0 +1,79 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Replica Unit Tests
+
+func Test_Should_Read_From_Replicas_In_Turn(t *testing.T) {
+	// Given
+	primary, first, second := &gorm.DB{}, &gorm.DB{}, &gorm.DB{}
+	cluster := database.NewCluster(primary, first, second)
+
+	// When
+	readers := []*gorm.DB{cluster.Reader(), cluster.Reader(), cluster.Reader()}
+
+	// Then
+	assert.Same(t, second, readers[0])
+	assert.Same(t, first, readers[1])
+	assert.Same(t, second, readers[2])
+	assert.Same(t, primary, cluster.Primary())
+}
+
+func Test_Should_Read_From_Primary_Without_Replicas(t *testing.T) {
+	// Given
+	primary := &gorm.DB{}
+
+	// When
+	reader := database.NewCluster(primary).Reader()
+
+	// Then
+	assert.Same(t, primary, reader)
+}
+
+func Test_Should_Read_From_Primary_When_Replicas_Are_Unhealthy(t *testing.T) {
+	// Given
+	primary := &gorm.DB{}
+	unreachable, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1 connect_timeout=1"}),
+		&gorm.Config{DisableAutomaticPing: true})
+	assert.Nil(t, err)
+
+	cluster := database.NewCluster(primary, unreachable)
+
+	// When
+	cluster.CheckReplicas(context.TODO())
+
+	// Then
+	assert.Same(t, primary, cluster.Reader())
+}
+
+func Test_Should_Return_Error_When_Replica_Hosts_Are_Invalid(t *testing.T) {
+	// Given
+	t.Setenv("POSTGRES_REPLICA_HOSTS", "replica-without-port")
+
+	// When
+	replicas, err := database.SetupReplicas()
+
+	// Then
+	assert.Nil(t, replicas)
+	assert.Equal(t, database.ErrReplicaHostIsInvalid, err)
+}
+
+func Test_Should_Have_No_Replicas_When_None_Are_Listed(t *testing.T) {
+	// Given
+	t.Setenv("POSTGRES_REPLICA_HOSTS", "")
+
+	// When
+	replicas, err := database.SetupReplicas()
+
+	// Then
+	assert.Nil(t, err)
+	assert.Empty(t, replicas)
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"os"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+
+	// DefaultSQLitePath is the file SQLite databases are kept in unless SQLITE_PATH names another.
+	DefaultSQLitePath = "ticket.db"
+)
+
+// openSQLite opens the SQLite database at SQLITE_PATH, creating it if needed.
+//
+// SQLite lets one connection write at a time and fails the others once its busy timeout passes.
+// The pool keeps a single connection, so that transactions such as purchases wait for each other
+// in it instead, and a transaction starts by taking the write lock, so that one which read
+// allocations cannot lose it to another before writing them.
+func openSQLite() (*gorm.DB, error) {
+	path := os.Getenv("SQLITE_PATH")
+	if path == "" {
+		path = DefaultSQLitePath
+	}
+
+	db, err := gorm.Open(sqlite.Open(path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate"), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn.SetMaxOpenConns(1)
+
+	return db, nil
+}
+
+func appendOnlySQLite(table string) []string {
+	return []string{
+		"CREATE TRIGGER IF NOT EXISTS " + table + "_no_update BEFORE UPDATE ON " + table +
+			" BEGIN SELECT RAISE(ABORT, '" + table + " is append-only'); END",
+		"CREATE TRIGGER IF NOT EXISTS " + table + "_no_delete BEFORE DELETE ON " + table +
+			" BEGIN SELECT RAISE(ABORT, '" + table + " is append-only'); END",
+	}
+}
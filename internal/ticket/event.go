@@ -0,0 +1,115 @@
+package ticket
+
+import (
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTicketOptionCreated EventType = "TicketOptionCreated"
+	EventTicketPurchased     EventType = "TicketPurchased"
+	EventTicketSoldOut       EventType = "TicketSoldOut"
+	EventPurchaseRefunded    EventType = "PurchaseRefunded"
+	EventTicketTransferred   EventType = "TicketTransferred"
+	EventResaleListingSold   EventType = "ResaleListingSold"
+	EventOrderStatusChanged  EventType = "OrderStatusChanged"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusPublished OutboxStatus = "published"
+	OutboxStatusDead      OutboxStatus = "dead"
+)
+
+// Event is a domain event as handed to an EventPublisher. Payload holds the
+// JSON encoding of one of the *Payload types below, chosen by Type. Only the
+// webhooks of OrganizerID, whose ticket options the event is about, get it.
+type Event struct {
+	ID          int       `json:"id"`
+	OrganizerID int       `json:"organizer_id"`
+	Type        EventType `json:"type"`
+	Payload     []byte    `json:"payload"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// OutboxMessage is a row of the outbox table. It is written in the same
+// transaction as the change it describes and relayed to publishers later.
+type OutboxMessage struct {
+	ID            int          `gorm:"primaryKey"`
+	OrganizerID   int          `gorm:"not null;default:1"`
+	EventType     EventType    `gorm:"not null"`
+	Payload       []byte       `gorm:"not null"`
+	Status        OutboxStatus `gorm:"not null;index:idx_outbox_messages_status_next_attempt"`
+	Attempts      int          `gorm:"not null"`
+	NextAttemptAt time.Time    `gorm:"not null;index:idx_outbox_messages_status_next_attempt"`
+	LastError     string
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+func (m OutboxMessage) Event() Event {
+	return Event{
+		ID:          m.ID,
+		OrganizerID: m.OrganizerID,
+		Type:        m.EventType,
+		Payload:     m.Payload,
+		OccurredAt:  m.CreatedAt,
+	}
+}
+
+type TicketOptionCreatedPayload struct {
+	TicketID   int    `json:"ticket_id"`
+	Name       string `json:"name"`
+	Allocation int    `json:"allocation"`
+}
+
+type TicketPurchasedPayload struct {
+	PurchaseID int    `json:"purchase_id"`
+	TicketID   int    `json:"ticket_id"`
+	UserID     string `json:"user_id"`
+	Quantity   int    `json:"quantity"`
+	Remaining  int    `json:"remaining"`
+}
+
+type TicketSoldOutPayload struct {
+	TicketID int `json:"ticket_id"`
+}
+
+type PurchaseRefundedPayload struct {
+	PurchaseID int    `json:"purchase_id"`
+	TicketID   int    `json:"ticket_id"`
+	UserID     string `json:"user_id"`
+	Quantity   int    `json:"quantity"`
+	Remaining  int    `json:"remaining"`
+}
+
+// TicketTransferredPayload leaves out the codes: the old one is void and the new one belongs to ToUserID alone.
+type TicketTransferredPayload struct {
+	IssuedTicketID int    `json:"issued_ticket_id"`
+	TicketID       int    `json:"ticket_id"`
+	FromUserID     string `json:"from_user_id"`
+	ToUserID       string `json:"to_user_id"`
+}
+
+// ResaleListingSoldPayload is what settles a resale: the buyer owes Price to the seller.
+type ResaleListingSoldPayload struct {
+	ListingID      int    `json:"listing_id"`
+	IssuedTicketID int    `json:"issued_ticket_id"`
+	TicketID       int    `json:"ticket_id"`
+	SellerID       string `json:"seller_id"`
+	BuyerID        string `json:"buyer_id"`
+	Price          int    `json:"price"`
+}
+
+// OrderStatusChangedPayload is written when an order is placed, with an empty From, and on every
+// status change after that.
+type OrderStatusChangedPayload struct {
+	OrderID    int         `json:"order_id"`
+	UserID     string      `json:"user_id"`
+	From       OrderStatus `json:"from,omitempty"`
+	To         OrderStatus `json:"to"`
+	TotalPrice int         `json:"total_price"`
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/audit"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderXActor names who makes a request, for the audit log.
+const HeaderXActor = "X-Actor"
+
+var (
+	WarnMessageWhenInvalidAuditFilter   = "limit and after_id need to be valid numbers, from and to need to be RFC 3339 times"
+	WarnMessageWhenAuditPeriodIsInvalid = "to needs to be after from"
+)
+
+// AuditOrigin puts who makes the request, from the X-Actor header, and its request id into the
+// request context, so that the changes it makes are audited under them.
+func AuditOrigin() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			requestID := req.Header.Get(echo.HeaderXRequestID)
+			if requestID == "" {
+				requestID = c.Response().Header().Get(echo.HeaderXRequestID)
+			}
+
+			ctx := audit.WithOrigin(req.Context(), audit.Origin{
+				Actor:     req.Header.Get(HeaderXActor),
+				RequestID: requestID,
+			})
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+type DefaultAuditHandler struct {
+	service service.Service
+}
+
+func NewDefaultAuditHandler(e *echo.Echo, service service.Service) *DefaultAuditHandler {
+	h := DefaultAuditHandler{service: service}
+
+	e.GET("/audit", h.ListAuditEntries)
+
+	return &h
+}
+
+// ListAuditEntries
+// @Tags audit
+// @Summary      List audit entries
+// @Description  List the audit log oldest first. Pass the id of the last item as after_id to get the next page
+// @Produce      json
+// @Param        target    query     string  false  "Target as kind:id, or a kind alone such as ticket_option"
+// @Param        actor     query     string  false  "Who made the changes"
+// @Param        from      query     string  false  "Made at or after, in RFC 3339"
+// @Param        to        query     string  false  "Made before, in RFC 3339"
+// @Param        limit     query     int     false  "Page size, defaults to 50, at most 100"
+// @Param        after_id  query     int     false  "Return audit entries with a greater id"
+// @Success      200  {array}   ticket.AuditEntry
+// @Failure      400              {string}  string
+// @Failure      500              {string}  string
+// @Router       /audit [get]
+func (h *DefaultAuditHandler) ListAuditEntries(c echo.Context) error {
+	filter := ticket.AuditFilter{}
+	var from, to time.Time
+
+	if err := echo.QueryParamsBinder(c).
+		String("target", &filter.Target).
+		String("actor", &filter.Actor).
+		Time("from", &from, time.RFC3339).
+		Time("to", &to, time.RFC3339).
+		Int("limit", &filter.Limit).
+		Int("after_id", &filter.AfterID).
+		BindError(); err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidAuditFilter)
+	}
+
+	if !from.IsZero() {
+		filter.From = &from
+	}
+
+	if !to.IsZero() {
+		filter.To = &to
+	}
+
+	entries, err := h.service.ListAuditEntries(c.Request().Context(), filter)
+	if err != nil {
+		switch err {
+		case service.ErrAuditPeriodIsInvalid:
+			return c.String(http.StatusBadRequest, WarnMessageWhenAuditPeriodIsInvalid)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
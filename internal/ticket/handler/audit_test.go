@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/audit"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Audit Unit Tests
+
+func Test_Should_Return_Status_OK_When_List_Audit_Entries(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet,
+		"/audit?target=purchase:1&actor=admin&from=2026-06-01T00:00:00Z&to=2026-06-02T00:00:00Z&limit=10&after_id=5", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+	expected := []ticket.AuditEntry{{ID: 6, Actor: "admin", Action: ticket.AuditPurchaseRefunded, Target: "purchase:1"}}
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().ListAuditEntries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, filter ticket.AuditFilter) ([]ticket.AuditEntry, error) {
+			assert.Equal(t, "purchase:1", filter.Target)
+			assert.Equal(t, "admin", filter.Actor)
+			assert.True(t, from.Equal(*filter.From))
+			assert.True(t, to.Equal(*filter.To))
+			assert.Equal(t, 10, filter.Limit)
+			assert.Equal(t, 5, filter.AfterID)
+			return expected, nil
+		}).Times(1)
+
+	auditHandler := handler.NewDefaultAuditHandler(e, mockService)
+
+	// When
+	err := auditHandler.ListAuditEntries(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual []ticket.AuditEntry
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Return_Status_Bad_Request_When_List_Audit_Entries_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		query           string
+		serviceError    error
+		expectedMessage string
+	}
+
+	testCases := []testCase{
+		{query: "from=yesterday", expectedMessage: handler.WarnMessageWhenInvalidAuditFilter},
+		{query: "limit=ten", expectedMessage: handler.WarnMessageWhenInvalidAuditFilter},
+		{
+			query:           "from=2026-06-02T00:00:00Z&to=2026-06-01T00:00:00Z",
+			serviceError:    service.ErrAuditPeriodIsInvalid,
+			expectedMessage: handler.WarnMessageWhenAuditPeriodIsInvalid,
+		},
+	}
+
+	for _, test := range testCases {
+		// Given
+		req := httptest.NewRequest(http.MethodGet, "/audit?"+test.query, nil)
+		rec := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(req, rec)
+
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		if test.serviceError != nil {
+			mockService.EXPECT().ListAuditEntries(gomock.Any(), gomock.Any()).Return(nil, test.serviceError).Times(1)
+		}
+
+		auditHandler := handler.NewDefaultAuditHandler(e, mockService)
+
+		// When
+		err := auditHandler.ListAuditEntries(c)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, test.expectedMessage, rec.Body.String())
+	}
+}
+
+func Test_Should_Put_Actor_And_Request_ID_Into_Request_Context(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/purchases/1/refund", nil)
+	req.Header.Set(handler.HeaderXActor, "support-agent")
+	req.Header.Set(echo.HeaderXRequestID, "request-1")
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	var origin audit.Origin
+	next := func(c echo.Context) error {
+		origin = audit.OriginFrom(c.Request().Context())
+		return nil
+	}
+
+	// When
+	err := handler.AuditOrigin()(next)(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, audit.Origin{Actor: "support-agent", RequestID: "request-1"}, origin)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/availability"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+const sseRetryMillis = 3000
+
+type DefaultAvailabilityHandler struct {
+	service     service.Service
+	broadcaster *availability.Broadcaster
+	done        chan struct{}
+	closeOnce   sync.Once
+
+	HeartbeatInterval time.Duration
+}
+
+func NewDefaultAvailabilityHandler(e *echo.Echo, service service.Service, broadcaster *availability.Broadcaster) *DefaultAvailabilityHandler {
+	h := DefaultAvailabilityHandler{
+		service:           service,
+		broadcaster:       broadcaster,
+		done:              make(chan struct{}),
+		HeartbeatInterval: 15 * time.Second, //nolint:gomnd
+	}
+
+	e.GET("/ticket_options/:id/availability/stream", h.StreamAvailability)
+
+	return &h
+}
+
+// Close ends all open streams. Register it with http.Server.RegisterOnShutdown, otherwise
+// graceful shutdown waits for streaming clients until its deadline.
+func (h *DefaultAvailabilityHandler) Close() {
+	h.closeOnce.Do(func() { close(h.done) })
+}
+
+// StreamAvailability
+// @Tags ticket
+// @Summary      Stream ticket availability
+// @Description  Server-Sent Events stream of the remaining allocation of a ticket_option. The current allocation is sent first,
+// @Description  then one "availability" event per purchase, refund or expired hold. Reconnecting clients sending Last-Event-ID receive
+// @Description  the updates they missed. A comment line is sent as heartbeat while idle.
+// @Produce      text/event-stream
+// @Param        id             path      int     true   "Ticket ID"
+// @Param        Last-Event-ID  header    string  false  "Id of the last event received"
+// @Success      200
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/availability/stream [get]
+func (h *DefaultAvailabilityHandler) StreamAvailability(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	lastEventID, _ := strconv.ParseUint(c.Request().Header.Get("Last-Event-ID"), 10, 64) //nolint:gomnd
+
+	// Subscribe before reading the snapshot so no update committed in between is lost.
+	subscription := h.broadcaster.Subscribe(id, lastEventID)
+	defer subscription.Close()
+
+	t, err := h.service.GetTicket(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err = fmt.Fprintf(res, "retry: %d\n\n", sseRetryMillis); err != nil {
+		return nil
+	}
+
+	if subscription.Resumed {
+		for _, update := range subscription.Replay {
+			if err = writeAvailabilityEvent(res, update); err != nil {
+				return nil
+			}
+		}
+	} else {
+		snapshot := availability.Update{ID: subscription.LastID, TicketID: t.ID, Allocation: t.Available, At: time.Now()}
+		if err = writeAvailabilityEvent(res, snapshot); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-h.done:
+			return nil
+		case update := <-subscription.Updates:
+			if err = writeAvailabilityEvent(res, update); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+func writeAvailabilityEvent(res *echo.Response, update availability.Update) error {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(res, "id: %d\nevent: availability\ndata: %s\n\n", update.ID, data)
+
+	return err
+}
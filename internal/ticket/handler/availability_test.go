@@ -0,0 +1,142 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/availability"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Availability Stream Unit Tests
+
+// readEvent reads one SSE event (or comment) up to the blank line that terminates it.
+func readEvent(t *testing.T, reader *bufio.Reader) string {
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return strings.Join(lines, "\n")
+		}
+		lines = append(lines, line)
+	}
+}
+
+func Test_Should_Stream_Snapshot_Then_Updates_When_Availability_Changes(t *testing.T) {
+	// Given
+	e := echo.New()
+	broadcaster := availability.NewBroadcaster(10)
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 50}, nil).Times(1)
+
+	availabilityHandler := handler.NewDefaultAvailabilityHandler(e, mockService, broadcaster)
+	availabilityHandler.HeartbeatInterval = 20 * time.Millisecond
+
+	server := httptest.NewServer(e)
+	defer server.Close()
+	defer availabilityHandler.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// When
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/ticket_options/1/availability/stream", nil)
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer res.Body.Close()
+	reader := bufio.NewReader(res.Body)
+
+	// Then
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	assert.Equal(t, "retry: 3000", readEvent(t, reader))
+
+	snapshot := readEvent(t, reader)
+	assert.Contains(t, snapshot, "id: 0\nevent: availability\n")
+	assert.Contains(t, snapshot, `"ticket_id":1,"allocation":50`)
+
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 48})
+
+	update := readEvent(t, reader)
+	for strings.HasPrefix(update, ":") {
+		update = readEvent(t, reader)
+	}
+	assert.Contains(t, update, "id: 1\nevent: availability\n")
+	assert.Contains(t, update, `"ticket_id":1,"allocation":48`)
+
+	assert.Equal(t, ": heartbeat", readEvent(t, reader))
+}
+
+func Test_Should_Replay_Missed_Updates_When_Client_Sends_Last_Event_ID(t *testing.T) {
+	// Given
+	e := echo.New()
+	broadcaster := availability.NewBroadcaster(10)
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 50})
+	broadcaster.NotifyAvailability(ticket.Ticket{ID: 1, Available: 45})
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 45}, nil).Times(1)
+
+	availabilityHandler := handler.NewDefaultAvailabilityHandler(e, mockService, broadcaster)
+
+	server := httptest.NewServer(e)
+	defer server.Close()
+	defer availabilityHandler.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// When
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/ticket_options/1/availability/stream", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer res.Body.Close()
+	reader := bufio.NewReader(res.Body)
+
+	// Then
+	assert.Equal(t, "retry: 3000", readEvent(t, reader))
+	replayed := readEvent(t, reader)
+	assert.Contains(t, replayed, "id: 2\n")
+	assert.Contains(t, replayed, `"allocation":45`)
+}
+
+func Test_Should_Return_Status_NotFound_When_Streaming_Unknown_Ticket(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/ticket_options/7/availability/stream", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket_options/:id/availability/stream")
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetTicket(gomock.Any(), 7).Return(nil, service.ErrTicketWasNotFound).Times(1)
+
+	availabilityHandler := handler.NewDefaultAvailabilityHandler(e, mockService, availability.NewBroadcaster(10))
+
+	// When
+	err := availabilityHandler.StreamAvailability(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenTicketWasNotFound, rec.Body.String())
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+const mimeTextCSV = "text/csv"
+
+var (
+	WarnMessageWhenBulkIsEmpty          = "Bulk import needs at least one row"
+	WarnMessageWhenBulkIsTooLarge       = "Bulk import has too many rows"
+	WarnMessageWhenBulkModeIsUnknown    = "mode needs to be all_or_nothing or best_effort"
+	WarnMessageWhenBulkMediaIsUnknown   = "Bulk import needs to be application/json or text/csv"
+	WarnMessageWhenBulkColumnIsUnknown  = "Bulk import has an unknown column"
+	WarnMessageWhenBulkHeaderIsNotGiven = "Bulk import in CSV needs a header row"
+)
+
+// BulkResponse reports what became of each row of a bulk import.
+type BulkResponse struct {
+	Created int           `json:"created"`
+	Failed  int           `json:"failed"`
+	Rows    []BulkRowItem `json:"rows"`
+}
+
+// BulkRowItem is what became of one row of a bulk import, numbered from 1 without the CSV header.
+type BulkRowItem struct {
+	Row    int            `json:"row"`
+	Ticket *ticket.Ticket `json:"ticket,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// CreateTicketOptions
+// @Tags ticket
+// @Summary      Create Ticket Options in Bulk
+// @Description  Create ticket_options from a JSON array of Create Ticket Option Request Bodies, or from CSV with a header
+// @Description  row naming the same fields. In all_or_nothing mode nothing is created unless every row can be, and the
+// @Description  report is answered with 422. In best_effort mode every row that can be created is
+// @Param requestBody body []CreateTicketOptionRequestBody true "Ticket options to create"
+// @Param        mode  query     string  false  "all_or_nothing (default) or best_effort"
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Success      200  {object}  BulkResponse  "Some rows of a best_effort import failed"
+// @Success      201  {object}  BulkResponse
+// @Failure      400              {string}  string
+// @Failure      415              {string}  string
+// @Failure      422  {object}  BulkResponse
+// @Failure      500              {string}  string
+// @Router       /ticket_options:bulk [post]
+func (t *DefaultHandler) CreateTicketOptions(c echo.Context) error {
+	mode := ticket.BulkMode(c.QueryParam("mode"))
+	if mode == "" {
+		mode = ticket.BulkAllOrNothing
+	}
+
+	var drafts []ticket.TicketDraft
+	var err error
+
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	switch mediaType {
+	case echo.MIMEApplicationJSON:
+		drafts, err = readJSONDrafts(c.Request().Body)
+	case mimeTextCSV:
+		drafts, err = readCSVDrafts(c.Request().Body)
+	default:
+		return c.String(http.StatusUnsupportedMediaType, WarnMessageWhenBulkMediaIsUnknown)
+	}
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	results, err := t.service.CreateTicketOptions(c.Request().Context(), drafts, mode)
+	if err != nil {
+		switch err {
+		case service.ErrBulkRowsAreInvalid:
+			return c.JSON(http.StatusUnprocessableEntity, bulkResponse(results))
+		case service.ErrBulkIsEmpty:
+			return c.String(http.StatusBadRequest, WarnMessageWhenBulkIsEmpty)
+		case service.ErrBulkIsTooLarge:
+			return c.String(http.StatusBadRequest, WarnMessageWhenBulkIsTooLarge)
+		case service.ErrBulkModeIsUnknown:
+			return c.String(http.StatusBadRequest, WarnMessageWhenBulkModeIsUnknown)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	response := bulkResponse(results)
+	if response.Failed > 0 {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+func bulkResponse(results []ticket.BulkResult) BulkResponse {
+	response := BulkResponse{Rows: make([]BulkRowItem, len(results))}
+
+	for i, result := range results {
+		response.Rows[i] = BulkRowItem{Row: result.Row, Ticket: result.Ticket}
+
+		switch {
+		case result.Err != nil:
+			_, response.Rows[i].Error = createTicketOptionWarning(result.Err)
+			response.Failed++
+		case result.Ticket != nil:
+			response.Created++
+		}
+	}
+
+	return response
+}
+
+func readJSONDrafts(body io.Reader) ([]ticket.TicketDraft, error) {
+	var rows []CreateTicketOptionRequestBody
+	if err := json.NewDecoder(body).Decode(&rows); err != nil {
+		return nil, err
+	}
+
+	drafts := make([]ticket.TicketDraft, len(rows))
+	for i, row := range rows {
+		drafts[i] = ticket.TicketDraft{
+			Name:         row.Name,
+			Desc:         row.Desc,
+			Allocation:   row.Allocation,
+			Price:        row.Price,
+			StartsAt:     row.StartsAt,
+			SaleStartsAt: row.SaleStartsAt,
+			SaleEndsAt:   row.SaleEndsAt,
+		}
+	}
+
+	return drafts, nil
+}
+
+// readCSVDrafts reads a draft of each row after the header, which names the columns as the fields
+// of CreateTicketOptionRequestBody are named in JSON. Empty cells are left unset.
+func readCSVDrafts(body io.Reader) ([]ticket.TicketDraft, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New(WarnMessageWhenBulkHeaderIsNotGiven)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, column := range header {
+		switch strings.TrimSpace(column) {
+		case "name", "desc", "allocation", "price", "starts_at", "sale_starts_at", "sale_ends_at":
+		default:
+			return nil, fmt.Errorf("%s: %q", WarnMessageWhenBulkColumnIsUnknown, column)
+		}
+	}
+
+	var drafts []ticket.TicketDraft
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return drafts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		draft := ticket.TicketDraft{}
+		for i, cell := range record {
+			if err := setDraftColumn(&draft, strings.TrimSpace(header[i]), cell); err != nil {
+				return nil, fmt.Errorf("row %d: %s: %w", row, header[i], err)
+			}
+		}
+
+		drafts = append(drafts, draft)
+	}
+}
+
+func setDraftColumn(draft *ticket.TicketDraft, column, cell string) error {
+	if cell == "" {
+		return nil
+	}
+
+	var err error
+	switch column {
+	case "name":
+		draft.Name = cell
+	case "desc":
+		draft.Desc = cell
+	case "allocation":
+		draft.Allocation, err = strconv.Atoi(cell)
+	case "price":
+		draft.Price, err = strconv.Atoi(cell)
+	case "starts_at":
+		draft.StartsAt, err = parseCSVTime(cell)
+	case "sale_starts_at":
+		draft.SaleStartsAt, err = parseCSVTime(cell)
+	case "sale_ends_at":
+		draft.SaleEndsAt, err = parseCSVTime(cell)
+	}
+
+	return err
+}
+
+func parseCSVTime(cell string) (*time.Time, error) {
+	at, err := time.Parse(time.RFC3339, cell)
+	if err != nil {
+		return nil, err
+	}
+
+	return &at, nil
+}
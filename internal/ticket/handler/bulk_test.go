@@ -0,0 +1,210 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Bulk Import Unit Tests
+
+func Test_Should_Create_Ticket_Options_From_CSV(t *testing.T) {
+	// Given
+	requestBody := "name,desc,allocation,price,sale_ends_at\n" +
+		"early bird,first come,100,2500,2026-06-01T00:00:00Z\n" +
+		"\"regular, standing\",general admission,500,,\n"
+	req := httptest.NewRequest(http.MethodPost, "/ticket_options:bulk", bytes.NewBufferString(requestBody))
+	req.Header.Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	rec := httptest.NewRecorder()
+
+	saleEndsAt := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	expectedDrafts := []ticket.TicketDraft{
+		{Name: "early bird", Desc: "first come", Allocation: 100, Price: 2500, SaleEndsAt: &saleEndsAt},
+		{Name: "regular, standing", Desc: "general admission", Allocation: 500},
+	}
+
+	e := echo.New()
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().CreateTicketOptions(gomock.Any(), expectedDrafts, ticket.BulkAllOrNothing).
+		Return([]ticket.BulkResult{{Row: 1, Ticket: &ticket.Ticket{ID: 1}}, {Row: 2, Ticket: &ticket.Ticket{ID: 2}}}, nil).Times(1)
+
+	handler.NewDefaultTicketHandler(e, mockService)
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	var actual handler.BulkResponse
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 2, actual.Created)
+	assert.Equal(t, 0, actual.Failed)
+	assert.Equal(t, 2, actual.Rows[1].Ticket.ID)
+}
+
+func Test_Should_Report_Each_Row_When_Best_Effort_Import_Partly_Fails(t *testing.T) {
+	// Given
+	requestBody := `[{"name":"example","desc":"sample description","allocation":100},{"name":"","desc":"sample description","allocation":100}]`
+	req := httptest.NewRequest(http.MethodPost, "/ticket_options:bulk?mode=best_effort", bytes.NewBufferString(requestBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().CreateTicketOptions(gomock.Any(), gomock.Len(2), ticket.BulkBestEffort).
+		Return([]ticket.BulkResult{{Row: 1, Ticket: &ticket.Ticket{ID: 1}}, {Row: 2, Err: service.ErrNameIsEmpty}}, nil).Times(1)
+
+	handler.NewDefaultTicketHandler(e, mockService)
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	var actual handler.BulkResponse
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, handler.BulkResponse{Created: 1, Failed: 1, Rows: []handler.BulkRowItem{
+		{Row: 1, Ticket: &ticket.Ticket{ID: 1}},
+		{Row: 2, Error: handler.WarnMessageWhenNameIsEmpty},
+	}}, actual)
+}
+
+func Test_Should_Return_Report_With_Unprocessable_Entity_When_All_Or_Nothing_Import_Fails(t *testing.T) {
+	// Given
+	requestBody := `[{"name":"example","desc":"sample description","allocation":100},{"name":"example","desc":"sample description","allocation":100}]`
+	req := httptest.NewRequest(http.MethodPost, "/ticket_options:bulk?mode=all_or_nothing", bytes.NewBufferString(requestBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().CreateTicketOptions(gomock.Any(), gomock.Len(2), ticket.BulkAllOrNothing).
+		Return([]ticket.BulkResult{{Row: 1}, {Row: 2, Err: service.ErrNameIsDuplicate}}, service.ErrBulkRowsAreInvalid).Times(1)
+
+	handler.NewDefaultTicketHandler(e, mockService)
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	var actual handler.BulkResponse
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, handler.BulkResponse{Created: 0, Failed: 1, Rows: []handler.BulkRowItem{
+		{Row: 1},
+		{Row: 2, Error: handler.WarnMessageWhenNameIsDuplicated},
+	}}, actual)
+}
+
+func Test_Should_Return_Error_When_Bulk_Import_Cannot_Be_Read(t *testing.T) {
+	type testCase struct {
+		name                string
+		contentType         string
+		requestBody         string
+		expectedStatus      int
+		expectedWarnMessage string
+	}
+
+	testCases := []testCase{
+		{
+			name:                "media type is unknown",
+			contentType:         echo.MIMETextPlain,
+			requestBody:         "name\nexample\n",
+			expectedStatus:      http.StatusUnsupportedMediaType,
+			expectedWarnMessage: handler.WarnMessageWhenBulkMediaIsUnknown,
+		},
+		{
+			name:                "CSV has no header",
+			contentType:         "text/csv",
+			requestBody:         "",
+			expectedStatus:      http.StatusBadRequest,
+			expectedWarnMessage: handler.WarnMessageWhenBulkHeaderIsNotGiven,
+		},
+		{
+			name:                "CSV has an unknown column",
+			contentType:         "text/csv",
+			requestBody:         "name,colour\nexample,red\n",
+			expectedStatus:      http.StatusBadRequest,
+			expectedWarnMessage: handler.WarnMessageWhenBulkColumnIsUnknown + `: "colour"`,
+		},
+		{
+			name:                "CSV cell is not a number",
+			contentType:         "text/csv",
+			requestBody:         "name,allocation\nexample,100\nother,many\n",
+			expectedStatus:      http.StatusBadRequest,
+			expectedWarnMessage: `row 2: allocation: strconv.Atoi: parsing "many": invalid syntax`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			req := httptest.NewRequest(http.MethodPost, "/ticket_options:bulk", strings.NewReader(test.requestBody))
+			req.Header.Set(echo.HeaderContentType, test.contentType)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			mockService := mocks.NewMockService(gomock.NewController(t))
+			mockService.EXPECT().CreateTicketOptions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			handler.NewDefaultTicketHandler(e, mockService)
+
+			// When
+			e.ServeHTTP(rec, req)
+
+			// Then
+			assert.Equal(t, test.expectedStatus, rec.Code)
+			assert.Equal(t, test.expectedWarnMessage, rec.Body.String())
+		})
+	}
+}
+
+func Test_Should_Return_Error_When_Bulk_Import_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		serviceErr          error
+		expectedStatus      int
+		expectedWarnMessage string
+	}
+
+	testCases := []testCase{
+		{serviceErr: service.ErrBulkIsEmpty, expectedStatus: http.StatusBadRequest, expectedWarnMessage: handler.WarnMessageWhenBulkIsEmpty},
+		{serviceErr: service.ErrBulkIsTooLarge, expectedStatus: http.StatusBadRequest, expectedWarnMessage: handler.WarnMessageWhenBulkIsTooLarge},
+		{serviceErr: service.ErrBulkModeIsUnknown, expectedStatus: http.StatusBadRequest, expectedWarnMessage: handler.WarnMessageWhenBulkModeIsUnknown},
+		{serviceErr: service.ErrTicketWasNotFound, expectedStatus: http.StatusInternalServerError, expectedWarnMessage: handler.WarnInternalServerError},
+	}
+
+	for _, test := range testCases {
+		// Given
+		req := httptest.NewRequest(http.MethodPost, "/ticket_options:bulk?mode=sometimes", bytes.NewBufferString(`[]`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		e := echo.New()
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		mockService.EXPECT().CreateTicketOptions(gomock.Any(), gomock.Any(), ticket.BulkMode("sometimes")).Return(nil, test.serviceErr).Times(1)
+
+		handler.NewDefaultTicketHandler(e, mockService)
+
+		// When
+		e.ServeHTTP(rec, req)
+
+		// Then
+		assert.Equal(t, test.expectedStatus, rec.Code)
+		assert.Equal(t, test.expectedWarnMessage, rec.Body.String())
+	}
+}
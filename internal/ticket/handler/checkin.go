@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	WarnMessageWhenGateIDIsEmpty             = "Gate id cannot be empty."
+	WarnMessageWhenIssuedTicketCodeIsInvalid = "Ticket code is not genuine"
+	WarnMessageWhenIssuedTicketAlreadyUsed   = "Ticket has already been used"
+	WarnMessageWhenIssuedTicketRefunded      = "Ticket has been refunded"
+	WarnMessageWhenIssuedTicketForOtherEvent = "Ticket is for another event"
+	WarnMessageWhenEventWasNotFound          = "Event was not found"
+)
+
+type DefaultCheckinHandler struct {
+	service service.Service
+}
+
+// NewDefaultCheckinHandler serves entry control. An event is a ticket option: event ids are ticket option ids.
+func NewDefaultCheckinHandler(e *echo.Echo, service service.Service) *DefaultCheckinHandler {
+	h := DefaultCheckinHandler{service: service}
+
+	e.POST("/checkins", h.CheckIn)
+	e.GET("/events/:id/checkins/stats", h.GetCheckinStats)
+
+	return &h
+}
+
+// CheckIn
+// @Tags checkin
+// @Summary      Check in a ticket
+// @Description  Mark an issued ticket as used at a gate. A ticket can only be checked in once
+// @Param requestBody body CheckinRequestBody true "Checkin Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      201  {object}  ticket.IssuedTicket
+// @Failure      400              {string}  string  "Invalid request or forged code"
+// @Failure      404              {string}  string  "Unknown code"
+// @Failure      409              {string}  string  "Already used"
+// @Failure      410              {string}  string  "Refunded"
+// @Failure      422              {string}  string  "Ticket for another event"
+// @Failure      500              {string}  string
+// @Router       /checkins [post]
+func (h *DefaultCheckinHandler) CheckIn(c echo.Context) error {
+	body := new(CheckinRequestBody)
+	if err := c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	issued, err := h.service.CheckIn(c.Request().Context(), body.Code, body.EventID, body.GateID)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrGateIDIsEmpty:
+			return c.String(http.StatusBadRequest, WarnMessageWhenGateIDIsEmpty)
+		case service.ErrIssuedTicketCodeIsInvalid:
+			return c.String(http.StatusBadRequest, WarnMessageWhenIssuedTicketCodeIsInvalid)
+		case service.ErrIssuedTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenIssuedTicketWasNotFound)
+		case service.ErrIssuedTicketAlreadyUsed:
+			return c.String(http.StatusConflict, WarnMessageWhenIssuedTicketAlreadyUsed)
+		case service.ErrIssuedTicketRefunded:
+			return c.String(http.StatusGone, WarnMessageWhenIssuedTicketRefunded)
+		case service.ErrIssuedTicketForOtherEvent:
+			return c.String(http.StatusUnprocessableEntity, WarnMessageWhenIssuedTicketForOtherEvent)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, issued)
+}
+
+// GetCheckinStats
+// @Tags checkin
+// @Summary      Get attendance of an event
+// @Description  Count issued, checked in and not yet arrived tickets of an event, with check-ins per gate
+// @Produce      json
+// @Param        id   path      int  true  "Event (ticket option) ID"
+// @Success      200  {object}  ticket.CheckinStats
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /events/{id}/checkins/stats [get]
+func (h *DefaultCheckinHandler) GetCheckinStats(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	stats, err := h.service.GetCheckinStats(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenEventWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,119 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Check-in Unit Tests
+
+func Test_Should_Return_Status_Created_When_Check_In(t *testing.T) {
+	// Given
+	body := `{"code":"code1","event_id":1,"gate_id":"A"}`
+	req := httptest.NewRequest(http.MethodPost, "/checkins", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	expected := &ticket.IssuedTicket{ID: 1, Code: "code1", TicketID: 1, Status: ticket.IssuedTicketUsed, GateID: "A"}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().CheckIn(gomock.Any(), "code1", 1, "A").Return(expected, nil).Times(1)
+
+	checkinHandler := handler.NewDefaultCheckinHandler(e, mockService)
+
+	// When
+	err := checkinHandler.CheckIn(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual ticket.IssuedTicket
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, *expected, actual)
+}
+
+func Test_Should_Map_Service_Errors_When_Check_In(t *testing.T) {
+	type testCase struct {
+		serviceError    error
+		expectedStatus  int
+		expectedMessage string
+	}
+
+	testCases := []testCase{
+		{serviceError: service.ErrGateIDIsEmpty, expectedStatus: http.StatusBadRequest, expectedMessage: handler.WarnMessageWhenGateIDIsEmpty},
+		{serviceError: service.ErrIssuedTicketCodeIsInvalid, expectedStatus: http.StatusBadRequest, expectedMessage: handler.WarnMessageWhenIssuedTicketCodeIsInvalid},
+		{serviceError: service.ErrIssuedTicketWasNotFound, expectedStatus: http.StatusNotFound, expectedMessage: handler.WarnMessageWhenIssuedTicketWasNotFound},
+		{serviceError: service.ErrIssuedTicketAlreadyUsed, expectedStatus: http.StatusConflict, expectedMessage: handler.WarnMessageWhenIssuedTicketAlreadyUsed},
+		{serviceError: service.ErrIssuedTicketRefunded, expectedStatus: http.StatusGone, expectedMessage: handler.WarnMessageWhenIssuedTicketRefunded},
+		{serviceError: service.ErrIssuedTicketForOtherEvent, expectedStatus: http.StatusUnprocessableEntity, expectedMessage: handler.WarnMessageWhenIssuedTicketForOtherEvent},
+	}
+
+	for _, test := range testCases {
+		// Given
+		body := `{"code":"code1","event_id":1,"gate_id":"A"}`
+		req := httptest.NewRequest(http.MethodPost, "/checkins", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(req, rec)
+
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		mockService.EXPECT().CheckIn(gomock.Any(), "code1", 1, "A").Return(nil, test.serviceError).Times(1)
+
+		checkinHandler := handler.NewDefaultCheckinHandler(e, mockService)
+
+		// When
+		err := checkinHandler.CheckIn(c)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, test.expectedStatus, rec.Code)
+		assert.Equal(t, test.expectedMessage, rec.Body.String())
+	}
+}
+
+func Test_Should_Return_Status_OK_When_Get_Checkin_Stats(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/events/1/checkins/stats", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/events/:id/checkins/stats")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	expected := &ticket.CheckinStats{EventID: 1, Issued: 3, CheckedIn: 2, NotArrived: 1, ByGate: map[string]int{"A": 2}}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetCheckinStats(gomock.Any(), 1).Return(expected, nil).Times(1)
+
+	checkinHandler := handler.NewDefaultCheckinHandler(e, mockService)
+
+	// When
+	err := checkinHandler.GetCheckinStats(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual ticket.CheckinStats
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, *expected, actual)
+}
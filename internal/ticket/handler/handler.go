@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 
+	"github.com/dilaragorum/ticket-api/internal/ticket"
 	"github.com/dilaragorum/ticket-api/internal/ticket/service"
 	"github.com/labstack/echo/v4"
 )
@@ -13,14 +15,23 @@ var (
 	WarnMessageWhenNameIsDuplicated         = "This name is already used"
 	WarnMessageWhenDescriptionIsEmpty       = "Description cannot be empty."
 	WarnMessageWhenAllocationIsBelowThanOne = "Allocation cannot be below than one."
+	WarnMessageWhenPriceIsNegative          = "Price cannot be negative."
+	WarnMessageWhenSaleWindowIsInvalid      = "Sale cannot end before it starts."
 
 	WarnMessageWhenInvalidID         = "Id need to be valid"
+	WarnMessageWhenInvalidPagination = "limit and after_id need to be valid numbers"
 	WarnMessageWhenTicketWasNotFound = "Ticket was not found"
 
 	WarnMessageWhenPurchaseTicketMoreThanAvailable = "Quantity of ticket wanted to be purchased is " +
 		"higher than available ones"
 	WarnMessageWhenQuantityLowerThanOne = "Quantity cannot be lower than one"
-	WarnInternalServerError             = "an error occurred please try again later"
+	WarnMessageWhenSaleNotStarted       = "Ticket option is not on sale yet"
+	WarnMessageWhenSaleEnded            = "Ticket option is no longer on sale"
+
+	WarnMessageWhenPurchaseWasNotFound     = "Purchase was not found"
+	WarnMessageWhenPurchaseAlreadyRefunded = "Purchase has already been refunded"
+
+	WarnInternalServerError = "an error occurred please try again later"
 )
 
 type DefaultHandler struct {
@@ -32,7 +43,10 @@ func NewDefaultTicketHandler(e *echo.Echo, service service.Service) *DefaultHand
 
 	e.GET("/ticket/:id", t.GetTicket)
 	e.POST("/ticket_options", t.CreateTicketOption)
+	e.POST("/ticket_options\\:bulk", t.CreateTicketOptions)
+	e.GET("/ticket_options", t.ListTicketOptions)
 	e.POST("/ticket_options/:id/purchases", t.PurchaseFromTicketOption)
+	e.POST("/purchases/:id/refund", t.RefundPurchase)
 
 	return &t
 }
@@ -55,32 +69,45 @@ func (t *DefaultHandler) CreateTicketOption(c echo.Context) error {
 		return c.String(http.StatusBadRequest, err.Error())
 	}
 
-	ticketOptions, err := t.service.CreateTicketOption(c.Request().Context(), options.Name, options.Desc, options.Allocation)
+	ticketOptions, err := t.service.CreateTicketOption(c.Request().Context(), options.Name, options.Desc, options.Allocation,
+		options.Price, options.StartsAt, options.SaleStartsAt, options.SaleEndsAt)
 	if err != nil {
-		switch err {
-		case service.ErrNameIsEmpty:
-			return c.String(http.StatusBadRequest, WarnMessageWhenNameIsEmpty)
-		case service.ErrDescriptionIsEmpty:
-			return c.String(http.StatusBadRequest, WarnMessageWhenDescriptionIsEmpty)
-		case service.ErrAllocationIsLowerThanOne:
-			return c.String(http.StatusBadRequest, WarnMessageWhenAllocationIsBelowThanOne)
-		case service.ErrNameIsDuplicate:
-			return c.String(http.StatusBadRequest, WarnMessageWhenNameIsDuplicated)
-		default:
-			return c.String(http.StatusInternalServerError, WarnInternalServerError)
-		}
+		return c.String(createTicketOptionWarning(err))
 	}
 
 	return c.JSON(http.StatusCreated, *ticketOptions)
 }
 
+// createTicketOptionWarning tells the status and message a ticket option that could not be created
+// because of err is answered with.
+func createTicketOptionWarning(err error) (int, string) {
+	switch err {
+	case service.ErrNameIsEmpty:
+		return http.StatusBadRequest, WarnMessageWhenNameIsEmpty
+	case service.ErrDescriptionIsEmpty:
+		return http.StatusBadRequest, WarnMessageWhenDescriptionIsEmpty
+	case service.ErrAllocationIsLowerThanOne:
+		return http.StatusBadRequest, WarnMessageWhenAllocationIsBelowThanOne
+	case service.ErrPriceIsNegative:
+		return http.StatusBadRequest, WarnMessageWhenPriceIsNegative
+	case service.ErrSaleWindowIsInvalid:
+		return http.StatusBadRequest, WarnMessageWhenSaleWindowIsInvalid
+	case service.ErrNameIsDuplicate:
+		return http.StatusBadRequest, WarnMessageWhenNameIsDuplicated
+	default:
+		return http.StatusInternalServerError, WarnInternalServerError
+	}
+}
+
 // GetTicket
 // @Tags ticket
 // @Summary      Get ticket by ticket id
-// @Description  Get specified ticket with ID from available tickets
+// @Description  Get specified ticket with ID, with its capacity and how many of it are sold, held and available
 // @Produce      json
 // @Param        id   path      int  true  "Ticket ID"
+// @Param        If-None-Match  header  string  false  "ETag of the ticket the client has"
 // @Success      200  {object}  ticket.Ticket
+// @Success      304
 // @Failure      400              {string}  string
 // @Failure      404              {string}  string
 // @Failure      500              {string}  string
@@ -105,7 +132,50 @@ func (t *DefaultHandler) GetTicket(c echo.Context) error {
 		}
 	}
 
-	return c.JSON(http.StatusOK, ticket)
+	body, err := json.Marshal(ticket)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, WarnInternalServerError)
+	}
+
+	etag := etagOf(body)
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+	c.Response().Header().Set(HeaderETag, etag)
+	if matchesETag(c.Request().Header.Get(HeaderIfNoneMatch), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.JSONBlob(http.StatusOK, body)
+}
+
+// ListTicketOptions
+// @Tags ticket
+// @Summary      List Ticket Options
+// @Description  List ticket_options ordered by id. Pass the id of the last item as after_id to get the next page
+// @Produce      json
+// @Param        limit     query     int  false  "Page size, defaults to 50, at most 100"
+// @Param        after_id  query     int  false  "Return ticket options with a greater id"
+// @Param        on_sale   query     bool false  "Return only ticket options on sale now"
+// @Success      200  {array}   ticket.Ticket
+// @Failure      400              {string}  string
+// @Failure      500              {string}  string
+// @Router       /ticket_options [get]
+func (t *DefaultHandler) ListTicketOptions(c echo.Context) error {
+	filter := ticket.TicketFilter{}
+
+	if err := echo.QueryParamsBinder(c).
+		Int("limit", &filter.Limit).
+		Int("after_id", &filter.AfterID).
+		Bool("on_sale", &filter.OnSale).
+		BindError(); err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidPagination)
+	}
+
+	tickets, err := t.service.ListTicketOptions(c.Request().Context(), filter)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, WarnInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, tickets)
 }
 
 // PurchaseFromTicketOption
@@ -115,8 +185,10 @@ func (t *DefaultHandler) GetTicket(c echo.Context) error {
 // @Accept       json
 // @Param requestBody body CreatePurchaseTicketOptionRequestBody true "Purchase Ticket Option Request Body"
 // @Param        id   path      int  true  "Ticket ID"
-// @Success      200
+// @Produce      json
+// @Success      200  {object}  ticket.Purchase
 // @Failure      400              {string}  string
+// @Failure      403              {string}  string  "Outside the sale window"
 // @Failure      500              {string}  string
 // @Router       /ticket_options/{id}/purchases [post]
 func (t *DefaultHandler) PurchaseFromTicketOption(c echo.Context) error {
@@ -130,7 +202,7 @@ func (t *DefaultHandler) PurchaseFromTicketOption(c echo.Context) error {
 		return c.String(http.StatusBadRequest, err.Error())
 	}
 
-	err = t.service.PurchaseFromTicketOption(c.Request().Context(), id, purchasedTicketOption.Quantity, purchasedTicketOption.UserID)
+	purchase, err := t.service.PurchaseFromTicketOption(c.Request().Context(), id, purchasedTicketOption.Quantity, purchasedTicketOption.UserID)
 	if err != nil {
 		switch err {
 		case service.ErrPurchaseTicketMoreThanAvailable:
@@ -139,10 +211,53 @@ func (t *DefaultHandler) PurchaseFromTicketOption(c echo.Context) error {
 			return c.String(http.StatusBadRequest, WarnMessageWhenQuantityLowerThanOne)
 		case service.ErrIDLowerThanOne:
 			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrTicketOptionIsSeated:
+			return c.String(http.StatusBadRequest, WarnMessageWhenTicketOptionIsSeated)
+		case service.ErrSaleNotStarted:
+			return c.String(http.StatusForbidden, WarnMessageWhenSaleNotStarted)
+		case service.ErrSaleEnded:
+			return c.String(http.StatusForbidden, WarnMessageWhenSaleEnded)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, purchase)
+}
+
+// RefundPurchase
+// @Tags ticket
+// @Summary      Refund a purchase
+// @Description  Refund the given purchase and return its tickets to the ticket_option allocation
+// @Produce      json
+// @Param        id   path      int  true  "Purchase ID"
+// @Success      200  {object}  ticket.Purchase
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string
+// @Failure      500              {string}  string
+// @Router       /purchases/{id}/refund [post]
+func (t *DefaultHandler) RefundPurchase(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	purchase, err := t.service.RefundPurchase(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrPurchaseWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenPurchaseWasNotFound)
+		case service.ErrPurchaseAlreadyRefunded:
+			return c.String(http.StatusConflict, WarnMessageWhenPurchaseAlreadyRefunded)
+		case service.ErrPurchaseBelongsToOrder:
+			return c.String(http.StatusConflict, WarnMessageWhenPurchaseBelongsToOrder)
 		default:
 			return c.String(http.StatusInternalServerError, WarnInternalServerError)
 		}
 	}
 
-	return c.NoContent(http.StatusOK)
+	return c.JSON(http.StatusOK, purchase)
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/dilaragorum/ticket-api/internal/ticket"
@@ -30,10 +31,10 @@ func Test_Should_Return_Status_Created_When_TicketOption_Is_Valid(t *testing.T)
 	e := echo.New()
 	c := e.NewContext(req, rec)
 
-	expectedCreatedTicketOption := ticket.Ticket{ID: 1, Name: "example", Desc: "sample description", Allocation: 100}
+	expectedCreatedTicketOption := ticket.Ticket{ID: 1, Name: "example", Desc: "sample description", Available: 100}
 	mockService := mocks.NewMockService(gomock.NewController(t))
 	mockService.EXPECT().
-		CreateTicketOption(gomock.Any(), "example", "sample description", 100).
+		CreateTicketOption(gomock.Any(), "example", "sample description", 100, 0, nil, nil, nil).
 		Return(&expectedCreatedTicketOption, nil).Times(1)
 
 	ticketOptHandler := handler.NewDefaultTicketHandler(e, mockService)
@@ -105,7 +106,7 @@ func Test_Should_Return_Status_BadRequest_When_TicketOption_Is_Not_Valid(t *test
 			mockService := mocks.NewMockService(gomock.NewController(t))
 			mockService.
 				EXPECT().
-				CreateTicketOption(gomock.Any(), test.ticketRequest.Name, test.ticketRequest.Desc, test.ticketRequest.Allocation).
+				CreateTicketOption(gomock.Any(), test.ticketRequest.Name, test.ticketRequest.Desc, test.ticketRequest.Allocation, 0, nil, nil, nil).
 				Return(nil, test.ticketStatusErr).
 				Times(1)
 
@@ -134,7 +135,7 @@ func Test_Should_Return_Status_Internal_Server_Error_When_Ticket_Option_Create(t
 
 	mockService := mocks.NewMockService(gomock.NewController(t))
 	mockService.EXPECT().
-		CreateTicketOption(gomock.Any(), "Ticket", "Ticket Description", 0).
+		CreateTicketOption(gomock.Any(), "Ticket", "Ticket Description", 0, 0, nil, nil, nil).
 		Return(nil, errors.New("test Error")).Times(1)
 
 	ticketOptHandler := handler.NewDefaultTicketHandler(e, mockService)
@@ -161,10 +162,10 @@ func Test_Should_Return_Status_OK_When_GetTicket(t *testing.T) {
 	c.SetParamValues("1")
 
 	expectedTicket := ticket.Ticket{
-		ID:         1,
-		Name:       "example",
-		Desc:       "sample description",
-		Allocation: 100,
+		ID:        1,
+		Name:      "example",
+		Desc:      "sample description",
+		Available: 100,
 	}
 
 	mockService := mocks.NewMockService(gomock.NewController(t))
@@ -185,6 +186,89 @@ func Test_Should_Return_Status_OK_When_GetTicket(t *testing.T) {
 	assert.Equal(t, expectedTicket, actualTicket)
 }
 
+func Test_Should_Keep_Allocation_As_Available_When_GetTicket(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetTicket(gomock.Any(), 1).
+		Return(&ticket.Ticket{ID: 1, Capacity: 120, Sold: 15, Held: 5, Available: 100}, nil).Times(1)
+
+	ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
+
+	// When
+	err := ticketHandler.GetTicket(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]interface{}
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.Equal(t, float64(100), body["available"])
+	assert.Equal(t, float64(100), body["allocation"])
+	assert.Equal(t, float64(120), body["capacity"])
+}
+
+func Test_Should_Return_Status_Not_Modified_When_Ticket_Matches_ETag(t *testing.T) {
+	type testCase struct {
+		ifNoneMatch  func(etag string) string
+		expectedCode int
+	}
+
+	testCases := []testCase{
+		{ifNoneMatch: func(etag string) string { return etag }, expectedCode: http.StatusNotModified},
+		{ifNoneMatch: func(etag string) string { return `"other", W/` + etag }, expectedCode: http.StatusNotModified},
+		{ifNoneMatch: func(string) string { return "*" }, expectedCode: http.StatusNotModified},
+		{ifNoneMatch: func(string) string { return `"other"` }, expectedCode: http.StatusOK},
+	}
+
+	for _, test := range testCases {
+		// Given
+		e := echo.New()
+		option := ticket.Ticket{ID: 1, Name: "example", Desc: "sample description", Capacity: 500, Sold: 380, Available: 120}
+
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		mockService.EXPECT().GetTicket(gomock.Any(), 1).Return(&option, nil).Times(2)
+
+		ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
+
+		first := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/ticket/1", http.NoBody), first)
+		c.SetPath("/ticket/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		assert.Nil(t, ticketHandler.GetTicket(c))
+		etag := first.Header().Get(handler.HeaderETag)
+
+		req := httptest.NewRequest(http.MethodGet, "/ticket/1", http.NoBody)
+		req.Header.Set(handler.HeaderIfNoneMatch, test.ifNoneMatch(etag))
+		rec := httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		c.SetPath("/ticket/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		// When
+		err := ticketHandler.GetTicket(c)
+
+		// Then
+		assert.Nil(t, err)
+		assert.NotEmpty(t, etag)
+		assert.Equal(t, test.expectedCode, rec.Code)
+		assert.Equal(t, etag, rec.Header().Get(handler.HeaderETag))
+		if test.expectedCode == http.StatusNotModified {
+			assert.Empty(t, rec.Body.String())
+		}
+	}
+}
+
 func Test_Should_Return_Status_BadRequest_When_Get_Ticket(t *testing.T) {
 	t.Run("Test_Should_Return_BadRequest_When_Invalid_id - Cannot be converted to int", func(t *testing.T) {
 		// Given
@@ -297,10 +381,17 @@ func Test_Should_Return_Status_Created_When_Purchase_From_Ticket_Option(t *testi
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
+	expectedPurchase := ticket.Purchase{
+		ID:            7,
+		UserID:        "406c1d05-bbb2-4e94-b183-7d208c2692e1",
+		TicketID:      1,
+		Quantity:      2,
+		IssuedTickets: []ticket.IssuedTicket{{ID: 1, Code: "code1"}, {ID: 2, Code: "code2"}},
+	}
 	mockService := mocks.NewMockService(gomock.NewController(t))
 	mockService.
 		EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 2, "406c1d05-bbb2-4e94-b183-7d208c2692e1").
-		Return(nil).Times(1)
+		Return(&expectedPurchase, nil).Times(1)
 
 	ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
 
@@ -309,7 +400,12 @@ func Test_Should_Return_Status_Created_When_Purchase_From_Ticket_Option(t *testi
 
 	// Then
 	assert.Nil(t, err)
+
+	var actualPurchase ticket.Purchase
+	_ = json.NewDecoder(rec.Body).Decode(&actualPurchase)
+
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expectedPurchase, actualPurchase)
 }
 
 func Test_Should_Return_Bad_Request_When_Purchase_From_Ticket_Option(t *testing.T) {
@@ -376,7 +472,7 @@ func Test_Should_Return_Bad_Request_When_Purchase_From_Ticket_Option(t *testing.
 		mockService := mocks.NewMockService(gomock.NewController(t))
 		mockService.
 			EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 1000, "406c1d05-bbb2-4e94-b183-7d208c2692e1").
-			Return(service.ErrPurchaseTicketMoreThanAvailable).Times(1)
+			Return(nil, service.ErrPurchaseTicketMoreThanAvailable).Times(1)
 
 		ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
 
@@ -404,7 +500,7 @@ func Test_Should_Return_Bad_Request_When_Purchase_From_Ticket_Option(t *testing.
 		mockService := mocks.NewMockService(gomock.NewController(t))
 		mockService.
 			EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 0, "406c1d05-bbb2-4e94-b183-7d208c2692e1").
-			Return(service.ErrQuantityLowerThanOne).Times(1)
+			Return(nil, service.ErrQuantityLowerThanOne).Times(1)
 
 		ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
 
@@ -432,7 +528,7 @@ func Test_Should_Return_Bad_Request_When_Purchase_From_Ticket_Option(t *testing.
 		mockService := mocks.NewMockService(gomock.NewController(t))
 		mockService.
 			EXPECT().PurchaseFromTicketOption(gomock.Any(), 0, 1, "406c1d05-bbb2-4e94-b183-7d208c2692e1").
-			Return(service.ErrIDLowerThanOne).Times(1)
+			Return(nil, service.ErrIDLowerThanOne).Times(1)
 
 		ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
 
@@ -462,7 +558,7 @@ func Test_Should_Return_Internal_Server_Error_When_Purchase_From_Ticket_Option(t
 	mockService := mocks.NewMockService(gomock.NewController(t))
 	mockService.
 		EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 2, "406c1d05-bbb2-4e94-b183-7d208c2692e1").
-		Return(errors.New("test")).Times(1)
+		Return(nil, errors.New("test")).Times(1)
 
 	ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
 
@@ -474,3 +570,200 @@ func Test_Should_Return_Internal_Server_Error_When_Purchase_From_Ticket_Option(t
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	assert.Equal(t, handler.WarnInternalServerError, rec.Body.String())
 }
+
+// Refund Purchase Unit Tests
+
+func Test_Should_Return_Status_OK_When_Refund_Purchase(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/purchases/3/refund", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/purchases/:id/refund")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+
+	expectedPurchase := ticket.Purchase{ID: 3, UserID: "test", TicketID: 1, Quantity: 2}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().RefundPurchase(gomock.Any(), 3).Return(&expectedPurchase, nil).Times(1)
+
+	ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
+
+	// When
+	err := ticketHandler.RefundPurchase(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actualPurchase ticket.Purchase
+	_ = json.NewDecoder(rec.Body).Decode(&actualPurchase)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expectedPurchase, actualPurchase)
+}
+
+func Test_Should_Return_Error_Status_When_Refund_Purchase(t *testing.T) {
+	type testCase struct {
+		name                string
+		serviceErr          error
+		expectedStatus      int
+		expectedWarnMessage string
+	}
+
+	testCases := []testCase{
+		{
+			name:                "Test_Should_Return_NotFound_When_Purchase_Does_Not_Exist",
+			serviceErr:          service.ErrPurchaseWasNotFound,
+			expectedStatus:      http.StatusNotFound,
+			expectedWarnMessage: handler.WarnMessageWhenPurchaseWasNotFound,
+		},
+		{
+			name:                "Test_Should_Return_Conflict_When_Purchase_Already_Refunded",
+			serviceErr:          service.ErrPurchaseAlreadyRefunded,
+			expectedStatus:      http.StatusConflict,
+			expectedWarnMessage: handler.WarnMessageWhenPurchaseAlreadyRefunded,
+		},
+		{
+			name:                "Test_Should_Return_Internal_Server_Error",
+			serviceErr:          errors.New("test"),
+			expectedStatus:      http.StatusInternalServerError,
+			expectedWarnMessage: handler.WarnInternalServerError,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			req := httptest.NewRequest(http.MethodPost, "/purchases/3/refund", nil)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetPath("/purchases/:id/refund")
+			c.SetParamNames("id")
+			c.SetParamValues("3")
+
+			mockService := mocks.NewMockService(gomock.NewController(t))
+			mockService.EXPECT().RefundPurchase(gomock.Any(), 3).Return(nil, test.serviceErr).Times(1)
+
+			ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
+
+			// When
+			err := ticketHandler.RefundPurchase(c)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatus, rec.Code)
+			assert.Equal(t, test.expectedWarnMessage, rec.Body.String())
+		})
+	}
+}
+
+// List Ticket Options Unit Tests
+
+func Test_Should_Return_Status_OK_When_List_Ticket_Options(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/ticket_options?limit=2&after_id=5", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	expected := []ticket.Ticket{{ID: 6, Name: "example", Desc: "sample description", Available: 100}}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().ListTicketOptions(gomock.Any(), ticket.TicketFilter{Limit: 2, AfterID: 5}).Return(expected, nil).Times(1)
+
+	ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
+
+	// When
+	err := ticketHandler.ListTicketOptions(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual []ticket.Ticket
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Return_Status_OK_When_List_Ticket_Options_On_Sale(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/ticket_options?on_sale=true", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().ListTicketOptions(gomock.Any(), ticket.TicketFilter{OnSale: true}).Return([]ticket.Ticket{}, nil).Times(1)
+
+	ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
+
+	// When
+	err := ticketHandler.ListTicketOptions(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func Test_Should_Return_Status_Forbidden_When_Purchase_Outside_Sale_Window(t *testing.T) {
+	type testCase struct {
+		serviceError    error
+		expectedMessage string
+	}
+
+	testCases := []testCase{
+		{serviceError: service.ErrSaleNotStarted, expectedMessage: handler.WarnMessageWhenSaleNotStarted},
+		{serviceError: service.ErrSaleEnded, expectedMessage: handler.WarnMessageWhenSaleEnded},
+	}
+
+	for _, test := range testCases {
+		// Given
+		req := httptest.NewRequest(http.MethodPost, "/ticket_options/1/purchases",
+			strings.NewReader(`{"quantity":1,"user_id":"test"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(req, rec)
+		c.SetPath("/ticket_options/:id/purchases")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		mockService.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 1, "test").Return(nil, test.serviceError).Times(1)
+
+		ticketHandler := handler.NewDefaultTicketHandler(e, mockService)
+
+		// When
+		err := ticketHandler.PurchaseFromTicketOption(c)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, test.expectedMessage, rec.Body.String())
+	}
+}
+
+func Test_Should_Return_Status_BadRequest_When_List_Pagination_Is_Not_Valid(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/ticket_options?limit=ten", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	ticketHandler := handler.NewDefaultTicketHandler(e, mocks.NewMockService(gomock.NewController(t)))
+
+	// When
+	err := ticketHandler.ListTicketOptions(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenInvalidPagination, rec.Body.String())
+}
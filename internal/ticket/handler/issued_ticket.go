@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"net/http"
+	"strconv"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+	"github.com/skip2/go-qrcode"
+)
+
+const qrCodeSize = 256
+
+var (
+	WarnMessageWhenIssuedTicketWasNotFound = "Issued ticket was not found"
+	WarnMessageWhenUserIDIsEmpty           = "User id cannot be empty."
+	WarnMessageWhenTransferToSameUser      = "Ticket already belongs to this user"
+	WarnMessageWhenTransferWindowClosed    = "Ticket can no longer be transferred"
+)
+
+type SigningKeyResponse struct {
+	Algorithm string `json:"algorithm"`
+	PublicKey []byte `json:"public_key"`
+}
+
+type DefaultIssuedTicketHandler struct {
+	service   service.Service
+	publicKey ed25519.PublicKey
+}
+
+// NewDefaultIssuedTicketHandler serves issued tickets. publicKey verifies their codes and is published
+// so scanners can check codes offline.
+func NewDefaultIssuedTicketHandler(e *echo.Echo, service service.Service, publicKey ed25519.PublicKey) *DefaultIssuedTicketHandler {
+	h := DefaultIssuedTicketHandler{service: service, publicKey: publicKey}
+
+	e.GET("/purchases/:id/tickets", h.GetPurchaseTickets)
+	e.GET("/issued_tickets/signing_key", h.GetSigningKey)
+	e.GET("/issued_tickets/:code/qr.png", h.GetQRCode)
+	e.POST("/issued_tickets/:code/transfer", h.TransferIssuedTicket)
+	e.GET("/issued_tickets/:code/transfers", h.GetIssuedTicketTransfers)
+
+	return &h
+}
+
+// GetPurchaseTickets
+// @Tags issued_ticket
+// @Summary      Get the tickets of a purchase
+// @Description  List the individual tickets issued for a purchase, one per purchased unit, with their codes
+// @Produce      json
+// @Param        id   path      int  true  "Purchase ID"
+// @Success      200  {array}   ticket.IssuedTicket
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /purchases/{id}/tickets [get]
+func (h *DefaultIssuedTicketHandler) GetPurchaseTickets(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	issued, err := h.service.GetPurchaseTickets(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrPurchaseWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenPurchaseWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, issued)
+}
+
+// GetQRCode
+// @Tags issued_ticket
+// @Summary      Get the QR code of an issued ticket
+// @Description  Render the code of an issued ticket as a PNG QR code
+// @Produce      png
+// @Param        code   path      string  true  "Issued ticket code"
+// @Success      200
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /issued_tickets/{code}/qr.png [get]
+func (h *DefaultIssuedTicketHandler) GetQRCode(c echo.Context) error {
+	issued, err := h.service.GetIssuedTicket(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		switch err {
+		case service.ErrIssuedTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenIssuedTicketWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	png, err := qrcode.Encode(issued.Code, qrcode.Medium, qrCodeSize)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, WarnInternalServerError)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, max-age=86400")
+
+	return c.Blob(http.StatusOK, "image/png", png)
+}
+
+// GetSigningKey
+// @Tags issued_ticket
+// @Summary      Get the ticket code signing key
+// @Description  Get the Ed25519 public key (base64) that verifies issued ticket codes offline
+// @Produce      json
+// @Success      200  {object}  SigningKeyResponse
+// @Router       /issued_tickets/signing_key [get]
+func (h *DefaultIssuedTicketHandler) GetSigningKey(c echo.Context) error {
+	return c.JSON(http.StatusOK, SigningKeyResponse{Algorithm: "Ed25519", PublicKey: h.publicKey})
+}
+
+// TransferIssuedTicket
+// @Tags issued_ticket
+// @Summary      Transfer an issued ticket
+// @Description  Give an issued ticket to another user. The old code stops working and the ticket is returned with its new code
+// @Param        code   path      string  true  "Issued ticket code"
+// @Param requestBody body TransferIssuedTicketRequestBody true "Transfer Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ticket.IssuedTicket
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string  "Already used or too close to the event"
+// @Failure      410              {string}  string  "Refunded"
+// @Failure      500              {string}  string
+// @Router       /issued_tickets/{code}/transfer [post]
+func (h *DefaultIssuedTicketHandler) TransferIssuedTicket(c echo.Context) error {
+	body := new(TransferIssuedTicketRequestBody)
+	if err := c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	issued, err := h.service.TransferIssuedTicket(c.Request().Context(), c.Param("code"), body.ToUserID)
+	if err != nil {
+		switch err {
+		case service.ErrUserIDIsEmpty:
+			return c.String(http.StatusBadRequest, WarnMessageWhenUserIDIsEmpty)
+		case service.ErrTransferToSameUser:
+			return c.String(http.StatusBadRequest, WarnMessageWhenTransferToSameUser)
+		case service.ErrIssuedTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenIssuedTicketWasNotFound)
+		case service.ErrIssuedTicketAlreadyUsed:
+			return c.String(http.StatusConflict, WarnMessageWhenIssuedTicketAlreadyUsed)
+		case service.ErrTransferWindowClosed:
+			return c.String(http.StatusConflict, WarnMessageWhenTransferWindowClosed)
+		case service.ErrIssuedTicketRefunded:
+			return c.String(http.StatusGone, WarnMessageWhenIssuedTicketRefunded)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, issued)
+}
+
+// GetIssuedTicketTransfers
+// @Tags issued_ticket
+// @Summary      Get the transfer history of an issued ticket
+// @Description  List every change of hands of the ticket currently holding the code, oldest first
+// @Produce      json
+// @Param        code   path      string  true  "Issued ticket code"
+// @Success      200  {array}   ticket.TicketTransfer
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /issued_tickets/{code}/transfers [get]
+func (h *DefaultIssuedTicketHandler) GetIssuedTicketTransfers(c echo.Context) error {
+	transfers, err := h.service.GetIssuedTicketTransfers(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		switch err {
+		case service.ErrIssuedTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenIssuedTicketWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, transfers)
+}
@@ -0,0 +1,223 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketcode"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Issued Ticket Unit Tests
+
+func Test_Should_Return_Status_OK_When_Get_Purchase_Tickets(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/purchases/3/tickets", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/purchases/:id/tickets")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+
+	expected := []ticket.IssuedTicket{
+		{ID: 1, Code: "code1", PurchaseID: 3, TicketID: 1, Status: ticket.IssuedTicketValid},
+		{ID: 2, Code: "code2", PurchaseID: 3, TicketID: 1, Status: ticket.IssuedTicketValid},
+	}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetPurchaseTickets(gomock.Any(), 3).Return(expected, nil).Times(1)
+
+	issuedTicketHandler := handler.NewDefaultIssuedTicketHandler(e, mockService, ticketcode.NewRandomSigner().PublicKey())
+
+	// When
+	err := issuedTicketHandler.GetPurchaseTickets(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual []ticket.IssuedTicket
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Return_Status_NotFound_When_Get_Tickets_Of_Unknown_Purchase(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/purchases/3/tickets", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/purchases/:id/tickets")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetPurchaseTickets(gomock.Any(), 3).Return(nil, service.ErrPurchaseWasNotFound).Times(1)
+
+	issuedTicketHandler := handler.NewDefaultIssuedTicketHandler(e, mockService, ticketcode.NewRandomSigner().PublicKey())
+
+	// When
+	err := issuedTicketHandler.GetPurchaseTickets(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenPurchaseWasNotFound, rec.Body.String())
+}
+
+func Test_Should_Return_PNG_When_Get_QR_Code(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/issued_tickets/code1/qr.png", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/issued_tickets/:code/qr.png")
+	c.SetParamNames("code")
+	c.SetParamValues("code1")
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetIssuedTicket(gomock.Any(), "code1").
+		Return(&ticket.IssuedTicket{ID: 1, Code: "code1"}, nil).Times(1)
+
+	issuedTicketHandler := handler.NewDefaultIssuedTicketHandler(e, mockService, ticketcode.NewRandomSigner().PublicKey())
+
+	// When
+	err := issuedTicketHandler.GetQRCode(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+
+	_, err = png.Decode(bytes.NewReader(rec.Body.Bytes()))
+	assert.Nil(t, err)
+}
+
+func Test_Should_Return_Error_Status_When_Get_QR_Code(t *testing.T) {
+	type testCase struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}
+
+	testCases := []testCase{
+		{name: "unknown code", serviceErr: service.ErrIssuedTicketWasNotFound, expectedStatus: http.StatusNotFound},
+		{name: "database error", serviceErr: errors.New("test"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			req := httptest.NewRequest(http.MethodGet, "/issued_tickets/code1/qr.png", nil)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetPath("/issued_tickets/:code/qr.png")
+			c.SetParamNames("code")
+			c.SetParamValues("code1")
+
+			mockService := mocks.NewMockService(gomock.NewController(t))
+			mockService.EXPECT().GetIssuedTicket(gomock.Any(), "code1").Return(nil, test.serviceErr).Times(1)
+
+			issuedTicketHandler := handler.NewDefaultIssuedTicketHandler(e, mockService, ticketcode.NewRandomSigner().PublicKey())
+
+			// When
+			err := issuedTicketHandler.GetQRCode(c)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func Test_Should_Return_Transferred_Ticket_When_Transfer(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/issued_tickets/old/transfer", bytes.NewBufferString(`{"to_user_id":"bob"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/issued_tickets/:code/transfer")
+	c.SetParamNames("code")
+	c.SetParamValues("old")
+
+	expected := ticket.IssuedTicket{ID: 1, Code: "new", TicketID: 1, UserID: "bob", Status: ticket.IssuedTicketValid}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().TransferIssuedTicket(gomock.Any(), "old", "bob").Return(&expected, nil).Times(1)
+
+	issuedTicketHandler := handler.NewDefaultIssuedTicketHandler(e, mockService, ticketcode.NewRandomSigner().PublicKey())
+
+	// When
+	err := issuedTicketHandler.TransferIssuedTicket(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual ticket.IssuedTicket
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Map_Service_Errors_When_Transfer(t *testing.T) {
+	type testCase struct {
+		serviceError    error
+		expectedStatus  int
+		expectedMessage string
+	}
+
+	testCases := []testCase{
+		{serviceError: service.ErrUserIDIsEmpty, expectedStatus: http.StatusBadRequest, expectedMessage: handler.WarnMessageWhenUserIDIsEmpty},
+		{serviceError: service.ErrTransferToSameUser, expectedStatus: http.StatusBadRequest, expectedMessage: handler.WarnMessageWhenTransferToSameUser},
+		{serviceError: service.ErrIssuedTicketWasNotFound, expectedStatus: http.StatusNotFound, expectedMessage: handler.WarnMessageWhenIssuedTicketWasNotFound},
+		{serviceError: service.ErrIssuedTicketAlreadyUsed, expectedStatus: http.StatusConflict, expectedMessage: handler.WarnMessageWhenIssuedTicketAlreadyUsed},
+		{serviceError: service.ErrTransferWindowClosed, expectedStatus: http.StatusConflict, expectedMessage: handler.WarnMessageWhenTransferWindowClosed},
+		{serviceError: service.ErrIssuedTicketRefunded, expectedStatus: http.StatusGone, expectedMessage: handler.WarnMessageWhenIssuedTicketRefunded},
+		{serviceError: errors.New("boom"), expectedStatus: http.StatusInternalServerError, expectedMessage: handler.WarnInternalServerError},
+	}
+
+	for _, test := range testCases {
+		// Given
+		req := httptest.NewRequest(http.MethodPost, "/issued_tickets/old/transfer", bytes.NewBufferString(`{"to_user_id":"bob"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(req, rec)
+		c.SetPath("/issued_tickets/:code/transfer")
+		c.SetParamNames("code")
+		c.SetParamValues("old")
+
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		mockService.EXPECT().TransferIssuedTicket(gomock.Any(), "old", "bob").Return(nil, test.serviceError).Times(1)
+
+		issuedTicketHandler := handler.NewDefaultIssuedTicketHandler(e, mockService, ticketcode.NewRandomSigner().PublicKey())
+
+		// When
+		err := issuedTicketHandler.TransferIssuedTicket(c)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, test.expectedStatus, rec.Code)
+		assert.Equal(t, test.expectedMessage, rec.Body.String())
+	}
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+type DefaultLedgerHandler struct {
+	service service.Service
+}
+
+func NewDefaultLedgerHandler(e *echo.Echo, service service.Service) *DefaultLedgerHandler {
+	h := DefaultLedgerHandler{service: service}
+
+	e.GET("/ticket_options/:id/ledger", h.ListLedgerEntries)
+
+	return &h
+}
+
+// ListLedgerEntries
+// @Tags ticket_options
+// @Summary      List allocation ledger entries
+// @Description  List every change of the tickets left of a ticket option oldest first. Pass the id of the last item as after_id to get the next page
+// @Produce      json
+// @Param        id        path      int  true   "Ticket option ID"
+// @Param        limit     query     int  false  "Page size, defaults to 50, at most 100"
+// @Param        after_id  query     int  false  "Return ledger entries with a greater id"
+// @Success      200  {array}   ticket.LedgerEntry
+// @Failure      400              {string}  string
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/ledger [get]
+func (h *DefaultLedgerHandler) ListLedgerEntries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	filter := ticket.LedgerFilter{TicketID: id}
+	if err = echo.QueryParamsBinder(c).
+		Int("limit", &filter.Limit).
+		Int("after_id", &filter.AfterID).
+		BindError(); err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidPagination)
+	}
+
+	entries, err := h.service.ListLedgerEntries(c.Request().Context(), filter)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,93 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ledger Unit Tests
+
+func Test_Should_Return_Status_OK_When_List_Ledger_Entries(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/?limit=10&after_id=5", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket_options/:id/ledger")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	purchaseID := 3
+	expected := []ticket.LedgerEntry{{ID: 6, TicketID: 1, Kind: ticket.LedgerPurchase, Delta: -2, PurchaseID: &purchaseID}}
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().ListLedgerEntries(gomock.Any(), ticket.LedgerFilter{TicketID: 1, Limit: 10, AfterID: 5}).
+		Return(expected, nil).Times(1)
+
+	ledgerHandler := handler.NewDefaultLedgerHandler(e, mockService)
+
+	// When
+	err := ledgerHandler.ListLedgerEntries(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual []ticket.LedgerEntry
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Return_Status_Bad_Request_When_List_Ledger_Entries_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		id              string
+		query           string
+		serviceError    error
+		expectedMessage string
+	}
+
+	testCases := []testCase{
+		{id: "one", expectedMessage: handler.WarnMessageWhenInvalidID},
+		{id: "1", query: "limit=ten", expectedMessage: handler.WarnMessageWhenInvalidPagination},
+		{id: "0", serviceError: service.ErrIDLowerThanOne, expectedMessage: handler.WarnMessageWhenInvalidID},
+	}
+
+	for _, test := range testCases {
+		// Given
+		req := httptest.NewRequest(http.MethodGet, "/?"+test.query, nil)
+		rec := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(req, rec)
+		c.SetPath("/ticket_options/:id/ledger")
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		if test.serviceError != nil {
+			mockService.EXPECT().ListLedgerEntries(gomock.Any(), gomock.Any()).Return(nil, test.serviceError).Times(1)
+		}
+
+		ledgerHandler := handler.NewDefaultLedgerHandler(e, mockService)
+
+		// When
+		err := ledgerHandler.ListLedgerEntries(c)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, test.expectedMessage, rec.Body.String())
+	}
+}
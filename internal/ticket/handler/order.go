@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	WarnMessageWhenOrderHasNoItems        = "Order needs at least one item"
+	WarnMessageWhenOrderItemIsDuplicated  = "Order needs one item per ticket option"
+	WarnMessageWhenOrderWasNotFound       = "Order was not found"
+	WarnMessageWhenOrderStatusConflict    = "Order cannot change to that status"
+	WarnMessageWhenOrderExpired           = "Order was not paid in time"
+	WarnMessageWhenPurchaseBelongsToOrder = "Purchase is an item of an order, refund the order instead"
+)
+
+type DefaultOrderHandler struct {
+	service service.Service
+}
+
+func NewDefaultOrderHandler(e *echo.Echo, service service.Service) *DefaultOrderHandler {
+	h := DefaultOrderHandler{service: service}
+
+	e.POST("/orders", h.CreateOrder)
+	e.GET("/orders/:id", h.GetOrder)
+	e.POST("/orders/:id/pay", h.PayOrder)
+	e.POST("/orders/:id/cancel", h.CancelOrder)
+	e.POST("/orders/:id/refund", h.RefundOrder)
+
+	return &h
+}
+
+// CreateOrder
+// @Tags order
+// @Summary      Place an order
+// @Description  Place a pending order for tickets of several ticket options at once. Either every item is held off its allocation or none is, until the order is paid, cancelled or expires
+// @Param requestBody body CreateOrderRequestBody true "Order Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      201  {object}  ticket.Order
+// @Failure      400              {string}  string
+// @Failure      403              {string}  string  "An item is outside its sale window"
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /orders [post]
+func (h *DefaultOrderHandler) CreateOrder(c echo.Context) error {
+	body := new(CreateOrderRequestBody)
+	if err := c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	items := make([]ticket.OrderItem, len(body.Items))
+	for i, item := range body.Items {
+		items[i] = ticket.OrderItem{TicketID: item.TicketID, Quantity: item.Quantity}
+	}
+
+	order, err := h.service.CreateOrder(c.Request().Context(), body.UserID, items)
+	if err != nil {
+		switch err {
+		case service.ErrUserIDIsEmpty:
+			return c.String(http.StatusBadRequest, WarnMessageWhenUserIDIsEmpty)
+		case service.ErrOrderHasNoItems:
+			return c.String(http.StatusBadRequest, WarnMessageWhenOrderHasNoItems)
+		case service.ErrOrderItemIsDuplicated:
+			return c.String(http.StatusBadRequest, WarnMessageWhenOrderItemIsDuplicated)
+		case service.ErrQuantityLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenQuantityLowerThanOne)
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrTicketOptionIsSeated:
+			return c.String(http.StatusBadRequest, WarnMessageWhenTicketOptionIsSeated)
+		case service.ErrPurchaseTicketMoreThanAvailable:
+			return c.String(http.StatusBadRequest, WarnMessageWhenPurchaseTicketMoreThanAvailable)
+		case service.ErrSaleNotStarted:
+			return c.String(http.StatusForbidden, WarnMessageWhenSaleNotStarted)
+		case service.ErrSaleEnded:
+			return c.String(http.StatusForbidden, WarnMessageWhenSaleEnded)
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, order)
+}
+
+// GetOrder
+// @Tags order
+// @Summary      Get an order
+// @Description  Get an order with its items
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  ticket.Order
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /orders/{id} [get]
+func (h *DefaultOrderHandler) GetOrder(c echo.Context) error {
+	return h.respond(c, h.service.GetOrder)
+}
+
+// PayOrder
+// @Tags order
+// @Summary      Pay an order
+// @Description  Mark a pending order as paid and issue the tickets of all its items, unless it has expired
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  ticket.Order
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string  "Order is not pending or has expired"
+// @Failure      500              {string}  string
+// @Router       /orders/{id}/pay [post]
+func (h *DefaultOrderHandler) PayOrder(c echo.Context) error {
+	return h.respond(c, h.service.PayOrder)
+}
+
+// CancelOrder
+// @Tags order
+// @Summary      Cancel an order
+// @Description  Cancel a pending order and give its tickets back to their ticket options
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  ticket.Order
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string  "Order is not pending"
+// @Failure      500              {string}  string
+// @Router       /orders/{id}/cancel [post]
+func (h *DefaultOrderHandler) CancelOrder(c echo.Context) error {
+	return h.respond(c, h.service.CancelOrder)
+}
+
+// RefundOrder
+// @Tags order
+// @Summary      Refund an order
+// @Description  Refund a paid order, voiding its tickets and giving them back to their ticket options
+// @Produce      json
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  ticket.Order
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string  "Order is not paid"
+// @Failure      500              {string}  string
+// @Router       /orders/{id}/refund [post]
+func (h *DefaultOrderHandler) RefundOrder(c echo.Context) error {
+	return h.respond(c, h.service.RefundOrder)
+}
+
+// respond calls get with the order id of the path and answers with the order it returns.
+func (h *DefaultOrderHandler) respond(c echo.Context, get func(ctx context.Context, id int) (*ticket.Order, error)) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	order, err := get(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrOrderWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenOrderWasNotFound)
+		case service.ErrOrderStatusConflict:
+			return c.String(http.StatusConflict, WarnMessageWhenOrderStatusConflict)
+		case service.ErrOrderExpired:
+			return c.String(http.StatusConflict, WarnMessageWhenOrderExpired)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
@@ -0,0 +1,120 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Order Unit Tests
+
+func Test_Should_Return_Status_Created_When_Create_Order(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/orders",
+		strings.NewReader(`{"user_id":"test","items":[{"ticket_id":1,"quantity":2},{"ticket_id":2,"quantity":1}]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	items := []ticket.OrderItem{{TicketID: 1, Quantity: 2}, {TicketID: 2, Quantity: 1}}
+	expected := ticket.Order{ID: 1, UserID: "test", Status: ticket.OrderPending, TotalPrice: 2500, Items: []ticket.OrderItem{
+		{ID: 1, OrderID: 1, TicketID: 1, Quantity: 2, TotalPrice: 2000, PurchaseID: 1},
+		{ID: 2, OrderID: 1, TicketID: 2, Quantity: 1, TotalPrice: 500, PurchaseID: 2},
+	}}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().CreateOrder(gomock.Any(), "test", items).Return(&expected, nil).Times(1)
+
+	orderHandler := handler.NewDefaultOrderHandler(e, mockService)
+
+	// When
+	err := orderHandler.CreateOrder(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual ticket.Order
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Return_Status_Bad_Request_When_Order_Is_More_Than_Available(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/orders",
+		strings.NewReader(`{"user_id":"test","items":[{"ticket_id":1,"quantity":20}]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().CreateOrder(gomock.Any(), "test", gomock.Any()).
+		Return(nil, service.ErrPurchaseTicketMoreThanAvailable).Times(1)
+
+	orderHandler := handler.NewDefaultOrderHandler(e, mockService)
+
+	// When
+	err := orderHandler.CreateOrder(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenPurchaseTicketMoreThanAvailable, rec.Body.String())
+}
+
+func Test_Should_Map_Errors_When_Change_Order_Status(t *testing.T) {
+	type testCase struct {
+		id              string
+		serviceError    error
+		expectedStatus  int
+		expectedMessage string
+	}
+
+	testCases := []testCase{
+		{id: "x", expectedStatus: http.StatusBadRequest, expectedMessage: handler.WarnMessageWhenInvalidID},
+		{id: "1", serviceError: service.ErrOrderWasNotFound, expectedStatus: http.StatusNotFound, expectedMessage: handler.WarnMessageWhenOrderWasNotFound},
+		{id: "1", serviceError: service.ErrOrderStatusConflict, expectedStatus: http.StatusConflict, expectedMessage: handler.WarnMessageWhenOrderStatusConflict},
+		{id: "1", serviceError: service.ErrOrderExpired, expectedStatus: http.StatusConflict, expectedMessage: handler.WarnMessageWhenOrderExpired},
+	}
+
+	for _, test := range testCases {
+		// Given
+		req := httptest.NewRequest(http.MethodPost, "/orders/"+test.id+"/pay", nil)
+		rec := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(req, rec)
+		c.SetPath("/orders/:id/pay")
+		c.SetParamNames("id")
+		c.SetParamValues(test.id)
+
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		if test.serviceError != nil {
+			mockService.EXPECT().PayOrder(gomock.Any(), 1).Return(nil, test.serviceError).Times(1)
+		}
+
+		orderHandler := handler.NewDefaultOrderHandler(e, mockService)
+
+		// When
+		err := orderHandler.PayOrder(c)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, test.expectedStatus, rec.Code)
+		assert.Equal(t, test.expectedMessage, rec.Body.String())
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// HeaderXOrganizerID names the organizer a request acts for when a trusted gateway authenticates callers.
+const HeaderXOrganizerID = "X-Organizer-ID"
+
+var WarnMessageWhenOrganizerIsUnknown = "A valid bearer token or organizer id is needed"
+
+// ResolveOrganizer makes every request act for the organizer sending it: the one its bearer token
+// was issued to or, when trustHeader is set because a gateway in front authenticates callers, the
+// one named by the X-Organizer-ID header. Requests of no known organizer are refused unless skipper
+// lets them through.
+func ResolveOrganizer(svc service.Service, trustHeader bool, skipper middleware.Skipper) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+
+			organizer, err := resolveOrganizer(c, svc, trustHeader)
+			if err != nil {
+				switch err {
+				case service.ErrOrganizerWasNotFound, service.ErrOrganizerTokenIsEmpty, service.ErrIDLowerThanOne:
+					return c.String(http.StatusUnauthorized, WarnMessageWhenOrganizerIsUnknown)
+				default:
+					return c.String(http.StatusInternalServerError, WarnInternalServerError)
+				}
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(tenant.WithOrganizer(req.Context(), organizer.ID)))
+
+			return next(c)
+		}
+	}
+}
+
+func resolveOrganizer(c echo.Context, svc service.Service, trustHeader bool) (*ticket.Organizer, error) {
+	ctx := c.Request().Context()
+
+	if authorization := c.Request().Header.Get(echo.HeaderAuthorization); authorization != "" || !trustHeader {
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		if token == authorization {
+			return nil, service.ErrOrganizerTokenIsEmpty
+		}
+		return svc.AuthenticateOrganizer(ctx, token)
+	}
+
+	id, err := strconv.Atoi(c.Request().Header.Get(HeaderXOrganizerID))
+	if err != nil {
+		return nil, service.ErrIDLowerThanOne
+	}
+
+	return svc.GetOrganizer(ctx, id)
+}
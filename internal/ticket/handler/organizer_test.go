@@ -0,0 +1,160 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Organizer Unit Tests
+
+func Test_Should_Act_For_Resolved_Organizer(t *testing.T) {
+	type testCase struct {
+		name        string
+		headers     map[string]string
+		trustHeader bool
+		expect      func(mockService *mocks.MockService)
+	}
+
+	testCases := []testCase{
+		{
+			name:    "bearer token",
+			headers: map[string]string{echo.HeaderAuthorization: "Bearer secret-token"},
+			expect: func(mockService *mocks.MockService) {
+				mockService.EXPECT().AuthenticateOrganizer(gomock.Any(), "secret-token").Return(&ticket.Organizer{ID: 2}, nil).Times(1)
+			},
+		},
+		{
+			name:        "trusted header",
+			headers:     map[string]string{handler.HeaderXOrganizerID: "2"},
+			trustHeader: true,
+			expect: func(mockService *mocks.MockService) {
+				mockService.EXPECT().GetOrganizer(gomock.Any(), 2).Return(&ticket.Organizer{ID: 2}, nil).Times(1)
+			},
+		},
+		{
+			name:        "bearer token over trusted header",
+			headers:     map[string]string{echo.HeaderAuthorization: "Bearer secret-token", handler.HeaderXOrganizerID: "3"},
+			trustHeader: true,
+			expect: func(mockService *mocks.MockService) {
+				mockService.EXPECT().AuthenticateOrganizer(gomock.Any(), "secret-token").Return(&ticket.Organizer{ID: 2}, nil).Times(1)
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			req := httptest.NewRequest(http.MethodGet, "/ticket_options", nil)
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			mockService := mocks.NewMockService(gomock.NewController(t))
+			test.expect(mockService)
+
+			var organizerID int
+			next := func(c echo.Context) error {
+				organizerID, _ = tenant.OrganizerFrom(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}
+
+			// When
+			err := handler.ResolveOrganizer(mockService, test.trustHeader, nil)(next)(c)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, 2, organizerID)
+		})
+	}
+}
+
+func Test_Should_Return_Status_Unauthorized_When_Organizer_Is_Not_Resolved(t *testing.T) {
+	type testCase struct {
+		name        string
+		headers     map[string]string
+		trustHeader bool
+		expect      func(mockService *mocks.MockService)
+	}
+
+	testCases := []testCase{
+		{name: "no credentials"},
+		{name: "untrusted header", headers: map[string]string{handler.HeaderXOrganizerID: "2"}},
+		{name: "not a bearer token", headers: map[string]string{echo.HeaderAuthorization: "Basic dXNlcjpwYXNz"}},
+		{name: "header is not a number", headers: map[string]string{handler.HeaderXOrganizerID: "two"}, trustHeader: true},
+		{
+			name:    "unknown token",
+			headers: map[string]string{echo.HeaderAuthorization: "Bearer unknown-token"},
+			expect: func(mockService *mocks.MockService) {
+				mockService.EXPECT().AuthenticateOrganizer(gomock.Any(), "unknown-token").Return(nil, service.ErrOrganizerWasNotFound).Times(1)
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			req := httptest.NewRequest(http.MethodGet, "/ticket_options", nil)
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			mockService := mocks.NewMockService(gomock.NewController(t))
+			if test.expect != nil {
+				test.expect(mockService)
+			}
+
+			next := func(c echo.Context) error {
+				t.Fatal("request should not reach the handler")
+				return nil
+			}
+
+			// When
+			err := handler.ResolveOrganizer(mockService, test.trustHeader, nil)(next)(c)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, handler.WarnMessageWhenOrganizerIsUnknown, rec.Body.String())
+		})
+	}
+}
+
+func Test_Should_Let_Skipped_Requests_Through_Without_Organizer(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	skipper := func(c echo.Context) bool { return true }
+
+	// When
+	err := handler.ResolveOrganizer(mockService, false, skipper)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
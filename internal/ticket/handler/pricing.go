@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	WarnMessageWhenInvalidQuantity        = "quantity needs to be a valid number"
+	WarnMessageWhenPriceTierKindIsUnknown = "Price tier kind must be one of early_bird, sold_step and last_minute"
+	WarnMessageWhenPriceTierIsIncomplete  = "early_bird needs until, sold_step needs after_sold and last_minute needs from"
+	WarnMessageWhenPriceTierWasNotFound   = "Price tier was not found"
+)
+
+type DefaultPricingHandler struct {
+	service service.Service
+}
+
+func NewDefaultPricingHandler(e *echo.Echo, service service.Service) *DefaultPricingHandler {
+	h := DefaultPricingHandler{service: service}
+
+	e.GET("/ticket_options/:id/price", h.QuotePrice)
+	e.POST("/ticket_options/:id/price_tiers", h.CreatePriceTier)
+	e.DELETE("/ticket_options/:id/price_tiers/:tier_id", h.DeletePriceTier)
+
+	return &h
+}
+
+// QuotePrice
+// @Tags pricing
+// @Summary      Quote the price of tickets
+// @Description  Price a quantity of tickets as a purchase made now would be. The price is not held
+// @Produce      json
+// @Param        id   path      int  true  "Ticket option ID"
+// @Param        quantity   query      int  false  "Quantity, 1 by default"
+// @Success      200  {object}  ticket.PriceQuote
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/price [get]
+func (h *DefaultPricingHandler) QuotePrice(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	quantity := 1
+	if err = echo.QueryParamsBinder(c).Int("quantity", &quantity).BindError(); err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidQuantity)
+	}
+
+	quote, err := h.service.QuotePrice(c.Request().Context(), id, quantity)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrQuantityLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenQuantityLowerThanOne)
+		case service.ErrPurchaseTicketMoreThanAvailable:
+			return c.String(http.StatusBadRequest, WarnMessageWhenPurchaseTicketMoreThanAvailable)
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, quote)
+}
+
+// CreatePriceTier
+// @Tags pricing
+// @Summary      Add a price tier to a ticket option
+// @Description  Override the face value early-bird until a date, after a number of tickets sold or last-minute from a date
+// @Param        id   path      int  true  "Ticket option ID"
+// @Param requestBody body CreatePriceTierRequestBody true "Price Tier Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      201  {object}  ticket.PriceTier
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/price_tiers [post]
+func (h *DefaultPricingHandler) CreatePriceTier(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	body := new(CreatePriceTierRequestBody)
+	if err = c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	tier, err := h.service.CreatePriceTier(c.Request().Context(), id, ticket.PriceTier{
+		Kind:      body.Kind,
+		Price:     body.Price,
+		Until:     body.Until,
+		AfterSold: body.AfterSold,
+		From:      body.From,
+	})
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrPriceIsNegative:
+			return c.String(http.StatusBadRequest, WarnMessageWhenPriceIsNegative)
+		case service.ErrPriceTierKindIsUnknown:
+			return c.String(http.StatusBadRequest, WarnMessageWhenPriceTierKindIsUnknown)
+		case service.ErrPriceTierIsIncomplete:
+			return c.String(http.StatusBadRequest, WarnMessageWhenPriceTierIsIncomplete)
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, tier)
+}
+
+// DeletePriceTier
+// @Tags pricing
+// @Summary      Remove a price tier from a ticket option
+// @Param        id   path      int  true  "Ticket option ID"
+// @Param        tier_id   path      int  true  "Price tier ID"
+// @Success      204
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/price_tiers/{tier_id} [delete]
+func (h *DefaultPricingHandler) DeletePriceTier(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	tierID, err := strconv.Atoi(c.Param("tier_id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	if err = h.service.DeletePriceTier(c.Request().Context(), id, tierID); err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrPriceTierWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenPriceTierWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
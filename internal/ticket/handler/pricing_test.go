@@ -0,0 +1,72 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Pricing Unit Tests
+
+func Test_Should_Return_Status_OK_When_Quote_Price(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/ticket_options/1/price?quantity=2", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket_options/:id/price")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	expected := ticket.PriceQuote{TicketID: 1, Quantity: 2, UnitPrices: []int{1000, 1500}, Total: 2500}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().QuotePrice(gomock.Any(), 1, 2).Return(&expected, nil).Times(1)
+
+	pricingHandler := handler.NewDefaultPricingHandler(e, mockService)
+
+	// When
+	err := pricingHandler.QuotePrice(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual ticket.PriceQuote
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Return_Status_Bad_Request_When_Quote_Quantity_Is_Not_A_Number(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/ticket_options/1/price?quantity=two", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket_options/:id/price")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().QuotePrice(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	pricingHandler := handler.NewDefaultPricingHandler(e, mockService)
+
+	// When
+	err := pricingHandler.QuotePrice(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenInvalidQuantity, rec.Body.String())
+}
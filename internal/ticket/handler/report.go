@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// salesRowsPerFlush is how many rows of a report are written before they are sent on.
+const salesRowsPerFlush = 100
+
+var (
+	WarnMessageWhenInvalidSalesFilter      = "ticket_id, from, to and interval need to be valid"
+	WarnMessageWhenReportPeriodIsInvalid   = "Report period cannot end before it starts."
+	WarnMessageWhenReportFormatIsUnknown   = "format needs to be json or csv"
+	WarnMessageWhenReportIntervalIsUnknown = "interval needs to be day or hour"
+)
+
+type DefaultReportHandler struct {
+	service service.Service
+}
+
+func NewDefaultReportHandler(e *echo.Echo, service service.Service) *DefaultReportHandler {
+	h := DefaultReportHandler{service: service}
+
+	e.GET("/reports/sales", h.GetSalesReport)
+
+	return &h
+}
+
+// GetSalesReport
+// @Tags report
+// @Summary      Sales report
+// @Description  Quantity sold, revenue, refunds, remaining allocation and sell-through of each ticket_option, over the
+// @Description  whole period or split by day or hour in UTC. Sales and refunds count when they were made. The report is
+// @Description  streamed as it is read, as a JSON array or as CSV with a header row
+// @Produce      json
+// @Produce      text/csv
+// @Param        ticket_id  query     int     false  "Report only this ticket option"
+// @Param        from       query     string  false  "Sales and refunds made at or after, in RFC 3339"
+// @Param        to         query     string  false  "Sales and refunds made before, in RFC 3339"
+// @Param        interval   query     string  false  "day or hour to split the report by"
+// @Param        format     query     string  false  "json (default) or csv"
+// @Success      200  {array}   ticket.SalesRow
+// @Failure      400              {string}  string
+// @Failure      500              {string}  string
+// @Router       /reports/sales [get]
+func (h *DefaultReportHandler) GetSalesReport(c echo.Context) error {
+	filter := ticket.SalesFilter{}
+	var from, to time.Time
+	var interval, format string
+
+	if err := echo.QueryParamsBinder(c).
+		Int("ticket_id", &filter.TicketID).
+		Time("from", &from, time.RFC3339).
+		Time("to", &to, time.RFC3339).
+		String("interval", &interval).
+		String("format", &format).
+		BindError(); err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidSalesFilter)
+	}
+
+	filter.Interval = ticket.SalesInterval(interval)
+
+	if !from.IsZero() {
+		filter.From = &from
+	}
+
+	if !to.IsZero() {
+		filter.To = &to
+	}
+
+	res := c.Response()
+
+	var encoder salesEncoder
+	switch format {
+	case "", "json":
+		encoder = &jsonSalesEncoder{w: res}
+	case "csv":
+		encoder = &csvSalesEncoder{w: csv.NewWriter(res)}
+	default:
+		return c.String(http.StatusBadRequest, WarnMessageWhenReportFormatIsUnknown)
+	}
+
+	written := 0
+	err := h.service.StreamSalesReport(c.Request().Context(), filter, func(row ticket.SalesRow) error {
+		if !res.Committed {
+			if err := startSalesReport(res, encoder); err != nil {
+				return err
+			}
+		}
+
+		if err := encoder.row(row); err != nil {
+			return err
+		}
+
+		written++
+		if written%salesRowsPerFlush == 0 {
+			res.Flush()
+		}
+
+		return nil
+	})
+	if err != nil {
+		if res.Committed {
+			// The status has been sent already, so the client can only tell by the report being cut short.
+			log.Errorf("sales report cut short after %d rows: %v", written, err)
+			return nil
+		}
+
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrReportPeriodIsInvalid:
+			return c.String(http.StatusBadRequest, WarnMessageWhenReportPeriodIsInvalid)
+		case service.ErrReportIntervalIsUnknown:
+			return c.String(http.StatusBadRequest, WarnMessageWhenReportIntervalIsUnknown)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	if !res.Committed {
+		if err = startSalesReport(res, encoder); err != nil {
+			return nil
+		}
+	}
+
+	if err = encoder.end(); err != nil {
+		return nil
+	}
+	res.Flush()
+
+	return nil
+}
+
+func startSalesReport(res *echo.Response, encoder salesEncoder) error {
+	res.Header().Set(echo.HeaderContentType, encoder.contentType())
+	res.WriteHeader(http.StatusOK)
+
+	return encoder.begin()
+}
+
+// salesEncoder writes a sales report one row at a time.
+type salesEncoder interface {
+	contentType() string
+	begin() error
+	row(row ticket.SalesRow) error
+	end() error
+}
+
+type jsonSalesEncoder struct {
+	w       io.Writer
+	written bool
+}
+
+func (e *jsonSalesEncoder) contentType() string {
+	return echo.MIMEApplicationJSONCharsetUTF8
+}
+
+func (e *jsonSalesEncoder) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonSalesEncoder) row(row ticket.SalesRow) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	if e.written {
+		if _, err = io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.written = true
+
+	_, err = e.w.Write(data)
+
+	return err
+}
+
+func (e *jsonSalesEncoder) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+type csvSalesEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvSalesEncoder) contentType() string {
+	return "text/csv; charset=UTF-8"
+}
+
+func (e *csvSalesEncoder) begin() error {
+	return e.w.Write([]string{"ticket_id", "ticket_name", "period", "sold", "revenue", "refunded", "refunds",
+		"remaining", "capacity", "sell_through"})
+}
+
+func (e *csvSalesEncoder) row(row ticket.SalesRow) error {
+	period := ""
+	if row.Period != nil {
+		period = row.Period.UTC().Format(time.RFC3339)
+	}
+
+	if err := e.w.Write([]string{
+		strconv.Itoa(row.TicketID),
+		row.TicketName,
+		period,
+		strconv.Itoa(row.Sold),
+		strconv.Itoa(row.Revenue),
+		strconv.Itoa(row.Refunded),
+		strconv.Itoa(row.Refunds),
+		strconv.Itoa(row.Remaining),
+		strconv.Itoa(row.Capacity),
+		strconv.FormatFloat(row.SellThrough, 'f', 2, 64), //nolint:gomnd
+	}); err != nil {
+		return err
+	}
+
+	// Hand the row on to the response, which is flushed every salesRowsPerFlush rows.
+	e.w.Flush()
+
+	return e.w.Error()
+}
+
+func (e *csvSalesEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
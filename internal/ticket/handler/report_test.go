@@ -0,0 +1,204 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Sales Report Unit Tests
+
+func streamRows(rows ...ticket.SalesRow) func(context.Context, ticket.SalesFilter, func(ticket.SalesRow) error) error {
+	return func(_ context.Context, _ ticket.SalesFilter, emit func(ticket.SalesRow) error) error {
+		for _, row := range rows {
+			if err := emit(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func Test_Should_Stream_Sales_Report_As_JSON(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet,
+		"/reports/sales?from=2026-06-01T00:00:00Z&to=2026-06-03T00:00:00Z&interval=day&ticket_id=1", nil)
+	rec := httptest.NewRecorder()
+
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC)
+	day := from.Add(24 * time.Hour)
+	expected := []ticket.SalesRow{
+		{TicketID: 1, TicketName: "early bird", Period: &from, Sold: 10, Revenue: 25000, Remaining: 80, Capacity: 100, SellThrough: 10},
+		{TicketID: 1, TicketName: "early bird", Period: &day, Sold: 10, Revenue: 25000, Refunded: 2, Refunds: 5000,
+			Remaining: 80, Capacity: 100, SellThrough: 8},
+	}
+
+	e := echo.New()
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().StreamSalesReport(gomock.Any(),
+		ticket.SalesFilter{TicketID: 1, From: &from, To: &to, Interval: ticket.SalesByDay}, gomock.Any()).
+		DoAndReturn(streamRows(expected...)).Times(1)
+
+	handler.NewDefaultReportHandler(e, mockService)
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	var actual []ticket.SalesRow
+	assert.Nil(t, json.NewDecoder(rec.Body).Decode(&actual))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, echo.MIMEApplicationJSONCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Stream_Sales_Report_As_CSV(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/reports/sales?format=csv", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().StreamSalesReport(gomock.Any(), ticket.SalesFilter{}, gomock.Any()).
+		DoAndReturn(streamRows(
+			ticket.SalesRow{TicketID: 1, TicketName: "regular, standing", Sold: 3, Revenue: 7500, Remaining: 7, Capacity: 10, SellThrough: 30},
+			ticket.SalesRow{TicketID: 2, TicketName: "vip", Remaining: 3, Capacity: 3},
+		)).Times(1)
+
+	handler.NewDefaultReportHandler(e, mockService)
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "ticket_id,ticket_name,period,sold,revenue,refunded,refunds,remaining,capacity,sell_through\n"+
+		"1,\"regular, standing\",,3,7500,0,0,7,10,30.00\n"+
+		"2,vip,,0,0,0,0,3,3,0.00\n", rec.Body.String())
+}
+
+func Test_Should_Return_Empty_Sales_Report_When_Nothing_Matches(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/reports/sales", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().StreamSalesReport(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamRows()).Times(1)
+
+	handler.NewDefaultReportHandler(e, mockService)
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "[]\n", rec.Body.String())
+}
+
+func Test_Should_Cut_Sales_Report_Short_When_Reading_Fails_Midway(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/reports/sales", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().StreamSalesReport(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ ticket.SalesFilter, emit func(ticket.SalesRow) error) error {
+			_ = emit(ticket.SalesRow{TicketID: 1})
+			return errors.New("connection reset")
+		}).Times(1)
+
+	handler.NewDefaultReportHandler(e, mockService)
+
+	// When
+	e.ServeHTTP(rec, req)
+
+	// Then
+	var actual []ticket.SalesRow
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotNil(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+}
+
+func Test_Should_Return_Error_When_Sales_Report_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		name                string
+		query               string
+		serviceErr          error
+		expectedStatus      int
+		expectedWarnMessage string
+	}
+
+	testCases := []testCase{
+		{
+			name:                "from is not a time",
+			query:               "from=yesterday",
+			expectedStatus:      http.StatusBadRequest,
+			expectedWarnMessage: handler.WarnMessageWhenInvalidSalesFilter,
+		},
+		{
+			name:                "format is unknown",
+			query:               "format=xlsx",
+			expectedStatus:      http.StatusBadRequest,
+			expectedWarnMessage: handler.WarnMessageWhenReportFormatIsUnknown,
+		},
+		{
+			name:                "period ends before it starts",
+			query:               "from=2026-06-02T00:00:00Z&to=2026-06-01T00:00:00Z",
+			serviceErr:          service.ErrReportPeriodIsInvalid,
+			expectedStatus:      http.StatusBadRequest,
+			expectedWarnMessage: handler.WarnMessageWhenReportPeriodIsInvalid,
+		},
+		{
+			name:                "interval is unknown",
+			query:               "interval=week",
+			serviceErr:          service.ErrReportIntervalIsUnknown,
+			expectedStatus:      http.StatusBadRequest,
+			expectedWarnMessage: handler.WarnMessageWhenReportIntervalIsUnknown,
+		},
+		{
+			name:                "database is unavailable",
+			serviceErr:          errors.New("database is unavailable"),
+			expectedStatus:      http.StatusInternalServerError,
+			expectedWarnMessage: handler.WarnInternalServerError,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			req := httptest.NewRequest(http.MethodGet, "/reports/sales?"+test.query, nil)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			mockService := mocks.NewMockService(gomock.NewController(t))
+			if test.serviceErr != nil {
+				mockService.EXPECT().StreamSalesReport(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.serviceErr).Times(1)
+			}
+
+			handler.NewDefaultReportHandler(e, mockService)
+
+			// When
+			e.ServeHTTP(rec, req)
+
+			// Then
+			assert.Equal(t, test.expectedStatus, rec.Code)
+			assert.Equal(t, test.expectedWarnMessage, rec.Body.String())
+		})
+	}
+}
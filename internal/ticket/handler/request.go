@@ -1,12 +1,89 @@
 package handler
 
+import (
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
 type CreateTicketOptionRequestBody struct {
 	Name       string `json:"name"`
 	Desc       string `json:"desc"`
 	Allocation int    `json:"allocation"`
+	// Price is the face value of one ticket in minor units of the currency.
+	Price int `json:"price"`
+	// StartsAt is optional, in RFC 3339.
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	// SaleStartsAt and SaleEndsAt are optional, in RFC 3339.
+	SaleStartsAt *time.Time `json:"sale_starts_at,omitempty"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at,omitempty"`
 }
 
 type CreatePurchaseTicketOptionRequestBody struct {
 	Quantity int    `json:"quantity"`
 	UserID   string `json:"user_id"`
 }
+
+type CreateWebhookRequestBody struct {
+	URL        string            `json:"url"`
+	EventTypes ticket.EventTypes `json:"event_types"`
+	Secret     string            `json:"secret"`
+}
+
+type CheckinRequestBody struct {
+	Code    string `json:"code"`
+	EventID int    `json:"event_id"`
+	GateID  string `json:"gate_id"`
+}
+
+type TransferIssuedTicketRequestBody struct {
+	ToUserID string `json:"to_user_id"`
+}
+
+type CreateResaleListingRequestBody struct {
+	SellerID string `json:"seller_id"`
+	// Price is in minor units of the currency.
+	Price int `json:"price"`
+}
+
+type BuyResaleListingRequestBody struct {
+	BuyerID string `json:"buyer_id"`
+}
+
+type CancelResaleListingRequestBody struct {
+	SellerID string `json:"seller_id"`
+}
+
+type CreatePriceTierRequestBody struct {
+	Kind ticket.PriceTierKind `json:"kind"`
+	// Price is in minor units of the currency.
+	Price     int        `json:"price"`
+	Until     *time.Time `json:"until,omitempty"`
+	AfterSold int        `json:"after_sold,omitempty"`
+	From      *time.Time `json:"from,omitempty"`
+}
+
+type CreateSeatMapRequestBody struct {
+	Sections []ticket.SectionLayout `json:"sections"`
+	Best     *ticket.SeatPoint      `json:"best"`
+}
+
+type HoldBestAvailableRequestBody struct {
+	Quantity int    `json:"quantity"`
+	UserID   string `json:"user_id"`
+}
+
+type PurchaseSeatsRequestBody struct {
+	SeatIDs []int  `json:"seat_ids"`
+	UserID  string `json:"user_id"`
+}
+
+type CreateOrderRequestBody struct {
+	UserID string                 `json:"user_id"`
+	Items  []OrderItemRequestBody `json:"items"`
+}
+
+type OrderItemRequestBody struct {
+	TicketID int `json:"ticket_id"`
+	Quantity int `json:"quantity"`
+}
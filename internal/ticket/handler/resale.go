@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	WarnMessageWhenIssuedTicketNotOwned       = "Ticket belongs to another user"
+	WarnMessageWhenResaleListingWasNotFound   = "Resale listing was not found"
+	WarnMessageWhenResaleListingAlreadyExists = "Ticket is listed for resale already"
+	WarnMessageWhenResaleListingNotAvailable  = "Resale listing is no longer available"
+	WarnMessageWhenResalePriceAboveCap        = "Resale price is higher than allowed"
+)
+
+type DefaultResaleHandler struct {
+	service service.Service
+}
+
+func NewDefaultResaleHandler(e *echo.Echo, service service.Service) *DefaultResaleHandler {
+	h := DefaultResaleHandler{service: service}
+
+	e.POST("/issued_tickets/:code/resale_listings", h.CreateResaleListing)
+	e.GET("/ticket_options/:id/resale_listings", h.ListResaleListings)
+	e.POST("/resale_listings/:id/purchase", h.BuyResaleListing)
+	e.POST("/resale_listings/:id/cancel", h.CancelResaleListing)
+
+	return &h
+}
+
+// CreateResaleListing
+// @Tags resale
+// @Summary      List an issued ticket for resale
+// @Description  Offer an issued ticket to other users. The price may not exceed the resale cap on the price the ticket was bought at
+// @Param        code   path      string  true  "Issued ticket code"
+// @Param requestBody body CreateResaleListingRequestBody true "Resale Listing Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      201  {object}  ticket.ResaleListing
+// @Failure      400              {string}  string
+// @Failure      403              {string}  string  "Not the owner"
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string  "Already used or listed"
+// @Failure      410              {string}  string  "Refunded"
+// @Failure      422              {string}  string  "Price above cap"
+// @Failure      500              {string}  string
+// @Router       /issued_tickets/{code}/resale_listings [post]
+func (h *DefaultResaleHandler) CreateResaleListing(c echo.Context) error {
+	body := new(CreateResaleListingRequestBody)
+	if err := c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	listing, err := h.service.CreateResaleListing(c.Request().Context(), c.Param("code"), body.SellerID, body.Price)
+	if err != nil {
+		return resaleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, listing)
+}
+
+// ListResaleListings
+// @Tags resale
+// @Summary      List the resale listings of a ticket option
+// @Description  List the listings that can still be bought, cheapest first
+// @Produce      json
+// @Param        id   path      int  true  "Ticket option ID"
+// @Success      200  {array}   ticket.ResaleListing
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/resale_listings [get]
+func (h *DefaultResaleHandler) ListResaleListings(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	listings, err := h.service.ListResaleListings(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, listings)
+}
+
+// BuyResaleListing
+// @Tags resale
+// @Summary      Buy a resale listing
+// @Description  Buy a listed ticket. It is transferred to the buyer under a new code, returned in the listing
+// @Param        id   path      int  true  "Resale listing ID"
+// @Param requestBody body BuyResaleListingRequestBody true "Buy Resale Listing Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ticket.ResaleListing
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string  "No longer available or too close to the event"
+// @Failure      410              {string}  string  "Refunded"
+// @Failure      500              {string}  string
+// @Router       /resale_listings/{id}/purchase [post]
+func (h *DefaultResaleHandler) BuyResaleListing(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	body := new(BuyResaleListingRequestBody)
+	if err = c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	listing, err := h.service.BuyResaleListing(c.Request().Context(), id, body.BuyerID)
+	if err != nil {
+		return resaleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, listing)
+}
+
+// CancelResaleListing
+// @Tags resale
+// @Summary      Cancel a resale listing
+// @Description  Take a listing down. Only its seller can
+// @Param        id   path      int  true  "Resale listing ID"
+// @Param requestBody body CancelResaleListingRequestBody true "Cancel Resale Listing Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ticket.ResaleListing
+// @Failure      400              {string}  string
+// @Failure      403              {string}  string
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string
+// @Failure      500              {string}  string
+// @Router       /resale_listings/{id}/cancel [post]
+func (h *DefaultResaleHandler) CancelResaleListing(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	body := new(CancelResaleListingRequestBody)
+	if err = c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	listing, err := h.service.CancelResaleListing(c.Request().Context(), id, body.SellerID)
+	if err != nil {
+		return resaleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, listing)
+}
+
+func resaleError(c echo.Context, err error) error {
+	switch err {
+	case service.ErrIDLowerThanOne:
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	case service.ErrUserIDIsEmpty:
+		return c.String(http.StatusBadRequest, WarnMessageWhenUserIDIsEmpty)
+	case service.ErrPriceIsNegative:
+		return c.String(http.StatusBadRequest, WarnMessageWhenPriceIsNegative)
+	case service.ErrTransferToSameUser:
+		return c.String(http.StatusBadRequest, WarnMessageWhenTransferToSameUser)
+	case service.ErrIssuedTicketNotOwned:
+		return c.String(http.StatusForbidden, WarnMessageWhenIssuedTicketNotOwned)
+	case service.ErrIssuedTicketWasNotFound:
+		return c.String(http.StatusNotFound, WarnMessageWhenIssuedTicketWasNotFound)
+	case service.ErrResaleListingWasNotFound:
+		return c.String(http.StatusNotFound, WarnMessageWhenResaleListingWasNotFound)
+	case service.ErrTicketWasNotFound:
+		return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+	case service.ErrIssuedTicketAlreadyUsed:
+		return c.String(http.StatusConflict, WarnMessageWhenIssuedTicketAlreadyUsed)
+	case service.ErrResaleListingAlreadyExists:
+		return c.String(http.StatusConflict, WarnMessageWhenResaleListingAlreadyExists)
+	case service.ErrResaleListingNotAvailable:
+		return c.String(http.StatusConflict, WarnMessageWhenResaleListingNotAvailable)
+	case service.ErrTransferWindowClosed:
+		return c.String(http.StatusConflict, WarnMessageWhenTransferWindowClosed)
+	case service.ErrIssuedTicketRefunded:
+		return c.String(http.StatusGone, WarnMessageWhenIssuedTicketRefunded)
+	case service.ErrResalePriceAboveCap:
+		return c.String(http.StatusUnprocessableEntity, WarnMessageWhenResalePriceAboveCap)
+	default:
+		return c.String(http.StatusInternalServerError, WarnInternalServerError)
+	}
+}
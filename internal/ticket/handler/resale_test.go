@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Resale Unit Tests
+
+func Test_Should_Return_Status_Created_When_Create_Resale_Listing(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/issued_tickets/code/resale_listings",
+		strings.NewReader(`{"seller_id":"alice","price":900}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/issued_tickets/:code/resale_listings")
+	c.SetParamNames("code")
+	c.SetParamValues("code")
+
+	expected := ticket.ResaleListing{ID: 1, IssuedTicketID: 1, TicketID: 1, SellerID: "alice", Price: 900,
+		Status: ticket.ResaleListingListed}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().CreateResaleListing(gomock.Any(), "code", "alice", 900).Return(&expected, nil).Times(1)
+
+	resaleHandler := handler.NewDefaultResaleHandler(e, mockService)
+
+	// When
+	err := resaleHandler.CreateResaleListing(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual ticket.ResaleListing
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Map_Service_Errors_When_Buy_Resale_Listing(t *testing.T) {
+	type testCase struct {
+		serviceError    error
+		expectedStatus  int
+		expectedMessage string
+	}
+
+	testCases := []testCase{
+		{serviceError: service.ErrUserIDIsEmpty, expectedStatus: http.StatusBadRequest, expectedMessage: handler.WarnMessageWhenUserIDIsEmpty},
+		{serviceError: service.ErrResaleListingWasNotFound, expectedStatus: http.StatusNotFound, expectedMessage: handler.WarnMessageWhenResaleListingWasNotFound},
+		{serviceError: service.ErrResaleListingNotAvailable, expectedStatus: http.StatusConflict, expectedMessage: handler.WarnMessageWhenResaleListingNotAvailable},
+		{serviceError: service.ErrTransferWindowClosed, expectedStatus: http.StatusConflict, expectedMessage: handler.WarnMessageWhenTransferWindowClosed},
+		{serviceError: service.ErrIssuedTicketRefunded, expectedStatus: http.StatusGone, expectedMessage: handler.WarnMessageWhenIssuedTicketRefunded},
+	}
+
+	for _, test := range testCases {
+		// Given
+		req := httptest.NewRequest(http.MethodPost, "/resale_listings/3/purchase", strings.NewReader(`{"buyer_id":"bob"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(req, rec)
+		c.SetPath("/resale_listings/:id/purchase")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		mockService.EXPECT().BuyResaleListing(gomock.Any(), 3, "bob").Return(nil, test.serviceError).Times(1)
+
+		resaleHandler := handler.NewDefaultResaleHandler(e, mockService)
+
+		// When
+		err := resaleHandler.BuyResaleListing(c)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, test.expectedStatus, rec.Code)
+		assert.Equal(t, test.expectedMessage, rec.Body.String())
+	}
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	WarnMessageWhenSeatMapLayoutIsInvalid  = "Seat map needs named sections and rows of at least one seat"
+	WarnMessageWhenSeatMapIsTooLarge       = fmt.Sprintf("Seat map may have up to %d seats per row and %d seats in all", service.MaxSeatsPerRow, service.MaxSeatsPerMap)
+	WarnMessageWhenSeatMapAlreadyExists    = "Ticket option has a seat map already"
+	WarnMessageWhenTicketOptionHasSales    = "Ticket option has sold tickets already"
+	WarnMessageWhenTicketOptionIsNotSeated = "Ticket option has no seat map"
+	WarnMessageWhenTicketOptionIsSeated    = "Ticket option sells specific seats only, pick seats to purchase"
+	WarnMessageWhenSeatSelectionIsInvalid  = "Seat ids must be given once each"
+	WarnMessageWhenSeatWasNotFound         = "Seat was not found"
+	WarnMessageWhenSeatNotAvailable        = "Seat is not available"
+	WarnMessageWhenNoContiguousSeats       = "No row has that many seats available together"
+)
+
+type DefaultSeatingHandler struct {
+	service service.Service
+}
+
+func NewDefaultSeatingHandler(e *echo.Echo, service service.Service) *DefaultSeatingHandler {
+	h := DefaultSeatingHandler{service: service}
+
+	e.POST("/ticket_options/:id/seats", h.CreateSeatMap)
+	e.GET("/ticket_options/:id/seats", h.GetSeatMap)
+	e.POST("/ticket_options/:id/seat_purchases", h.PurchaseSeats)
+	e.POST("/ticket_options/:id/best_available", h.HoldBestAvailable)
+
+	return &h
+}
+
+// CreateSeatMap
+// @Tags seating
+// @Summary      Create the seat map of a ticket option
+// @Description  Lay out sections and rows of seats, up to 500 per row and 100000 in all. The ticket option then sells these seats only and its allocation becomes the number of seats
+// @Param        id   path      int  true  "Ticket option ID"
+// @Param requestBody body CreateSeatMapRequestBody true "Seat Map Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      201  {object}  ticket.SeatMap
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/seats [post]
+func (h *DefaultSeatingHandler) CreateSeatMap(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	body := new(CreateSeatMapRequestBody)
+	if err = c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	seatMap, err := h.service.CreateSeatMap(c.Request().Context(), id, ticket.SeatMapLayout{Sections: body.Sections, Best: body.Best})
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrSeatMapLayoutIsInvalid:
+			return c.String(http.StatusBadRequest, WarnMessageWhenSeatMapLayoutIsInvalid)
+		case service.ErrSeatMapIsTooLarge:
+			return c.String(http.StatusBadRequest, WarnMessageWhenSeatMapIsTooLarge)
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+		case service.ErrSeatMapAlreadyExists:
+			return c.String(http.StatusConflict, WarnMessageWhenSeatMapAlreadyExists)
+		case service.ErrTicketOptionHasSales:
+			return c.String(http.StatusConflict, WarnMessageWhenTicketOptionHasSales)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, seatMap)
+}
+
+// GetSeatMap
+// @Tags seating
+// @Summary      Get the seats of a ticket option
+// @Description  Get the seat map of a ticket option with the status of every seat
+// @Produce      json
+// @Param        id   path      int  true  "Ticket option ID"
+// @Success      200  {object}  ticket.SeatMap
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/seats [get]
+func (h *DefaultSeatingHandler) GetSeatMap(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	seatMap, err := h.service.GetSeatMap(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+		case service.ErrTicketOptionIsNotSeated:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketOptionIsNotSeated)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, seatMap)
+}
+
+// PurchaseSeats
+// @Tags seating
+// @Summary      Purchase specific seats
+// @Description  Purchase all of the given seats or, if any of them is taken, none
+// @Param        id   path      int  true  "Ticket option ID"
+// @Param requestBody body PurchaseSeatsRequestBody true "Seat Purchase Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ticket.Purchase
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      403              {string}  string  "Outside the sale window"
+// @Failure      409              {string}  string  "A seat is taken"
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/seat_purchases [post]
+func (h *DefaultSeatingHandler) PurchaseSeats(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	body := new(PurchaseSeatsRequestBody)
+	if err = c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	purchase, err := h.service.PurchaseSeats(c.Request().Context(), id, body.SeatIDs, body.UserID)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrQuantityLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenQuantityLowerThanOne)
+		case service.ErrSeatSelectionIsInvalid:
+			return c.String(http.StatusBadRequest, WarnMessageWhenSeatSelectionIsInvalid)
+		case service.ErrTicketOptionIsNotSeated:
+			return c.String(http.StatusBadRequest, WarnMessageWhenTicketOptionIsNotSeated)
+		case service.ErrSaleNotStarted:
+			return c.String(http.StatusForbidden, WarnMessageWhenSaleNotStarted)
+		case service.ErrSaleEnded:
+			return c.String(http.StatusForbidden, WarnMessageWhenSaleEnded)
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+		case service.ErrSeatWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenSeatWasNotFound)
+		case service.ErrSeatNotAvailable:
+			return c.String(http.StatusConflict, WarnMessageWhenSeatNotAvailable)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, purchase)
+}
+
+// HoldBestAvailable
+// @Tags seating
+// @Summary      Hold the best available seats
+// @Description  Hold the given quantity of seats side by side in one row, closest to the best point of the seat map. Held seats can be purchased by their holder only until the hold expires
+// @Param        id   path      int  true  "Ticket option ID"
+// @Param requestBody body HoldBestAvailableRequestBody true "Best Available Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      201  {object}  ticket.SeatHold
+// @Failure      400              {string}  string
+// @Failure      403              {string}  string  "Outside the sale window"
+// @Failure      404              {string}  string
+// @Failure      409              {string}  string  "No row has that many seats available together"
+// @Failure      500              {string}  string
+// @Router       /ticket_options/{id}/best_available [post]
+func (h *DefaultSeatingHandler) HoldBestAvailable(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	body := new(HoldBestAvailableRequestBody)
+	if err = c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	hold, err := h.service.HoldBestAvailable(c.Request().Context(), id, body.Quantity, body.UserID)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrQuantityLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenQuantityLowerThanOne)
+		case service.ErrUserIDIsEmpty:
+			return c.String(http.StatusBadRequest, WarnMessageWhenUserIDIsEmpty)
+		case service.ErrTicketOptionIsNotSeated:
+			return c.String(http.StatusBadRequest, WarnMessageWhenTicketOptionIsNotSeated)
+		case service.ErrSaleNotStarted:
+			return c.String(http.StatusForbidden, WarnMessageWhenSaleNotStarted)
+		case service.ErrSaleEnded:
+			return c.String(http.StatusForbidden, WarnMessageWhenSaleEnded)
+		case service.ErrTicketWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenTicketWasNotFound)
+		case service.ErrNoContiguousSeats:
+			return c.String(http.StatusConflict, WarnMessageWhenNoContiguousSeats)
+		case service.ErrSeatNotAvailable:
+			return c.String(http.StatusConflict, WarnMessageWhenSeatNotAvailable)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, hold)
+}
@@ -0,0 +1,169 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Seating Unit Tests
+
+func Test_Should_Return_Status_OK_When_Get_Seat_Map(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/ticket_options/1/seats", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket_options/:id/seats")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	expected := ticket.SeatMap{TicketID: 1, Available: 1, Sections: []ticket.SeatSection{
+		{Name: "Stalls", Rows: []ticket.SeatRow{{Name: "A", Seats: []ticket.Seat{
+			{ID: 1, TicketID: 1, Section: "Stalls", Row: "A", Number: 1, Status: ticket.SeatAvailable},
+		}}}},
+	}}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetSeatMap(gomock.Any(), 1).Return(&expected, nil).Times(1)
+
+	seatingHandler := handler.NewDefaultSeatingHandler(e, mockService)
+
+	// When
+	err := seatingHandler.GetSeatMap(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual ticket.SeatMap
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Return_Status_Bad_Request_When_Seat_Map_Is_Too_Large(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/ticket_options/1/seats",
+		strings.NewReader(`{"sections":[{"name":"Stalls","rows":[{"name":"A","seats":100000000}]}]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket_options/:id/seats")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().CreateSeatMap(gomock.Any(), 1, gomock.Any()).Return(nil, service.ErrSeatMapIsTooLarge).Times(1)
+
+	seatingHandler := handler.NewDefaultSeatingHandler(e, mockService)
+
+	// When
+	err := seatingHandler.CreateSeatMap(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenSeatMapIsTooLarge, rec.Body.String())
+}
+
+func Test_Should_Return_Status_Conflict_When_Seat_Is_Taken(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/ticket_options/1/seat_purchases",
+		strings.NewReader(`{"seat_ids":[1,2],"user_id":"test"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket_options/:id/seat_purchases")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().PurchaseSeats(gomock.Any(), 1, []int{1, 2}, "test").Return(nil, service.ErrSeatNotAvailable).Times(1)
+
+	seatingHandler := handler.NewDefaultSeatingHandler(e, mockService)
+
+	// When
+	err := seatingHandler.PurchaseSeats(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenSeatNotAvailable, rec.Body.String())
+}
+
+func Test_Should_Return_Status_Created_When_Hold_Best_Available(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/ticket_options/1/best_available",
+		strings.NewReader(`{"quantity":2,"user_id":"test"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket_options/:id/best_available")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	expected := ticket.SeatHold{TicketID: 1, UserID: "test", Seats: []ticket.Seat{
+		{ID: 1, TicketID: 1, Section: "Stalls", Row: "A", Number: 1, Status: ticket.SeatHeld},
+		{ID: 2, TicketID: 1, Section: "Stalls", Row: "A", Number: 2, Status: ticket.SeatHeld},
+	}}
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().HoldBestAvailable(gomock.Any(), 1, 2, "test").Return(&expected, nil).Times(1)
+
+	seatingHandler := handler.NewDefaultSeatingHandler(e, mockService)
+
+	// When
+	err := seatingHandler.HoldBestAvailable(c)
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual ticket.SeatHold
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Return_Status_Conflict_When_No_Contiguous_Seats(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/ticket_options/1/best_available",
+		strings.NewReader(`{"quantity":8,"user_id":"test"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/ticket_options/:id/best_available")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().HoldBestAvailable(gomock.Any(), 1, 8, "test").Return(nil, service.ErrNoContiguousSeats).Times(1)
+
+	seatingHandler := handler.NewDefaultSeatingHandler(e, mockService)
+
+	// When
+	err := seatingHandler.HoldBestAvailable(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenNoContiguousSeats, rec.Body.String())
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	WarnMessageWhenWebhookURLIsInvalid        = "URL must be an absolute http or https URL."
+	WarnMessageWhenWebhookEventTypesIsEmpty   = "At least one event type must be given."
+	WarnMessageWhenWebhookEventTypeIsUnknown  = "Event type is unknown."
+	WarnMessageWhenWebhookSecretIsEmpty       = "Secret cannot be empty."
+	WarnMessageWhenWebhookWasNotFound         = "Webhook was not found"
+	WarnMessageWhenWebhookDeliveryWasNotFound = "Webhook delivery was not found"
+)
+
+type DefaultWebhookHandler struct {
+	service service.WebhookService
+}
+
+func NewDefaultWebhookHandler(e *echo.Echo, service service.WebhookService) *DefaultWebhookHandler {
+	h := DefaultWebhookHandler{service: service}
+
+	e.POST("/webhooks", h.CreateWebhook)
+	e.GET("/webhooks/:id/deliveries", h.GetWebhookDeliveries)
+	e.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", h.Redeliver)
+
+	return &h
+}
+
+// CreateWebhook
+// @Tags webhook
+// @Summary      Create Webhook
+// @Description  Subscribe a URL to the ticket lifecycle events of the organizer. Deliveries are signed with HMAC-SHA256 of "<timestamp>.<body>" using the secret
+// @Param requestBody body CreateWebhookRequestBody true "Create Webhook Request Body"
+// @Accept       json
+// @Produce      json
+// @Success      201  {object}  ticket.WebhookSubscription
+// @Failure      400              {string}  string
+// @Failure      500              {string}  string
+// @Router       /webhooks [post]
+func (h *DefaultWebhookHandler) CreateWebhook(c echo.Context) error {
+	body := new(CreateWebhookRequestBody)
+	if err := c.Bind(body); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	subscription, err := h.service.CreateWebhook(c.Request().Context(), body.URL, body.Secret, body.EventTypes)
+	if err != nil {
+		switch err {
+		case service.ErrWebhookURLIsInvalid:
+			return c.String(http.StatusBadRequest, WarnMessageWhenWebhookURLIsInvalid)
+		case service.ErrWebhookEventTypesIsEmpty:
+			return c.String(http.StatusBadRequest, WarnMessageWhenWebhookEventTypesIsEmpty)
+		case service.ErrWebhookEventTypeIsUnknown:
+			return c.String(http.StatusBadRequest, WarnMessageWhenWebhookEventTypeIsUnknown)
+		case service.ErrWebhookSecretIsEmpty:
+			return c.String(http.StatusBadRequest, WarnMessageWhenWebhookSecretIsEmpty)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, subscription)
+}
+
+// GetWebhookDeliveries
+// @Tags webhook
+// @Summary      List webhook deliveries
+// @Description  List deliveries of the given webhook of the organizer, newest first, with every delivery attempt
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {array}   ticket.WebhookDelivery
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *DefaultWebhookHandler) GetWebhookDeliveries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	deliveries, err := h.service.GetWebhookDeliveries(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrWebhookWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenWebhookWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// Redeliver
+// @Tags webhook
+// @Summary      Redeliver a webhook delivery
+// @Description  Queue the given delivery to be sent again, regardless of its current status
+// @Produce      json
+// @Param        id           path      int  true  "Webhook ID"
+// @Param        delivery_id  path      int  true  "Delivery ID"
+// @Success      202  {object}  ticket.WebhookDelivery
+// @Failure      400              {string}  string
+// @Failure      404              {string}  string
+// @Failure      500              {string}  string
+// @Router       /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *DefaultWebhookHandler) Redeliver(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+	}
+
+	delivery, err := h.service.Redeliver(c.Request().Context(), id, deliveryID)
+	if err != nil {
+		switch err {
+		case service.ErrIDLowerThanOne:
+			return c.String(http.StatusBadRequest, WarnMessageWhenInvalidID)
+		case service.ErrWebhookDeliveryWasNotFound:
+			return c.String(http.StatusNotFound, WarnMessageWhenWebhookDeliveryWasNotFound)
+		default:
+			return c.String(http.StatusInternalServerError, WarnInternalServerError)
+		}
+	}
+
+	return c.JSON(http.StatusAccepted, delivery)
+}
@@ -0,0 +1,125 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Webhook Unit Tests
+
+func Test_Should_Return_Status_Created_When_Webhook_Is_Valid(t *testing.T) {
+	// Given
+	requestBody := `{"url":"https://partner.example/hook","event_types":["TicketPurchased"],"secret":"secret"}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	eventTypes := ticket.EventTypes{ticket.EventTicketPurchased}
+	expected := ticket.WebhookSubscription{ID: 1, URL: "https://partner.example/hook", EventTypes: eventTypes}
+	mockService := mocks.NewMockWebhookService(gomock.NewController(t))
+	mockService.EXPECT().CreateWebhook(gomock.Any(), "https://partner.example/hook", "secret", eventTypes).
+		Return(&expected, nil).Times(1)
+
+	webhookHandler := handler.NewDefaultWebhookHandler(e, mockService)
+
+	// When
+	err := webhookHandler.CreateWebhook(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "secret")
+
+	var actual ticket.WebhookSubscription
+	_ = json.NewDecoder(rec.Body).Decode(&actual)
+	assert.Equal(t, expected.EventTypes, actual.EventTypes)
+}
+
+func Test_Should_Return_Status_BadRequest_When_Webhook_Is_Not_Valid(t *testing.T) {
+	// Given
+	requestBody := `{"url":"partner","event_types":["TicketPurchased"],"secret":"secret"}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	mockService := mocks.NewMockWebhookService(gomock.NewController(t))
+	mockService.EXPECT().CreateWebhook(gomock.Any(), "partner", "secret", gomock.Any()).
+		Return(nil, service.ErrWebhookURLIsInvalid).Times(1)
+
+	webhookHandler := handler.NewDefaultWebhookHandler(e, mockService)
+
+	// When
+	err := webhookHandler.CreateWebhook(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenWebhookURLIsInvalid, rec.Body.String())
+}
+
+func Test_Should_Return_Status_NotFound_When_Webhook_Does_Not_Exist(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/4/deliveries", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/webhooks/:id/deliveries")
+	c.SetParamNames("id")
+	c.SetParamValues("4")
+
+	mockService := mocks.NewMockWebhookService(gomock.NewController(t))
+	mockService.EXPECT().GetWebhookDeliveries(gomock.Any(), 4).Return(nil, service.ErrWebhookWasNotFound).Times(1)
+
+	webhookHandler := handler.NewDefaultWebhookHandler(e, mockService)
+
+	// When
+	err := webhookHandler.GetWebhookDeliveries(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, handler.WarnMessageWhenWebhookWasNotFound, rec.Body.String())
+}
+
+func Test_Should_Return_Status_Accepted_When_Redeliver(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/4/deliveries/3/redeliver", nil)
+	rec := httptest.NewRecorder()
+
+	e := echo.New()
+	c := e.NewContext(req, rec)
+	c.SetPath("/webhooks/:id/deliveries/:delivery_id/redeliver")
+	c.SetParamNames("id", "delivery_id")
+	c.SetParamValues("4", "3")
+
+	mockService := mocks.NewMockWebhookService(gomock.NewController(t))
+	mockService.EXPECT().Redeliver(gomock.Any(), 4, 3).
+		Return(&ticket.WebhookDelivery{ID: 3, SubscriptionID: 4, Status: ticket.WebhookDeliveryPending}, nil).Times(1)
+
+	webhookHandler := handler.NewDefaultWebhookHandler(e, mockService)
+
+	// When
+	err := webhookHandler.Redeliver(c)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
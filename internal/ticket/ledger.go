@@ -0,0 +1,51 @@
+package ticket
+
+import "time"
+
+type LedgerKind string
+
+const (
+	// LedgerInitial is the allocation a ticket option was created with, or had when the ledger began.
+	LedgerInitial  LedgerKind = "initial"
+	LedgerPurchase LedgerKind = "purchase"
+	LedgerRefund   LedgerKind = "refund"
+	// LedgerHold and LedgerRelease set seats, or the tickets of a pending order, aside for a buyer
+	// and give them back when the hold ends, by purchase, cancellation or running out.
+	LedgerHold    LedgerKind = "hold"
+	LedgerRelease LedgerKind = "release"
+	// LedgerAdjustment is a change made by hand, or by giving the ticket option a seat map, with its Reason.
+	LedgerAdjustment LedgerKind = "adjustment"
+)
+
+// LedgerEntry is one change of the tickets left of a ticket option. The tickets available of a
+// ticket option are the sum of the Delta of its entries, and Ticket.Available caches it. Initial and
+// adjustment entries move Ticket.Capacity with it, purchases and refunds Ticket.Sold against it, and
+// holds and releases Ticket.Held against it. Entries are never updated or deleted.
+type LedgerEntry struct {
+	ID          int        `gorm:"primaryKey" json:"id"`
+	OrganizerID int        `gorm:"not null;index" json:"organizer_id"`
+	TicketID    int        `gorm:"not null;index" json:"ticket_id"`
+	Kind        LedgerKind `gorm:"not null" json:"kind"`
+	Delta       int        `gorm:"not null" json:"delta"`
+	PurchaseID  *int       `json:"purchase_id,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+	CreatedAt   time.Time  `gorm:"not null" json:"created_at"`
+}
+
+// LedgerFilter selects a page of the ledger entries of a ticket option ordered by id.
+type LedgerFilter struct {
+	TicketID int
+	Limit    int
+	AfterID  int
+}
+
+// AllocationDrift is a ticket option whose available tickets are not what its ledger adds up to,
+// or not Capacity - Sold - Held.
+type AllocationDrift struct {
+	TicketID  int `json:"ticket_id"`
+	Capacity  int `json:"capacity"`
+	Sold      int `json:"sold"`
+	Held      int `json:"held"`
+	Available int `json:"available"`
+	Ledger    int `json:"ledger"`
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ticket/repository/outbox.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	ticket "github.com/dilaragorum/ticket-api/internal/ticket"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FetchPendingEvents mocks base method.
+func (m *MockOutboxRepository) FetchPendingEvents(ctx context.Context, now time.Time, limit int) ([]ticket.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPendingEvents", ctx, now, limit)
+	ret0, _ := ret[0].([]ticket.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPendingEvents indicates an expected call of FetchPendingEvents.
+func (mr *MockOutboxRepositoryMockRecorder) FetchPendingEvents(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPendingEvents", reflect.TypeOf((*MockOutboxRepository)(nil).FetchPendingEvents), ctx, now, limit)
+}
+
+// MarkEventFailed mocks base method.
+func (m *MockOutboxRepository) MarkEventFailed(ctx context.Context, id, attempts int, nextAttemptAt time.Time, lastErr string, dead bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventFailed", ctx, id, attempts, nextAttemptAt, lastErr, dead)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventFailed indicates an expected call of MarkEventFailed.
+func (mr *MockOutboxRepositoryMockRecorder) MarkEventFailed(ctx, id, attempts, nextAttemptAt, lastErr, dead interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventFailed", reflect.TypeOf((*MockOutboxRepository)(nil).MarkEventFailed), ctx, id, attempts, nextAttemptAt, lastErr, dead)
+}
+
+// MarkEventPublished mocks base method.
+func (m *MockOutboxRepository) MarkEventPublished(ctx context.Context, id int, publishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventPublished", ctx, id, publishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventPublished indicates an expected call of MarkEventPublished.
+func (mr *MockOutboxRepositoryMockRecorder) MarkEventPublished(ctx, id, publishedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventPublished", reflect.TypeOf((*MockOutboxRepository)(nil).MarkEventPublished), ctx, id, publishedAt)
+}
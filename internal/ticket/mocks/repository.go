@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	ticket "github.com/dilaragorum/ticket-api/internal/ticket"
 	gomock "github.com/golang/mock/gomock"
@@ -35,19 +36,378 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AdjustAllocation mocks base method.
+func (m *MockRepository) AdjustAllocation(ctx context.Context, id, delta int, reason string) (*ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustAllocation", ctx, id, delta, reason)
+	ret0, _ := ret[0].(*ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustAllocation indicates an expected call of AdjustAllocation.
+func (mr *MockRepositoryMockRecorder) AdjustAllocation(ctx, id, delta, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustAllocation", reflect.TypeOf((*MockRepository)(nil).AdjustAllocation), ctx, id, delta, reason)
+}
+
+// BuyResaleListing mocks base method.
+func (m *MockRepository) BuyResaleListing(ctx context.Context, id int, buyerID, newCode string) (*ticket.ResaleListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyResaleListing", ctx, id, buyerID, newCode)
+	ret0, _ := ret[0].(*ticket.ResaleListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyResaleListing indicates an expected call of BuyResaleListing.
+func (mr *MockRepositoryMockRecorder) BuyResaleListing(ctx, id, buyerID, newCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyResaleListing", reflect.TypeOf((*MockRepository)(nil).BuyResaleListing), ctx, id, buyerID, newCode)
+}
+
+// CancelOrder mocks base method.
+func (m *MockRepository) CancelOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, id)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockRepositoryMockRecorder) CancelOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockRepository)(nil).CancelOrder), ctx, id)
+}
+
+// CancelResaleListing mocks base method.
+func (m *MockRepository) CancelResaleListing(ctx context.Context, id int, sellerID string) (*ticket.ResaleListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelResaleListing", ctx, id, sellerID)
+	ret0, _ := ret[0].(*ticket.ResaleListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelResaleListing indicates an expected call of CancelResaleListing.
+func (mr *MockRepositoryMockRecorder) CancelResaleListing(ctx, id, sellerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelResaleListing", reflect.TypeOf((*MockRepository)(nil).CancelResaleListing), ctx, id, sellerID)
+}
+
+// CheckIn mocks base method.
+func (m *MockRepository) CheckIn(ctx context.Context, code string, eventID int, gateID string, at time.Time) (*ticket.IssuedTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIn", ctx, code, eventID, gateID, at)
+	ret0, _ := ret[0].(*ticket.IssuedTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIn indicates an expected call of CheckIn.
+func (mr *MockRepositoryMockRecorder) CheckIn(ctx, code, eventID, gateID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIn", reflect.TypeOf((*MockRepository)(nil).CheckIn), ctx, code, eventID, gateID, at)
+}
+
+// CountSoldTickets mocks base method.
+func (m *MockRepository) CountSoldTickets(ctx context.Context, id int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSoldTickets", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSoldTickets indicates an expected call of CountSoldTickets.
+func (mr *MockRepositoryMockRecorder) CountSoldTickets(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSoldTickets", reflect.TypeOf((*MockRepository)(nil).CountSoldTickets), ctx, id)
+}
+
+// CreateOrder mocks base method.
+func (m *MockRepository) CreateOrder(ctx context.Context, userID string, items []ticket.OrderItem, quotes map[int]ticket.PriceQuoter, expiresAt time.Time) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, userID, items, quotes, expiresAt)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockRepositoryMockRecorder) CreateOrder(ctx, userID, items, quotes, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockRepository)(nil).CreateOrder), ctx, userID, items, quotes, expiresAt)
+}
+
+// CreateOrganizer mocks base method.
+func (m *MockRepository) CreateOrganizer(ctx context.Context, name, tokenHash string) (*ticket.Organizer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganizer", ctx, name, tokenHash)
+	ret0, _ := ret[0].(*ticket.Organizer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganizer indicates an expected call of CreateOrganizer.
+func (mr *MockRepositoryMockRecorder) CreateOrganizer(ctx, name, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganizer", reflect.TypeOf((*MockRepository)(nil).CreateOrganizer), ctx, name, tokenHash)
+}
+
+// CreatePriceTier mocks base method.
+func (m *MockRepository) CreatePriceTier(ctx context.Context, tier ticket.PriceTier) (*ticket.PriceTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceTier", ctx, tier)
+	ret0, _ := ret[0].(*ticket.PriceTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceTier indicates an expected call of CreatePriceTier.
+func (mr *MockRepositoryMockRecorder) CreatePriceTier(ctx, tier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceTier", reflect.TypeOf((*MockRepository)(nil).CreatePriceTier), ctx, tier)
+}
+
+// CreateResaleListing mocks base method.
+func (m *MockRepository) CreateResaleListing(ctx context.Context, code, sellerID string, price int) (*ticket.ResaleListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResaleListing", ctx, code, sellerID, price)
+	ret0, _ := ret[0].(*ticket.ResaleListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateResaleListing indicates an expected call of CreateResaleListing.
+func (mr *MockRepositoryMockRecorder) CreateResaleListing(ctx, code, sellerID, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResaleListing", reflect.TypeOf((*MockRepository)(nil).CreateResaleListing), ctx, code, sellerID, price)
+}
+
+// CreateSeatMap mocks base method.
+func (m *MockRepository) CreateSeatMap(ctx context.Context, ticketID int, seats []ticket.Seat, best ticket.SeatPoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeatMap", ctx, ticketID, seats, best)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSeatMap indicates an expected call of CreateSeatMap.
+func (mr *MockRepositoryMockRecorder) CreateSeatMap(ctx, ticketID, seats, best interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeatMap", reflect.TypeOf((*MockRepository)(nil).CreateSeatMap), ctx, ticketID, seats, best)
+}
+
 // CreateTicketOption mocks base method.
-func (m *MockRepository) CreateTicketOption(ctx context.Context, name, description string, allocation int) (*ticket.Ticket, error) {
+func (m *MockRepository) CreateTicketOption(ctx context.Context, name, description string, allocation, price int, startsAt, saleStartsAt, saleEndsAt *time.Time) (*ticket.Ticket, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTicketOption", ctx, name, description, allocation)
+	ret := m.ctrl.Call(m, "CreateTicketOption", ctx, name, description, allocation, price, startsAt, saleStartsAt, saleEndsAt)
 	ret0, _ := ret[0].(*ticket.Ticket)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTicketOption indicates an expected call of CreateTicketOption.
-func (mr *MockRepositoryMockRecorder) CreateTicketOption(ctx, name, description, allocation interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreateTicketOption(ctx, name, description, allocation, price, startsAt, saleStartsAt, saleEndsAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicketOption", reflect.TypeOf((*MockRepository)(nil).CreateTicketOption), ctx, name, description, allocation, price, startsAt, saleStartsAt, saleEndsAt)
+}
+
+// CreateTicketOptions mocks base method.
+func (m *MockRepository) CreateTicketOptions(ctx context.Context, drafts []ticket.TicketDraft) ([]ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTicketOptions", ctx, drafts)
+	ret0, _ := ret[0].([]ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTicketOptions indicates an expected call of CreateTicketOptions.
+func (mr *MockRepositoryMockRecorder) CreateTicketOptions(ctx, drafts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicketOptions", reflect.TypeOf((*MockRepository)(nil).CreateTicketOptions), ctx, drafts)
+}
+
+// DeletePriceTier mocks base method.
+func (m *MockRepository) DeletePriceTier(ctx context.Context, ticketID, tierID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceTier", ctx, ticketID, tierID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceTier indicates an expected call of DeletePriceTier.
+func (mr *MockRepositoryMockRecorder) DeletePriceTier(ctx, ticketID, tierID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceTier", reflect.TypeOf((*MockRepository)(nil).DeletePriceTier), ctx, ticketID, tierID)
+}
+
+// ExpirePendingOrders mocks base method.
+func (m *MockRepository) ExpirePendingOrders(ctx context.Context, now time.Time, limit int) (int, []ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePendingOrders", ctx, now, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]ticket.Ticket)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExpirePendingOrders indicates an expected call of ExpirePendingOrders.
+func (mr *MockRepositoryMockRecorder) ExpirePendingOrders(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePendingOrders", reflect.TypeOf((*MockRepository)(nil).ExpirePendingOrders), ctx, now, limit)
+}
+
+// GetCheckinStats mocks base method.
+func (m *MockRepository) GetCheckinStats(ctx context.Context, eventID int) (*ticket.CheckinStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckinStats", ctx, eventID)
+	ret0, _ := ret[0].(*ticket.CheckinStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckinStats indicates an expected call of GetCheckinStats.
+func (mr *MockRepositoryMockRecorder) GetCheckinStats(ctx, eventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckinStats", reflect.TypeOf((*MockRepository)(nil).GetCheckinStats), ctx, eventID)
+}
+
+// GetIssuedTicket mocks base method.
+func (m *MockRepository) GetIssuedTicket(ctx context.Context, code string) (*ticket.IssuedTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssuedTicket", ctx, code)
+	ret0, _ := ret[0].(*ticket.IssuedTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssuedTicket indicates an expected call of GetIssuedTicket.
+func (mr *MockRepositoryMockRecorder) GetIssuedTicket(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssuedTicket", reflect.TypeOf((*MockRepository)(nil).GetIssuedTicket), ctx, code)
+}
+
+// GetIssuedTicketTransfers mocks base method.
+func (m *MockRepository) GetIssuedTicketTransfers(ctx context.Context, code string) ([]ticket.TicketTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssuedTicketTransfers", ctx, code)
+	ret0, _ := ret[0].([]ticket.TicketTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssuedTicketTransfers indicates an expected call of GetIssuedTicketTransfers.
+func (mr *MockRepositoryMockRecorder) GetIssuedTicketTransfers(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssuedTicketTransfers", reflect.TypeOf((*MockRepository)(nil).GetIssuedTicketTransfers), ctx, code)
+}
+
+// GetOrder mocks base method.
+func (m *MockRepository) GetOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockRepositoryMockRecorder) GetOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockRepository)(nil).GetOrder), ctx, id)
+}
+
+// GetOrganizer mocks base method.
+func (m *MockRepository) GetOrganizer(ctx context.Context, id int) (*ticket.Organizer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizer", ctx, id)
+	ret0, _ := ret[0].(*ticket.Organizer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizer indicates an expected call of GetOrganizer.
+func (mr *MockRepositoryMockRecorder) GetOrganizer(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizer", reflect.TypeOf((*MockRepository)(nil).GetOrganizer), ctx, id)
+}
+
+// GetOrganizerByTokenHash mocks base method.
+func (m *MockRepository) GetOrganizerByTokenHash(ctx context.Context, tokenHash string) (*ticket.Organizer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizerByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*ticket.Organizer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizerByTokenHash indicates an expected call of GetOrganizerByTokenHash.
+func (mr *MockRepositoryMockRecorder) GetOrganizerByTokenHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizerByTokenHash", reflect.TypeOf((*MockRepository)(nil).GetOrganizerByTokenHash), ctx, tokenHash)
+}
+
+// GetPurchase mocks base method.
+func (m *MockRepository) GetPurchase(ctx context.Context, id int) (*ticket.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchase", ctx, id)
+	ret0, _ := ret[0].(*ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchase indicates an expected call of GetPurchase.
+func (mr *MockRepositoryMockRecorder) GetPurchase(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchase", reflect.TypeOf((*MockRepository)(nil).GetPurchase), ctx, id)
+}
+
+// GetPurchaseTickets mocks base method.
+func (m *MockRepository) GetPurchaseTickets(ctx context.Context, purchaseID int) ([]ticket.IssuedTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseTickets", ctx, purchaseID)
+	ret0, _ := ret[0].([]ticket.IssuedTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseTickets indicates an expected call of GetPurchaseTickets.
+func (mr *MockRepositoryMockRecorder) GetPurchaseTickets(ctx, purchaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseTickets", reflect.TypeOf((*MockRepository)(nil).GetPurchaseTickets), ctx, purchaseID)
+}
+
+// GetResaleListing mocks base method.
+func (m *MockRepository) GetResaleListing(ctx context.Context, id int) (*ticket.ResaleListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResaleListing", ctx, id)
+	ret0, _ := ret[0].(*ticket.ResaleListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResaleListing indicates an expected call of GetResaleListing.
+func (mr *MockRepositoryMockRecorder) GetResaleListing(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResaleListing", reflect.TypeOf((*MockRepository)(nil).GetResaleListing), ctx, id)
+}
+
+// GetSeats mocks base method.
+func (m *MockRepository) GetSeats(ctx context.Context, ticketID int) ([]ticket.Seat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeats", ctx, ticketID)
+	ret0, _ := ret[0].([]ticket.Seat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeats indicates an expected call of GetSeats.
+func (mr *MockRepositoryMockRecorder) GetSeats(ctx, ticketID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicketOption", reflect.TypeOf((*MockRepository)(nil).CreateTicketOption), ctx, name, description, allocation)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeats", reflect.TypeOf((*MockRepository)(nil).GetSeats), ctx, ticketID)
 }
 
 // GetTicket mocks base method.
@@ -65,16 +425,286 @@ func (mr *MockRepositoryMockRecorder) GetTicket(ctx, id interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicket", reflect.TypeOf((*MockRepository)(nil).GetTicket), ctx, id)
 }
 
-// PurchaseFromTicketOption mocks base method.
-func (m *MockRepository) PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string) error {
+// HoldSeats mocks base method.
+func (m *MockRepository) HoldSeats(ctx context.Context, ticketID int, seatIDs []int, userID string, now, until time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PurchaseFromTicketOption", ctx, id, quantity, userID)
+	ret := m.ctrl.Call(m, "HoldSeats", ctx, ticketID, seatIDs, userID, now, until)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// HoldSeats indicates an expected call of HoldSeats.
+func (mr *MockRepositoryMockRecorder) HoldSeats(ctx, ticketID, seatIDs, userID, now, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldSeats", reflect.TypeOf((*MockRepository)(nil).HoldSeats), ctx, ticketID, seatIDs, userID, now, until)
+}
+
+// ListAuditEntries mocks base method.
+func (m *MockRepository) ListAuditEntries(ctx context.Context, filter ticket.AuditFilter) ([]ticket.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEntries", ctx, filter)
+	ret0, _ := ret[0].([]ticket.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEntries indicates an expected call of ListAuditEntries.
+func (mr *MockRepositoryMockRecorder) ListAuditEntries(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEntries", reflect.TypeOf((*MockRepository)(nil).ListAuditEntries), ctx, filter)
+}
+
+// ListLedgerEntries mocks base method.
+func (m *MockRepository) ListLedgerEntries(ctx context.Context, filter ticket.LedgerFilter) ([]ticket.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLedgerEntries", ctx, filter)
+	ret0, _ := ret[0].([]ticket.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLedgerEntries indicates an expected call of ListLedgerEntries.
+func (mr *MockRepositoryMockRecorder) ListLedgerEntries(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerEntries", reflect.TypeOf((*MockRepository)(nil).ListLedgerEntries), ctx, filter)
+}
+
+// ListPurchases mocks base method.
+func (m *MockRepository) ListPurchases(ctx context.Context, filter ticket.PurchaseFilter) ([]ticket.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchases", ctx, filter)
+	ret0, _ := ret[0].([]ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchases indicates an expected call of ListPurchases.
+func (mr *MockRepositoryMockRecorder) ListPurchases(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchases", reflect.TypeOf((*MockRepository)(nil).ListPurchases), ctx, filter)
+}
+
+// ListResaleListings mocks base method.
+func (m *MockRepository) ListResaleListings(ctx context.Context, ticketID int) ([]ticket.ResaleListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResaleListings", ctx, ticketID)
+	ret0, _ := ret[0].([]ticket.ResaleListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResaleListings indicates an expected call of ListResaleListings.
+func (mr *MockRepositoryMockRecorder) ListResaleListings(ctx, ticketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResaleListings", reflect.TypeOf((*MockRepository)(nil).ListResaleListings), ctx, ticketID)
+}
+
+// ListTicketOptions mocks base method.
+func (m *MockRepository) ListTicketOptions(ctx context.Context, filter ticket.TicketFilter) ([]ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTicketOptions", ctx, filter)
+	ret0, _ := ret[0].([]ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTicketOptions indicates an expected call of ListTicketOptions.
+func (mr *MockRepositoryMockRecorder) ListTicketOptions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTicketOptions", reflect.TypeOf((*MockRepository)(nil).ListTicketOptions), ctx, filter)
+}
+
+// PayOrder mocks base method.
+func (m *MockRepository) PayOrder(ctx context.Context, id int, codes map[int][]string) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayOrder", ctx, id, codes)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayOrder indicates an expected call of PayOrder.
+func (mr *MockRepositoryMockRecorder) PayOrder(ctx, id, codes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockRepository)(nil).PayOrder), ctx, id, codes)
+}
+
+// PurchaseFromTicketOption mocks base method.
+func (m *MockRepository) PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string, codes []string, quote ticket.PriceQuoter) (*ticket.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurchaseFromTicketOption", ctx, id, quantity, userID, codes, quote)
+	ret0, _ := ret[0].(*ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // PurchaseFromTicketOption indicates an expected call of PurchaseFromTicketOption.
-func (mr *MockRepositoryMockRecorder) PurchaseFromTicketOption(ctx, id, quantity, userID interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) PurchaseFromTicketOption(ctx, id, quantity, userID, codes, quote interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseFromTicketOption", reflect.TypeOf((*MockRepository)(nil).PurchaseFromTicketOption), ctx, id, quantity, userID, codes, quote)
+}
+
+// PurchaseSeats mocks base method.
+func (m *MockRepository) PurchaseSeats(ctx context.Context, ticketID int, userID string, seatIDs []int, codes []string, quote ticket.PriceQuoter, now time.Time) (*ticket.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurchaseSeats", ctx, ticketID, userID, seatIDs, codes, quote, now)
+	ret0, _ := ret[0].(*ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurchaseSeats indicates an expected call of PurchaseSeats.
+func (mr *MockRepositoryMockRecorder) PurchaseSeats(ctx, ticketID, userID, seatIDs, codes, quote, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseSeats", reflect.TypeOf((*MockRepository)(nil).PurchaseSeats), ctx, ticketID, userID, seatIDs, codes, quote, now)
+}
+
+// RebalanceShards mocks base method.
+func (m *MockRepository) RebalanceShards(ctx context.Context, id int) (*ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebalanceShards", ctx, id)
+	ret0, _ := ret[0].(*ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebalanceShards indicates an expected call of RebalanceShards.
+func (mr *MockRepositoryMockRecorder) RebalanceShards(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebalanceShards", reflect.TypeOf((*MockRepository)(nil).RebalanceShards), ctx, id)
+}
+
+// ReconcileAllocations mocks base method.
+func (m *MockRepository) ReconcileAllocations(ctx context.Context) ([]ticket.AllocationDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileAllocations", ctx)
+	ret0, _ := ret[0].([]ticket.AllocationDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileAllocations indicates an expected call of ReconcileAllocations.
+func (mr *MockRepositoryMockRecorder) ReconcileAllocations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileAllocations", reflect.TypeOf((*MockRepository)(nil).ReconcileAllocations), ctx)
+}
+
+// RefundOrder mocks base method.
+func (m *MockRepository) RefundOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundOrder", ctx, id)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundOrder indicates an expected call of RefundOrder.
+func (mr *MockRepositoryMockRecorder) RefundOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockRepository)(nil).RefundOrder), ctx, id)
+}
+
+// RefundPurchase mocks base method.
+func (m *MockRepository) RefundPurchase(ctx context.Context, purchaseID int) (*ticket.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPurchase", ctx, purchaseID)
+	ret0, _ := ret[0].(*ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPurchase indicates an expected call of RefundPurchase.
+func (mr *MockRepositoryMockRecorder) RefundPurchase(ctx, purchaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPurchase", reflect.TypeOf((*MockRepository)(nil).RefundPurchase), ctx, purchaseID)
+}
+
+// ReleaseExpiredHolds mocks base method.
+func (m *MockRepository) ReleaseExpiredHolds(ctx context.Context, now time.Time, limit int) (int, []ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpiredHolds", ctx, now, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]ticket.Ticket)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReleaseExpiredHolds indicates an expected call of ReleaseExpiredHolds.
+func (mr *MockRepositoryMockRecorder) ReleaseExpiredHolds(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpiredHolds", reflect.TypeOf((*MockRepository)(nil).ReleaseExpiredHolds), ctx, now, limit)
+}
+
+// SetOrganizerTokenHash mocks base method.
+func (m *MockRepository) SetOrganizerTokenHash(ctx context.Context, id int, tokenHash string) (*ticket.Organizer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrganizerTokenHash", ctx, id, tokenHash)
+	ret0, _ := ret[0].(*ticket.Organizer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOrganizerTokenHash indicates an expected call of SetOrganizerTokenHash.
+func (mr *MockRepositoryMockRecorder) SetOrganizerTokenHash(ctx, id, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrganizerTokenHash", reflect.TypeOf((*MockRepository)(nil).SetOrganizerTokenHash), ctx, id, tokenHash)
+}
+
+// ShardAllocation mocks base method.
+func (m *MockRepository) ShardAllocation(ctx context.Context, id, shards int) (*ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShardAllocation", ctx, id, shards)
+	ret0, _ := ret[0].(*ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShardAllocation indicates an expected call of ShardAllocation.
+func (mr *MockRepositoryMockRecorder) ShardAllocation(ctx, id, shards interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShardAllocation", reflect.TypeOf((*MockRepository)(nil).ShardAllocation), ctx, id, shards)
+}
+
+// StreamSalesReport mocks base method.
+func (m *MockRepository) StreamSalesReport(ctx context.Context, filter ticket.SalesFilter, emit func(ticket.SalesRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSalesReport", ctx, filter, emit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSalesReport indicates an expected call of StreamSalesReport.
+func (mr *MockRepositoryMockRecorder) StreamSalesReport(ctx, filter, emit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSalesReport", reflect.TypeOf((*MockRepository)(nil).StreamSalesReport), ctx, filter, emit)
+}
+
+// TransferIssuedTicket mocks base method.
+func (m *MockRepository) TransferIssuedTicket(ctx context.Context, code, toUserID, newCode string) (*ticket.IssuedTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferIssuedTicket", ctx, code, toUserID, newCode)
+	ret0, _ := ret[0].(*ticket.IssuedTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferIssuedTicket indicates an expected call of TransferIssuedTicket.
+func (mr *MockRepositoryMockRecorder) TransferIssuedTicket(ctx, code, toUserID, newCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferIssuedTicket", reflect.TypeOf((*MockRepository)(nil).TransferIssuedTicket), ctx, code, toUserID, newCode)
+}
+
+// UpdateTicketOption mocks base method.
+func (m *MockRepository) UpdateTicketOption(ctx context.Context, id int, update ticket.TicketUpdate) (*ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTicketOption", ctx, id, update)
+	ret0, _ := ret[0].(*ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTicketOption indicates an expected call of UpdateTicketOption.
+func (mr *MockRepositoryMockRecorder) UpdateTicketOption(ctx, id, update interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseFromTicketOption", reflect.TypeOf((*MockRepository)(nil).PurchaseFromTicketOption), ctx, id, quantity, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTicketOption", reflect.TypeOf((*MockRepository)(nil).UpdateTicketOption), ctx, id, update)
 }
@@ -7,8 +7,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	ticket "github.com/dilaragorum/ticket-api/internal/ticket"
+	ticketcode "github.com/dilaragorum/ticket-api/internal/ticket/ticketcode"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,19 +37,349 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// AdjustAllocation mocks base method.
+func (m *MockService) AdjustAllocation(ctx context.Context, id, delta int, reason string) (*ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustAllocation", ctx, id, delta, reason)
+	ret0, _ := ret[0].(*ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustAllocation indicates an expected call of AdjustAllocation.
+func (mr *MockServiceMockRecorder) AdjustAllocation(ctx, id, delta, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustAllocation", reflect.TypeOf((*MockService)(nil).AdjustAllocation), ctx, id, delta, reason)
+}
+
+// AuthenticateOrganizer mocks base method.
+func (m *MockService) AuthenticateOrganizer(ctx context.Context, token string) (*ticket.Organizer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateOrganizer", ctx, token)
+	ret0, _ := ret[0].(*ticket.Organizer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateOrganizer indicates an expected call of AuthenticateOrganizer.
+func (mr *MockServiceMockRecorder) AuthenticateOrganizer(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateOrganizer", reflect.TypeOf((*MockService)(nil).AuthenticateOrganizer), ctx, token)
+}
+
+// BuyResaleListing mocks base method.
+func (m *MockService) BuyResaleListing(ctx context.Context, listingID int, buyerID string) (*ticket.ResaleListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyResaleListing", ctx, listingID, buyerID)
+	ret0, _ := ret[0].(*ticket.ResaleListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyResaleListing indicates an expected call of BuyResaleListing.
+func (mr *MockServiceMockRecorder) BuyResaleListing(ctx, listingID, buyerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyResaleListing", reflect.TypeOf((*MockService)(nil).BuyResaleListing), ctx, listingID, buyerID)
+}
+
+// CancelOrder mocks base method.
+func (m *MockService) CancelOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, id)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockServiceMockRecorder) CancelOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockService)(nil).CancelOrder), ctx, id)
+}
+
+// CancelResaleListing mocks base method.
+func (m *MockService) CancelResaleListing(ctx context.Context, listingID int, sellerID string) (*ticket.ResaleListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelResaleListing", ctx, listingID, sellerID)
+	ret0, _ := ret[0].(*ticket.ResaleListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelResaleListing indicates an expected call of CancelResaleListing.
+func (mr *MockServiceMockRecorder) CancelResaleListing(ctx, listingID, sellerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelResaleListing", reflect.TypeOf((*MockService)(nil).CancelResaleListing), ctx, listingID, sellerID)
+}
+
+// CheckIn mocks base method.
+func (m *MockService) CheckIn(ctx context.Context, code string, eventID int, gateID string) (*ticket.IssuedTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIn", ctx, code, eventID, gateID)
+	ret0, _ := ret[0].(*ticket.IssuedTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIn indicates an expected call of CheckIn.
+func (mr *MockServiceMockRecorder) CheckIn(ctx, code, eventID, gateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIn", reflect.TypeOf((*MockService)(nil).CheckIn), ctx, code, eventID, gateID)
+}
+
+// CreateOrder mocks base method.
+func (m *MockService) CreateOrder(ctx context.Context, userID string, items []ticket.OrderItem) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, userID, items)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockServiceMockRecorder) CreateOrder(ctx, userID, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockService)(nil).CreateOrder), ctx, userID, items)
+}
+
+// CreateOrganizer mocks base method.
+func (m *MockService) CreateOrganizer(ctx context.Context, name string) (*ticket.Organizer, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganizer", ctx, name)
+	ret0, _ := ret[0].(*ticket.Organizer)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateOrganizer indicates an expected call of CreateOrganizer.
+func (mr *MockServiceMockRecorder) CreateOrganizer(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganizer", reflect.TypeOf((*MockService)(nil).CreateOrganizer), ctx, name)
+}
+
+// CreatePriceTier mocks base method.
+func (m *MockService) CreatePriceTier(ctx context.Context, ticketID int, tier ticket.PriceTier) (*ticket.PriceTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceTier", ctx, ticketID, tier)
+	ret0, _ := ret[0].(*ticket.PriceTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceTier indicates an expected call of CreatePriceTier.
+func (mr *MockServiceMockRecorder) CreatePriceTier(ctx, ticketID, tier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceTier", reflect.TypeOf((*MockService)(nil).CreatePriceTier), ctx, ticketID, tier)
+}
+
+// CreateResaleListing mocks base method.
+func (m *MockService) CreateResaleListing(ctx context.Context, code, sellerID string, price int) (*ticket.ResaleListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResaleListing", ctx, code, sellerID, price)
+	ret0, _ := ret[0].(*ticket.ResaleListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateResaleListing indicates an expected call of CreateResaleListing.
+func (mr *MockServiceMockRecorder) CreateResaleListing(ctx, code, sellerID, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResaleListing", reflect.TypeOf((*MockService)(nil).CreateResaleListing), ctx, code, sellerID, price)
+}
+
+// CreateSeatMap mocks base method.
+func (m *MockService) CreateSeatMap(ctx context.Context, ticketID int, layout ticket.SeatMapLayout) (*ticket.SeatMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeatMap", ctx, ticketID, layout)
+	ret0, _ := ret[0].(*ticket.SeatMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeatMap indicates an expected call of CreateSeatMap.
+func (mr *MockServiceMockRecorder) CreateSeatMap(ctx, ticketID, layout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeatMap", reflect.TypeOf((*MockService)(nil).CreateSeatMap), ctx, ticketID, layout)
+}
+
 // CreateTicketOption mocks base method.
-func (m *MockService) CreateTicketOption(ctx context.Context, name, description string, allocation int) (*ticket.Ticket, error) {
+func (m *MockService) CreateTicketOption(ctx context.Context, name, description string, allocation, price int, startsAt, saleStartsAt, saleEndsAt *time.Time) (*ticket.Ticket, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTicketOption", ctx, name, description, allocation)
+	ret := m.ctrl.Call(m, "CreateTicketOption", ctx, name, description, allocation, price, startsAt, saleStartsAt, saleEndsAt)
 	ret0, _ := ret[0].(*ticket.Ticket)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTicketOption indicates an expected call of CreateTicketOption.
-func (mr *MockServiceMockRecorder) CreateTicketOption(ctx, name, description, allocation interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateTicketOption(ctx, name, description, allocation, price, startsAt, saleStartsAt, saleEndsAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicketOption", reflect.TypeOf((*MockService)(nil).CreateTicketOption), ctx, name, description, allocation, price, startsAt, saleStartsAt, saleEndsAt)
+}
+
+// CreateTicketOptions mocks base method.
+func (m *MockService) CreateTicketOptions(ctx context.Context, drafts []ticket.TicketDraft, mode ticket.BulkMode) ([]ticket.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTicketOptions", ctx, drafts, mode)
+	ret0, _ := ret[0].([]ticket.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTicketOptions indicates an expected call of CreateTicketOptions.
+func (mr *MockServiceMockRecorder) CreateTicketOptions(ctx, drafts, mode interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicketOption", reflect.TypeOf((*MockService)(nil).CreateTicketOption), ctx, name, description, allocation)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicketOptions", reflect.TypeOf((*MockService)(nil).CreateTicketOptions), ctx, drafts, mode)
+}
+
+// DeletePriceTier mocks base method.
+func (m *MockService) DeletePriceTier(ctx context.Context, ticketID, tierID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceTier", ctx, ticketID, tierID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceTier indicates an expected call of DeletePriceTier.
+func (mr *MockServiceMockRecorder) DeletePriceTier(ctx, ticketID, tierID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceTier", reflect.TypeOf((*MockService)(nil).DeletePriceTier), ctx, ticketID, tierID)
+}
+
+// ExpirePendingOrders mocks base method.
+func (m *MockService) ExpirePendingOrders(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePendingOrders", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePendingOrders indicates an expected call of ExpirePendingOrders.
+func (mr *MockServiceMockRecorder) ExpirePendingOrders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePendingOrders", reflect.TypeOf((*MockService)(nil).ExpirePendingOrders), ctx)
+}
+
+// GetCheckinStats mocks base method.
+func (m *MockService) GetCheckinStats(ctx context.Context, eventID int) (*ticket.CheckinStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckinStats", ctx, eventID)
+	ret0, _ := ret[0].(*ticket.CheckinStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckinStats indicates an expected call of GetCheckinStats.
+func (mr *MockServiceMockRecorder) GetCheckinStats(ctx, eventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckinStats", reflect.TypeOf((*MockService)(nil).GetCheckinStats), ctx, eventID)
+}
+
+// GetIssuedTicket mocks base method.
+func (m *MockService) GetIssuedTicket(ctx context.Context, code string) (*ticket.IssuedTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssuedTicket", ctx, code)
+	ret0, _ := ret[0].(*ticket.IssuedTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssuedTicket indicates an expected call of GetIssuedTicket.
+func (mr *MockServiceMockRecorder) GetIssuedTicket(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssuedTicket", reflect.TypeOf((*MockService)(nil).GetIssuedTicket), ctx, code)
+}
+
+// GetIssuedTicketTransfers mocks base method.
+func (m *MockService) GetIssuedTicketTransfers(ctx context.Context, code string) ([]ticket.TicketTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssuedTicketTransfers", ctx, code)
+	ret0, _ := ret[0].([]ticket.TicketTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssuedTicketTransfers indicates an expected call of GetIssuedTicketTransfers.
+func (mr *MockServiceMockRecorder) GetIssuedTicketTransfers(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssuedTicketTransfers", reflect.TypeOf((*MockService)(nil).GetIssuedTicketTransfers), ctx, code)
+}
+
+// GetOrder mocks base method.
+func (m *MockService) GetOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockServiceMockRecorder) GetOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockService)(nil).GetOrder), ctx, id)
+}
+
+// GetOrganizer mocks base method.
+func (m *MockService) GetOrganizer(ctx context.Context, id int) (*ticket.Organizer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizer", ctx, id)
+	ret0, _ := ret[0].(*ticket.Organizer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizer indicates an expected call of GetOrganizer.
+func (mr *MockServiceMockRecorder) GetOrganizer(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizer", reflect.TypeOf((*MockService)(nil).GetOrganizer), ctx, id)
+}
+
+// GetPurchase mocks base method.
+func (m *MockService) GetPurchase(ctx context.Context, id int) (*ticket.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchase", ctx, id)
+	ret0, _ := ret[0].(*ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchase indicates an expected call of GetPurchase.
+func (mr *MockServiceMockRecorder) GetPurchase(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchase", reflect.TypeOf((*MockService)(nil).GetPurchase), ctx, id)
+}
+
+// GetPurchaseTickets mocks base method.
+func (m *MockService) GetPurchaseTickets(ctx context.Context, purchaseID int) ([]ticket.IssuedTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseTickets", ctx, purchaseID)
+	ret0, _ := ret[0].([]ticket.IssuedTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseTickets indicates an expected call of GetPurchaseTickets.
+func (mr *MockServiceMockRecorder) GetPurchaseTickets(ctx, purchaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseTickets", reflect.TypeOf((*MockService)(nil).GetPurchaseTickets), ctx, purchaseID)
+}
+
+// GetSeatMap mocks base method.
+func (m *MockService) GetSeatMap(ctx context.Context, ticketID int) (*ticket.SeatMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeatMap", ctx, ticketID)
+	ret0, _ := ret[0].(*ticket.SeatMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeatMap indicates an expected call of GetSeatMap.
+func (mr *MockServiceMockRecorder) GetSeatMap(ctx, ticketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeatMap", reflect.TypeOf((*MockService)(nil).GetSeatMap), ctx, ticketID)
 }
 
 // GetTicket mocks base method.
@@ -65,12 +397,134 @@ func (mr *MockServiceMockRecorder) GetTicket(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicket", reflect.TypeOf((*MockService)(nil).GetTicket), ctx, id)
 }
 
+// HoldBestAvailable mocks base method.
+func (m *MockService) HoldBestAvailable(ctx context.Context, ticketID, quantity int, userID string) (*ticket.SeatHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldBestAvailable", ctx, ticketID, quantity, userID)
+	ret0, _ := ret[0].(*ticket.SeatHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldBestAvailable indicates an expected call of HoldBestAvailable.
+func (mr *MockServiceMockRecorder) HoldBestAvailable(ctx, ticketID, quantity, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldBestAvailable", reflect.TypeOf((*MockService)(nil).HoldBestAvailable), ctx, ticketID, quantity, userID)
+}
+
+// IssueOrganizerToken mocks base method.
+func (m *MockService) IssueOrganizerToken(ctx context.Context, id int) (*ticket.Organizer, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueOrganizerToken", ctx, id)
+	ret0, _ := ret[0].(*ticket.Organizer)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// IssueOrganizerToken indicates an expected call of IssueOrganizerToken.
+func (mr *MockServiceMockRecorder) IssueOrganizerToken(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueOrganizerToken", reflect.TypeOf((*MockService)(nil).IssueOrganizerToken), ctx, id)
+}
+
+// ListAuditEntries mocks base method.
+func (m *MockService) ListAuditEntries(ctx context.Context, filter ticket.AuditFilter) ([]ticket.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEntries", ctx, filter)
+	ret0, _ := ret[0].([]ticket.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEntries indicates an expected call of ListAuditEntries.
+func (mr *MockServiceMockRecorder) ListAuditEntries(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEntries", reflect.TypeOf((*MockService)(nil).ListAuditEntries), ctx, filter)
+}
+
+// ListLedgerEntries mocks base method.
+func (m *MockService) ListLedgerEntries(ctx context.Context, filter ticket.LedgerFilter) ([]ticket.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLedgerEntries", ctx, filter)
+	ret0, _ := ret[0].([]ticket.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLedgerEntries indicates an expected call of ListLedgerEntries.
+func (mr *MockServiceMockRecorder) ListLedgerEntries(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerEntries", reflect.TypeOf((*MockService)(nil).ListLedgerEntries), ctx, filter)
+}
+
+// ListPurchases mocks base method.
+func (m *MockService) ListPurchases(ctx context.Context, filter ticket.PurchaseFilter) ([]ticket.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchases", ctx, filter)
+	ret0, _ := ret[0].([]ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchases indicates an expected call of ListPurchases.
+func (mr *MockServiceMockRecorder) ListPurchases(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchases", reflect.TypeOf((*MockService)(nil).ListPurchases), ctx, filter)
+}
+
+// ListResaleListings mocks base method.
+func (m *MockService) ListResaleListings(ctx context.Context, ticketID int) ([]ticket.ResaleListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResaleListings", ctx, ticketID)
+	ret0, _ := ret[0].([]ticket.ResaleListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResaleListings indicates an expected call of ListResaleListings.
+func (mr *MockServiceMockRecorder) ListResaleListings(ctx, ticketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResaleListings", reflect.TypeOf((*MockService)(nil).ListResaleListings), ctx, ticketID)
+}
+
+// ListTicketOptions mocks base method.
+func (m *MockService) ListTicketOptions(ctx context.Context, filter ticket.TicketFilter) ([]ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTicketOptions", ctx, filter)
+	ret0, _ := ret[0].([]ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTicketOptions indicates an expected call of ListTicketOptions.
+func (mr *MockServiceMockRecorder) ListTicketOptions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTicketOptions", reflect.TypeOf((*MockService)(nil).ListTicketOptions), ctx, filter)
+}
+
+// PayOrder mocks base method.
+func (m *MockService) PayOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayOrder", ctx, id)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayOrder indicates an expected call of PayOrder.
+func (mr *MockServiceMockRecorder) PayOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockService)(nil).PayOrder), ctx, id)
+}
+
 // PurchaseFromTicketOption mocks base method.
-func (m *MockService) PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string) error {
+func (m *MockService) PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string) (*ticket.Purchase, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PurchaseFromTicketOption", ctx, id, quantity, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // PurchaseFromTicketOption indicates an expected call of PurchaseFromTicketOption.
@@ -78,3 +532,255 @@ func (mr *MockServiceMockRecorder) PurchaseFromTicketOption(ctx, id, quantity, u
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseFromTicketOption", reflect.TypeOf((*MockService)(nil).PurchaseFromTicketOption), ctx, id, quantity, userID)
 }
+
+// PurchaseSeats mocks base method.
+func (m *MockService) PurchaseSeats(ctx context.Context, ticketID int, seatIDs []int, userID string) (*ticket.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurchaseSeats", ctx, ticketID, seatIDs, userID)
+	ret0, _ := ret[0].(*ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurchaseSeats indicates an expected call of PurchaseSeats.
+func (mr *MockServiceMockRecorder) PurchaseSeats(ctx, ticketID, seatIDs, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseSeats", reflect.TypeOf((*MockService)(nil).PurchaseSeats), ctx, ticketID, seatIDs, userID)
+}
+
+// QuotePrice mocks base method.
+func (m *MockService) QuotePrice(ctx context.Context, id, quantity int) (*ticket.PriceQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuotePrice", ctx, id, quantity)
+	ret0, _ := ret[0].(*ticket.PriceQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuotePrice indicates an expected call of QuotePrice.
+func (mr *MockServiceMockRecorder) QuotePrice(ctx, id, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuotePrice", reflect.TypeOf((*MockService)(nil).QuotePrice), ctx, id, quantity)
+}
+
+// RebalanceShards mocks base method.
+func (m *MockService) RebalanceShards(ctx context.Context, id int) (*ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebalanceShards", ctx, id)
+	ret0, _ := ret[0].(*ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebalanceShards indicates an expected call of RebalanceShards.
+func (mr *MockServiceMockRecorder) RebalanceShards(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebalanceShards", reflect.TypeOf((*MockService)(nil).RebalanceShards), ctx, id)
+}
+
+// ReconcileAllocations mocks base method.
+func (m *MockService) ReconcileAllocations(ctx context.Context) ([]ticket.AllocationDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileAllocations", ctx)
+	ret0, _ := ret[0].([]ticket.AllocationDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileAllocations indicates an expected call of ReconcileAllocations.
+func (mr *MockServiceMockRecorder) ReconcileAllocations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileAllocations", reflect.TypeOf((*MockService)(nil).ReconcileAllocations), ctx)
+}
+
+// RefundOrder mocks base method.
+func (m *MockService) RefundOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundOrder", ctx, id)
+	ret0, _ := ret[0].(*ticket.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundOrder indicates an expected call of RefundOrder.
+func (mr *MockServiceMockRecorder) RefundOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockService)(nil).RefundOrder), ctx, id)
+}
+
+// RefundPurchase mocks base method.
+func (m *MockService) RefundPurchase(ctx context.Context, purchaseID int) (*ticket.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPurchase", ctx, purchaseID)
+	ret0, _ := ret[0].(*ticket.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPurchase indicates an expected call of RefundPurchase.
+func (mr *MockServiceMockRecorder) RefundPurchase(ctx, purchaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPurchase", reflect.TypeOf((*MockService)(nil).RefundPurchase), ctx, purchaseID)
+}
+
+// ReleaseExpiredHolds mocks base method.
+func (m *MockService) ReleaseExpiredHolds(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpiredHolds", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpiredHolds indicates an expected call of ReleaseExpiredHolds.
+func (mr *MockServiceMockRecorder) ReleaseExpiredHolds(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpiredHolds", reflect.TypeOf((*MockService)(nil).ReleaseExpiredHolds), ctx)
+}
+
+// ShardAllocation mocks base method.
+func (m *MockService) ShardAllocation(ctx context.Context, id, shards int) (*ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShardAllocation", ctx, id, shards)
+	ret0, _ := ret[0].(*ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShardAllocation indicates an expected call of ShardAllocation.
+func (mr *MockServiceMockRecorder) ShardAllocation(ctx, id, shards interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShardAllocation", reflect.TypeOf((*MockService)(nil).ShardAllocation), ctx, id, shards)
+}
+
+// StreamSalesReport mocks base method.
+func (m *MockService) StreamSalesReport(ctx context.Context, filter ticket.SalesFilter, emit func(ticket.SalesRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSalesReport", ctx, filter, emit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSalesReport indicates an expected call of StreamSalesReport.
+func (mr *MockServiceMockRecorder) StreamSalesReport(ctx, filter, emit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSalesReport", reflect.TypeOf((*MockService)(nil).StreamSalesReport), ctx, filter, emit)
+}
+
+// TransferIssuedTicket mocks base method.
+func (m *MockService) TransferIssuedTicket(ctx context.Context, code, toUserID string) (*ticket.IssuedTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferIssuedTicket", ctx, code, toUserID)
+	ret0, _ := ret[0].(*ticket.IssuedTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferIssuedTicket indicates an expected call of TransferIssuedTicket.
+func (mr *MockServiceMockRecorder) TransferIssuedTicket(ctx, code, toUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferIssuedTicket", reflect.TypeOf((*MockService)(nil).TransferIssuedTicket), ctx, code, toUserID)
+}
+
+// UpdateTicketOption mocks base method.
+func (m *MockService) UpdateTicketOption(ctx context.Context, id int, update ticket.TicketUpdate) (*ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTicketOption", ctx, id, update)
+	ret0, _ := ret[0].(*ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTicketOption indicates an expected call of UpdateTicketOption.
+func (mr *MockServiceMockRecorder) UpdateTicketOption(ctx, id, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTicketOption", reflect.TypeOf((*MockService)(nil).UpdateTicketOption), ctx, id, update)
+}
+
+// MockAvailabilityNotifier is a mock of AvailabilityNotifier interface.
+type MockAvailabilityNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvailabilityNotifierMockRecorder
+}
+
+// MockAvailabilityNotifierMockRecorder is the mock recorder for MockAvailabilityNotifier.
+type MockAvailabilityNotifierMockRecorder struct {
+	mock *MockAvailabilityNotifier
+}
+
+// NewMockAvailabilityNotifier creates a new mock instance.
+func NewMockAvailabilityNotifier(ctrl *gomock.Controller) *MockAvailabilityNotifier {
+	mock := &MockAvailabilityNotifier{ctrl: ctrl}
+	mock.recorder = &MockAvailabilityNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvailabilityNotifier) EXPECT() *MockAvailabilityNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyAvailability mocks base method.
+func (m *MockAvailabilityNotifier) NotifyAvailability(t ticket.Ticket) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyAvailability", t)
+}
+
+// NotifyAvailability indicates an expected call of NotifyAvailability.
+func (mr *MockAvailabilityNotifierMockRecorder) NotifyAvailability(t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyAvailability", reflect.TypeOf((*MockAvailabilityNotifier)(nil).NotifyAvailability), t)
+}
+
+// MockCodeIssuer is a mock of CodeIssuer interface.
+type MockCodeIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCodeIssuerMockRecorder
+}
+
+// MockCodeIssuerMockRecorder is the mock recorder for MockCodeIssuer.
+type MockCodeIssuerMockRecorder struct {
+	mock *MockCodeIssuer
+}
+
+// NewMockCodeIssuer creates a new mock instance.
+func NewMockCodeIssuer(ctrl *gomock.Controller) *MockCodeIssuer {
+	mock := &MockCodeIssuer{ctrl: ctrl}
+	mock.recorder = &MockCodeIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCodeIssuer) EXPECT() *MockCodeIssuerMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockCodeIssuer) Issue(ticketID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ticketID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockCodeIssuerMockRecorder) Issue(ticketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockCodeIssuer)(nil).Issue), ticketID)
+}
+
+// Verify mocks base method.
+func (m *MockCodeIssuer) Verify(code string) (ticketcode.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", code)
+	ret0, _ := ret[0].(ticketcode.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockCodeIssuerMockRecorder) Verify(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockCodeIssuer)(nil).Verify), code)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ticket/repository/webhook.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	ticket "github.com/dilaragorum/ticket-api/internal/ticket"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhookDeliveries mocks base method.
+func (m *MockWebhookRepository) CreateWebhookDeliveries(ctx context.Context, deliveries []ticket.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveries", ctx, deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookDeliveries indicates an expected call of CreateWebhookDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) CreateWebhookDeliveries(ctx, deliveries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).CreateWebhookDeliveries), ctx, deliveries)
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockWebhookRepository) CreateWebhookSubscription(ctx context.Context, url, secret string, eventTypes ticket.EventTypes) (*ticket.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", ctx, url, secret, eventTypes)
+	ret0, _ := ret[0].(*ticket.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockWebhookRepositoryMockRecorder) CreateWebhookSubscription(ctx, url, secret, eventTypes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockWebhookRepository)(nil).CreateWebhookSubscription), ctx, url, secret, eventTypes)
+}
+
+// FetchDueWebhookDeliveries mocks base method.
+func (m *MockWebhookRepository) FetchDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]ticket.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDueWebhookDeliveries", ctx, now, limit)
+	ret0, _ := ret[0].([]ticket.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDueWebhookDeliveries indicates an expected call of FetchDueWebhookDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) FetchDueWebhookDeliveries(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDueWebhookDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).FetchDueWebhookDeliveries), ctx, now, limit)
+}
+
+// GetWebhookSubscription mocks base method.
+func (m *MockWebhookRepository) GetWebhookSubscription(ctx context.Context, id int) (*ticket.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscription", ctx, id)
+	ret0, _ := ret[0].(*ticket.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookSubscription indicates an expected call of GetWebhookSubscription.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhookSubscription(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscription", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhookSubscription), ctx, id)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockWebhookRepository) ListWebhookDeliveries(ctx context.Context, subscriptionID int) ([]ticket.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, subscriptionID)
+	ret0, _ := ret[0].([]ticket.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ListWebhookDeliveries(ctx, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ListWebhookDeliveries), ctx, subscriptionID)
+}
+
+// ListWebhookSubscriptions mocks base method.
+func (m *MockWebhookRepository) ListWebhookSubscriptions(ctx context.Context) ([]ticket.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptions", ctx)
+	ret0, _ := ret[0].([]ticket.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscriptions indicates an expected call of ListWebhookSubscriptions.
+func (mr *MockWebhookRepositoryMockRecorder) ListWebhookSubscriptions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptions", reflect.TypeOf((*MockWebhookRepository)(nil).ListWebhookSubscriptions), ctx)
+}
+
+// RecordWebhookDeliveryAttempt mocks base method.
+func (m *MockWebhookRepository) RecordWebhookDeliveryAttempt(ctx context.Context, delivery *ticket.WebhookDelivery, attempt ticket.WebhookDeliveryAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookDeliveryAttempt", ctx, delivery, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordWebhookDeliveryAttempt indicates an expected call of RecordWebhookDeliveryAttempt.
+func (mr *MockWebhookRepositoryMockRecorder) RecordWebhookDeliveryAttempt(ctx, delivery, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDeliveryAttempt", reflect.TypeOf((*MockWebhookRepository)(nil).RecordWebhookDeliveryAttempt), ctx, delivery, attempt)
+}
+
+// ResetWebhookDelivery mocks base method.
+func (m *MockWebhookRepository) ResetWebhookDelivery(ctx context.Context, subscriptionID, deliveryID int, nextAttemptAt time.Time) (*ticket.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetWebhookDelivery", ctx, subscriptionID, deliveryID, nextAttemptAt)
+	ret0, _ := ret[0].(*ticket.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetWebhookDelivery indicates an expected call of ResetWebhookDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) ResetWebhookDelivery(ctx, subscriptionID, deliveryID, nextAttemptAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetWebhookDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).ResetWebhookDelivery), ctx, subscriptionID, deliveryID, nextAttemptAt)
+}
@@ -1,25 +1,173 @@
 package ticket
 
 import (
+	"encoding/json"
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Ticket struct {
-	ID         int    `gorm:"primaryKey" json:"id"`
-	Name       string `gorm:"not null;unique" json:"name"`
-	Desc       string `gorm:"not null" json:"desc"`
-	Allocation int    `gorm:"not null;check:allocation>0" json:"allocation"`
+	ID int `gorm:"primaryKey" json:"id"`
+	// OrganizerID owns the ticket option. Ticket options made before there were organizers belong to
+	// DefaultOrganizerID. Names are unique per organizer.
+	OrganizerID int    `gorm:"not null;default:1;uniqueIndex:idx_tickets_organizer_name" json:"organizer_id"`
+	Name        string `gorm:"not null;uniqueIndex:idx_tickets_organizer_name" json:"name"`
+	Desc        string `gorm:"not null" json:"desc"`
+	// Capacity is how many tickets the ticket option has in all, of which Sold are sold, Held are
+	// set aside for buyers and Available are left, so that Available = Capacity - Sold - Held.
+	// Available caches what the ledger entries of the ticket option add up to.
+	Capacity  int `gorm:"not null;default:0;check:chk_tickets_capacity_non_negative,capacity >= 0" json:"capacity"`
+	Sold      int `gorm:"not null;default:0;check:chk_tickets_sold_non_negative,sold >= 0" json:"sold"`
+	Held      int `gorm:"not null;default:0;check:chk_tickets_held_non_negative,held >= 0" json:"held"`
+	Available int `gorm:"not null;check:chk_tickets_available_non_negative,available >= 0" json:"available"`
+	// Price is the face value of one ticket in minor units of the currency.
+	Price int `gorm:"not null;default:0;check:chk_tickets_price_non_negative,price >= 0" json:"price"`
+	// StartsAt is when the event admitting this ticket option begins, if scheduled.
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	// SaleStartsAt and SaleEndsAt bound when the ticket option can be purchased, from SaleStartsAt up to
+	// but not including SaleEndsAt. Either may be nil to leave that side of the window open.
+	SaleStartsAt *time.Time `gorm:"index" json:"sale_starts_at,omitempty"`
+	SaleEndsAt   *time.Time `gorm:"index" json:"sale_ends_at,omitempty"`
+	// Shards is how many AllocationShard the available tickets are split across for purchases to
+	// take from concurrently, or 0 when they are all counted here.
+	Shards int `gorm:"not null;default:0" json:"shards,omitempty"`
+	// Seated ticket options sell the seats of their seat map rather than general admission.
+	Seated bool `gorm:"not null;default:false" json:"seated"`
+	// BestSeat is the point of the seat map best available seats are looked for around.
+	BestSeat SeatPoint `gorm:"embedded;embeddedPrefix:best_seat_" json:"-"`
+	// PriceTiers override Price while they apply.
+	PriceTiers []PriceTier `gorm:"foreignKey:TicketID" json:"price_tiers,omitempty"`
 	gorm.Model
 }
 
-type Purchase struct {
-	ID       int `gorm:"primaryKey"`
+// MarshalJSON encodes t with allocation next to available, as it was called before capacity, sold
+// and held were counted, for the clients still reading it. allocation is deprecated.
+func (t Ticket) MarshalJSON() ([]byte, error) {
+	type plain Ticket
+
+	return json.Marshal(struct {
+		plain
+		Allocation int `json:"allocation"`
+	}{plain(t), t.Available})
+}
+
+// TicketFilter selects a page of ticket options ordered by id. OnSale keeps only the ticket options
+// whose sale window contains At.
+type TicketFilter struct {
+	Limit   int
+	AfterID int
+	OnSale  bool
+	At      time.Time
+}
+
+// TicketUpdate changes the fields of a ticket option that are not nil. Allocation is changed by
+// adjusting it instead, so that every change of it has a reason.
+type TicketUpdate struct {
+	Name         *string
+	Desc         *string
+	Price        *int
+	StartsAt     *time.Time
+	SaleStartsAt *time.Time
+	SaleEndsAt   *time.Time
+}
+
+// TicketDraft holds what a ticket option is created with, for creating many of them at once.
+type TicketDraft struct {
+	Name         string
+	Desc         string
+	Allocation   int
+	Price        int
+	StartsAt     *time.Time
+	SaleStartsAt *time.Time
+	SaleEndsAt   *time.Time
+}
+
+// BulkMode tells what becomes of the other rows of a bulk import when some of them fail.
+type BulkMode string
+
+const (
+	// BulkAllOrNothing creates every row or, if any of them fails, none of them.
+	BulkAllOrNothing BulkMode = "all_or_nothing"
+	// BulkBestEffort creates every row that can be created.
+	BulkBestEffort BulkMode = "best_effort"
+)
+
+// BulkResult is what became of one row of a bulk import. Rows are numbered from 1. Ticket is set
+// when the row was created and Err when it was not.
+type BulkResult struct {
+	Row    int
+	Ticket *Ticket
+	Err    error
+}
+
+// PurchaseFilter selects a page of purchases ordered by id, of one ticket option and one user when
+// they are given.
+type PurchaseFilter struct {
+	TicketID int
 	UserID   string
-	TicketID int `gorm:"not null"`
-	Quantity int `gorm:"not null;check:quantity>0"`
+	Limit    int
+	AfterID  int
+}
+
+type Purchase struct {
+	ID          int `gorm:"primaryKey"`
+	OrganizerID int `gorm:"not null;default:1;index"`
+	UserID      string
+	TicketID    int `gorm:"not null"`
+	Quantity    int `gorm:"not null;check:quantity>0"`
+	// OrderID is set on the purchases that are the items of an order.
+	OrderID *int `gorm:"index"`
+	// TotalPrice is what the purchase was quoted at, in minor units, locked in when it was made.
+	TotalPrice    int `gorm:"not null;default:0"`
+	RefundedAt    *time.Time
+	IssuedTickets []IssuedTicket `gorm:"foreignKey:PurchaseID"`
 	gorm.Model
 }
 
 func (Purchase) TableName() string {
 	return "tickets_purchases"
 }
+
+type IssuedTicketStatus string
+
+const (
+	IssuedTicketValid    IssuedTicketStatus = "valid"
+	IssuedTicketUsed     IssuedTicketStatus = "used"
+	IssuedTicketRefunded IssuedTicketStatus = "refunded"
+)
+
+// IssuedTicket is a single admission from a Purchase, identified by a signed code.
+type IssuedTicket struct {
+	ID          int                `gorm:"primaryKey" json:"id"`
+	Code        string             `gorm:"not null;uniqueIndex" json:"code"`
+	PurchaseID  int                `gorm:"not null;index" json:"purchase_id"`
+	TicketID    int                `gorm:"not null;index" json:"ticket_id"`
+	UserID      string             `json:"user_id"`
+	SeatID      *int               `gorm:"index" json:"seat_id,omitempty"`
+	Status      IssuedTicketStatus `gorm:"not null" json:"status"`
+	CheckedInAt *time.Time         `json:"checked_in_at,omitempty"`
+	GateID      string             `json:"gate_id,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// TicketTransfer records an issued ticket changing hands. The old code stops admitting anyone
+// once the new code is issued.
+type TicketTransfer struct {
+	ID             int       `gorm:"primaryKey" json:"id"`
+	IssuedTicketID int       `gorm:"not null;index" json:"issued_ticket_id"`
+	FromUserID     string    `gorm:"not null" json:"from_user_id"`
+	ToUserID       string    `gorm:"not null" json:"to_user_id"`
+	OldCode        string    `gorm:"not null" json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CheckinStats is the live attendance of an event. An event is a ticket option, so EventID is a ticket option id.
+type CheckinStats struct {
+	EventID    int            `json:"event_id"`
+	Issued     int            `json:"issued"`
+	CheckedIn  int            `json:"checked_in"`
+	NotArrived int            `json:"not_arrived"`
+	ByGate     map[string]int `json:"by_gate"`
+}
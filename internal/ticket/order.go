@@ -0,0 +1,60 @@
+package ticket
+
+import (
+	"time"
+)
+
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"
+	OrderPaid      OrderStatus = "paid"
+	OrderCancelled OrderStatus = "cancelled"
+	OrderRefunded  OrderStatus = "refunded"
+)
+
+// orderTransitions lists the statuses each status can move to. Cancelled and refunded orders are final.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending: {OrderPaid, OrderCancelled},
+	OrderPaid:    {OrderRefunded},
+}
+
+// CanBecome tells whether an order with status s can move to next.
+func (s OrderStatus) CanBecome(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Order buys several ticket options at once. Placing it holds the tickets of all its items off their
+// allocations, or none of them; they are priced then too. They are sold and issued once it is paid,
+// and given back when it is cancelled or not paid before ExpiresAt.
+type Order struct {
+	ID          int         `gorm:"primaryKey" json:"id"`
+	OrganizerID int         `gorm:"not null;default:1;index" json:"organizer_id"`
+	UserID      string      `gorm:"not null;index" json:"user_id"`
+	Status      OrderStatus `gorm:"not null" json:"status"`
+	TotalPrice  int         `gorm:"not null;default:0" json:"total_price"`
+	Items       []OrderItem `gorm:"foreignKey:OrderID" json:"items"`
+	ExpiresAt   *time.Time  `gorm:"index" json:"expires_at,omitempty"`
+	PaidAt      *time.Time  `json:"paid_at,omitempty"`
+	CancelledAt *time.Time  `json:"cancelled_at,omitempty"`
+	RefundedAt  *time.Time  `json:"refunded_at,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// OrderItem is the line of an order for one ticket option. It is recorded as the purchase PurchaseID,
+// so its tickets are issued, checked in and transferred like those of any other purchase.
+type OrderItem struct {
+	ID         int `gorm:"primaryKey" json:"id"`
+	OrderID    int `gorm:"not null;index" json:"order_id"`
+	TicketID   int `gorm:"not null" json:"ticket_id"`
+	Quantity   int `gorm:"not null;check:chk_order_items_quantity_positive,quantity > 0" json:"quantity"`
+	TotalPrice int `gorm:"not null;default:0" json:"total_price"`
+	PurchaseID int `gorm:"not null" json:"purchase_id"`
+}
@@ -0,0 +1,16 @@
+package ticket
+
+import "time"
+
+// DefaultOrganizerID is the organizer that owned everything before there were organizers.
+const DefaultOrganizerID = 1
+
+// Organizer is a promoter selling its own ticket options. Everything sold is scoped by organizer, so
+// one organizer can never see or sell the ticket options of another. TokenHash is the SHA-256 of the
+// bearer token the organizer authenticates with; the token itself is not stored.
+type Organizer struct {
+	ID        int       `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"not null;uniqueIndex" json:"name"`
+	TokenHash string    `gorm:"index" json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,114 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+// EventPublisher delivers domain events to the outside world. Publish may be called more than
+// once for the same event, so implementations must tolerate duplicates (use Event.ID to dedupe).
+type EventPublisher interface {
+	Publish(ctx context.Context, event ticket.Event) error
+}
+
+// Relay polls the outbox table and hands pending events to an EventPublisher. A failed
+// publish is retried with exponential backoff; after MaxAttempts the event is moved to
+// the dead state and no longer retried.
+type Relay struct {
+	repository repository.OutboxRepository
+	publisher  EventPublisher
+
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Now          func() time.Time
+}
+
+func NewRelay(repository repository.OutboxRepository, publisher EventPublisher) *Relay {
+	return &Relay{
+		repository:   repository,
+		publisher:    publisher,
+		PollInterval: time.Second,
+		BatchSize:    100, //nolint:gomnd
+		MaxAttempts:  10,  //nolint:gomnd
+		BaseBackoff:  time.Second,
+		MaxBackoff:   5 * time.Minute, //nolint:gomnd
+		Now:          time.Now,
+	}
+}
+
+// Run relays events until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.ProcessBatch(ctx); err != nil && ctx.Err() == nil {
+			log.Error(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch publishes one batch of due events and returns how many were published.
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	messages, err := r.repository.FetchPendingEvents(ctx, r.Now(), r.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, message := range messages {
+		if err := r.publisher.Publish(ctx, message.Event()); err != nil {
+			if err := r.fail(ctx, message, err); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := r.repository.MarkEventPublished(ctx, message.ID, r.Now()); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+func (r *Relay) fail(ctx context.Context, message ticket.OutboxMessage, publishErr error) error {
+	attempts := message.Attempts + 1
+	dead := attempts >= r.MaxAttempts
+	if dead {
+		log.Errorf("outbox event %d (%s) moved to dead letter after %d attempts: %v", message.ID, message.EventType, attempts, publishErr)
+	}
+
+	nextAttemptAt := r.Now().Add(Backoff(r.BaseBackoff, r.MaxBackoff, attempts))
+
+	return r.repository.MarkEventFailed(ctx, message.ID, attempts, nextAttemptAt, publishErr.Error(), dead)
+}
+
+// Backoff returns the delay before the next try after the given number of failed attempts:
+// base, 2*base, 4*base, ... capped at max.
+func Backoff(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+
+	return delay
+}
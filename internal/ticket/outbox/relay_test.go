@@ -0,0 +1,101 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/outbox"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newRelay(t *testing.T) (*outbox.Relay, *mocks.MockOutboxRepository, *mocks.MockEventPublisher) {
+	controller := gomock.NewController(t)
+	mockRepository := mocks.NewMockOutboxRepository(controller)
+	mockPublisher := mocks.NewMockEventPublisher(controller)
+
+	relay := outbox.NewRelay(mockRepository, mockPublisher)
+	relay.Now = func() time.Time { return now }
+
+	return relay, mockRepository, mockPublisher
+}
+
+func Test_Should_Publish_Pending_Events_And_Mark_Them_Published(t *testing.T) {
+	// Given
+	relay, mockRepository, mockPublisher := newRelay(t)
+	messages := []ticket.OutboxMessage{
+		{ID: 1, EventType: ticket.EventTicketPurchased, Payload: []byte(`{}`)},
+		{ID: 2, EventType: ticket.EventTicketSoldOut, Payload: []byte(`{}`)},
+	}
+
+	mockRepository.EXPECT().FetchPendingEvents(gomock.Any(), now, relay.BatchSize).Return(messages, nil).Times(1)
+	mockPublisher.EXPECT().Publish(gomock.Any(), messages[0].Event()).Return(nil).Times(1)
+	mockPublisher.EXPECT().Publish(gomock.Any(), messages[1].Event()).Return(nil).Times(1)
+	mockRepository.EXPECT().MarkEventPublished(gomock.Any(), 1, now).Return(nil).Times(1)
+	mockRepository.EXPECT().MarkEventPublished(gomock.Any(), 2, now).Return(nil).Times(1)
+
+	// When
+	published, err := relay.ProcessBatch(context.TODO())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, published)
+}
+
+func Test_Should_Reschedule_Event_With_Backoff_When_Publish_Fails(t *testing.T) {
+	type testCase struct {
+		name             string
+		previousAttempts int
+		expectedDelay    time.Duration
+		expectedDead     bool
+	}
+
+	testCases := []testCase{
+		{name: "first failure waits base backoff", previousAttempts: 0, expectedDelay: time.Second, expectedDead: false},
+		{name: "third failure doubles twice", previousAttempts: 2, expectedDelay: 4 * time.Second, expectedDead: false},
+		{name: "backoff is capped", previousAttempts: 8, expectedDelay: 4 * time.Minute, expectedDead: false},
+		{name: "last attempt moves event to dead letter", previousAttempts: 9, expectedDelay: 4 * time.Minute, expectedDead: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			relay, mockRepository, mockPublisher := newRelay(t)
+			relay.MaxBackoff = 4 * time.Minute
+			message := ticket.OutboxMessage{ID: 7, EventType: ticket.EventTicketPurchased, Attempts: test.previousAttempts}
+
+			mockRepository.EXPECT().FetchPendingEvents(gomock.Any(), now, relay.BatchSize).
+				Return([]ticket.OutboxMessage{message}, nil).Times(1)
+			mockPublisher.EXPECT().Publish(gomock.Any(), message.Event()).Return(errors.New("broker down")).Times(1)
+			mockRepository.EXPECT().
+				MarkEventFailed(gomock.Any(), 7, test.previousAttempts+1, now.Add(test.expectedDelay), "broker down", test.expectedDead).
+				Return(nil).Times(1)
+
+			// When
+			published, err := relay.ProcessBatch(context.TODO())
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, 0, published)
+		})
+	}
+}
+
+func Test_Should_Return_Error_When_Fetching_Pending_Events_Fails(t *testing.T) {
+	// Given
+	relay, mockRepository, _ := newRelay(t)
+	mockRepository.EXPECT().FetchPendingEvents(gomock.Any(), now, relay.BatchSize).Return(nil, errors.New("test")).Times(1)
+
+	// When
+	published, err := relay.ProcessBatch(context.TODO())
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, 0, published)
+}
@@ -0,0 +1,40 @@
+package ticket
+
+import (
+	"time"
+)
+
+type PriceTierKind string
+
+const (
+	// PriceTierEarlyBird applies until Until.
+	PriceTierEarlyBird PriceTierKind = "early_bird"
+	// PriceTierSoldStep applies once AfterSold tickets of the option are sold.
+	PriceTierSoldStep PriceTierKind = "sold_step"
+	// PriceTierLastMinute applies from From on.
+	PriceTierLastMinute PriceTierKind = "last_minute"
+)
+
+// PriceTier overrides the face value of a ticket option while it applies. Price is in minor units.
+type PriceTier struct {
+	ID        int           `gorm:"primaryKey" json:"id"`
+	TicketID  int           `gorm:"not null;index" json:"ticket_id"`
+	Kind      PriceTierKind `gorm:"not null" json:"kind"`
+	Price     int           `gorm:"not null;check:chk_price_tiers_price_non_negative,price >= 0" json:"price"`
+	Until     *time.Time    `json:"until,omitempty"`
+	AfterSold int           `json:"after_sold,omitempty"`
+	From      *time.Time    `json:"from,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// PriceQuote prices Quantity tickets of a ticket option. UnitPrices holds the price of each ticket in
+// order, as tickets of one purchase can straddle a sold step.
+type PriceQuote struct {
+	TicketID   int   `json:"ticket_id"`
+	Quantity   int   `json:"quantity"`
+	UnitPrices []int `json:"unit_prices"`
+	Total      int   `json:"total"`
+}
+
+// PriceQuoter prices a purchase given how many tickets of the option were sold before it.
+type PriceQuoter func(sold int) PriceQuote
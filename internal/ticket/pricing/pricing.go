@@ -0,0 +1,63 @@
+// Package pricing evaluates the price tiers of a ticket option.
+package pricing
+
+import (
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+// Quote prices quantity tickets of option when sold tickets were sold before them. Each ticket is
+// priced on its own so a purchase crossing a sold step pays both prices.
+//
+// A last-minute tier that has started wins, then an early-bird tier that has not ended, then the
+// highest sold step reached; otherwise the face value applies. Among last-minute tiers the latest
+// started wins and among early-bird tiers the one ending first.
+func Quote(option ticket.Ticket, sold, quantity int, now time.Time) ticket.PriceQuote {
+	quote := ticket.PriceQuote{
+		TicketID:   option.ID,
+		Quantity:   quantity,
+		UnitPrices: make([]int, quantity),
+	}
+
+	for i := range quote.UnitPrices {
+		quote.UnitPrices[i] = unitPrice(option, sold+i, now)
+		quote.Total += quote.UnitPrices[i]
+	}
+
+	return quote
+}
+
+func unitPrice(option ticket.Ticket, sold int, now time.Time) int {
+	var lastMinute, earlyBird, step *ticket.PriceTier
+
+	for i := range option.PriceTiers {
+		tier := &option.PriceTiers[i]
+
+		switch tier.Kind {
+		case ticket.PriceTierLastMinute:
+			if tier.From != nil && !now.Before(*tier.From) && (lastMinute == nil || tier.From.After(*lastMinute.From)) {
+				lastMinute = tier
+			}
+		case ticket.PriceTierEarlyBird:
+			if tier.Until != nil && now.Before(*tier.Until) && (earlyBird == nil || tier.Until.Before(*earlyBird.Until)) {
+				earlyBird = tier
+			}
+		case ticket.PriceTierSoldStep:
+			if sold >= tier.AfterSold && (step == nil || tier.AfterSold > step.AfterSold) {
+				step = tier
+			}
+		}
+	}
+
+	switch {
+	case lastMinute != nil:
+		return lastMinute.Price
+	case earlyBird != nil:
+		return earlyBird.Price
+	case step != nil:
+		return step.Price
+	default:
+		return option.Price
+	}
+}
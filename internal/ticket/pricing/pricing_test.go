@@ -0,0 +1,106 @@
+package pricing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Quote_Each_Ticket_By_The_Tier_That_Applies(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+
+	type testCase struct {
+		name               string
+		tiers              []ticket.PriceTier
+		sold               int
+		quantity           int
+		expectedUnitPrices []int
+		expectedTotal      int
+	}
+
+	testCases := []testCase{
+		{
+			name:               "face value without tiers",
+			sold:               0,
+			quantity:           2,
+			expectedUnitPrices: []int{1000, 1000},
+			expectedTotal:      2000,
+		},
+		{
+			name:               "early bird before it ends",
+			tiers:              []ticket.PriceTier{{Kind: ticket.PriceTierEarlyBird, Price: 700, Until: &tomorrow}},
+			quantity:           1,
+			expectedUnitPrices: []int{700},
+			expectedTotal:      700,
+		},
+		{
+			name:               "early bird after it ended",
+			tiers:              []ticket.PriceTier{{Kind: ticket.PriceTierEarlyBird, Price: 700, Until: &yesterday}},
+			quantity:           1,
+			expectedUnitPrices: []int{1000},
+			expectedTotal:      1000,
+		},
+		{
+			name: "earliest ending early bird",
+			tiers: []ticket.PriceTier{
+				{Kind: ticket.PriceTierEarlyBird, Price: 800, Until: &nextWeek},
+				{Kind: ticket.PriceTierEarlyBird, Price: 600, Until: &tomorrow},
+			},
+			quantity:           1,
+			expectedUnitPrices: []int{600},
+			expectedTotal:      600,
+		},
+		{
+			name: "purchase straddling sold steps",
+			tiers: []ticket.PriceTier{
+				{Kind: ticket.PriceTierSoldStep, Price: 1200, AfterSold: 10},
+				{Kind: ticket.PriceTierSoldStep, Price: 1500, AfterSold: 12},
+			},
+			sold:               9,
+			quantity:           4,
+			expectedUnitPrices: []int{1000, 1200, 1200, 1500},
+			expectedTotal:      4900,
+		},
+		{
+			name: "last minute wins over early bird and steps",
+			tiers: []ticket.PriceTier{
+				{Kind: ticket.PriceTierEarlyBird, Price: 700, Until: &tomorrow},
+				{Kind: ticket.PriceTierSoldStep, Price: 1200, AfterSold: 1},
+				{Kind: ticket.PriceTierLastMinute, Price: 500, From: &yesterday},
+			},
+			sold:               5,
+			quantity:           1,
+			expectedUnitPrices: []int{500},
+			expectedTotal:      500,
+		},
+		{
+			name:               "last minute not started",
+			tiers:              []ticket.PriceTier{{Kind: ticket.PriceTierLastMinute, Price: 500, From: &tomorrow}},
+			quantity:           1,
+			expectedUnitPrices: []int{1000},
+			expectedTotal:      1000,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			option := ticket.Ticket{ID: 1, Price: 1000, PriceTiers: test.tiers}
+
+			// When
+			quote := pricing.Quote(option, test.sold, test.quantity, now)
+
+			// Then
+			assert.Equal(t, 1, quote.TicketID)
+			assert.Equal(t, test.quantity, quote.Quantity)
+			assert.Equal(t, test.expectedUnitPrices, quote.UnitPrices)
+			assert.Equal(t, test.expectedTotal, quote.Total)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package ticket
+
+import "time"
+
+// SalesInterval is the length of the periods a sales report splits the sales of each ticket option
+// into. SalesTotal does not split them.
+type SalesInterval string
+
+const (
+	SalesTotal  SalesInterval = ""
+	SalesByDay  SalesInterval = "day"
+	SalesByHour SalesInterval = "hour"
+)
+
+// SalesFilter selects what a sales report covers: the sales and refunds made from From up to but
+// not including To, of one ticket option when TicketID is given. Either end may be nil.
+type SalesFilter struct {
+	TicketID int
+	From     *time.Time
+	To       *time.Time
+	Interval SalesInterval
+}
+
+// SalesRow sums up the sales and refunds of a ticket option over a period, in UTC, or over the
+// whole report when it is not split into periods. Revenue and Refunds are in minor units.
+type SalesRow struct {
+	TicketID   int        `json:"ticket_id"`
+	TicketName string     `json:"ticket_name"`
+	Period     *time.Time `json:"period,omitempty"`
+	// Sold and Refunded count tickets sold and refunded in the period.
+	Sold     int `json:"sold"`
+	Revenue  int `json:"revenue"`
+	Refunded int `json:"refunded"`
+	Refunds  int `json:"refunds"`
+	// Remaining and Capacity are Ticket.Available and Ticket.Capacity now.
+	Remaining int `json:"remaining"`
+	Capacity  int `json:"capacity"`
+	// SellThrough is the percentage of Capacity the period sold net of refunds.
+	SellThrough float64 `json:"sell_through"`
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/audit"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+)
+
+func (df *DefaultRepository) ListAuditEntries(ctx context.Context, filter ticket.AuditFilter) ([]ticket.AuditEntry, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ticket.AuditEntry
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	query := df.reader().WithContext(timeoutCtx).Scopes(ofOrganizer(organizerID)).Where("id > ?", filter.AfterID)
+
+	switch {
+	case strings.Contains(filter.Target, ":"):
+		query = query.Where("target = ?", filter.Target)
+	case filter.Target != "":
+		query = query.Where("target LIKE ?", filter.Target+":%")
+	}
+
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if err = query.Order("id").Limit(filter.Limit).Find(&entries).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// writeAudit appends to the audit log using tx, so the entry is committed or rolled back together
+// with the change it records. The organizer, actor and request id are taken from the context of tx.
+func writeAudit(tx *gorm.DB, action ticket.AuditAction, target string, before, after interface{}) error {
+	beforeJSON, afterJSON, err := audit.Diff(before, after)
+	if err != nil {
+		return err
+	}
+
+	origin := audit.OriginFrom(tx.Statement.Context)
+	organizerID, _ := tenant.OrganizerFrom(tx.Statement.Context)
+	entry := ticket.AuditEntry{
+		OrganizerID: organizerID,
+		Actor:       origin.Actor,
+		Action:      action,
+		Target:      target,
+		Before:      beforeJSON,
+		After:       afterJSON,
+		RequestID:   origin.RequestID,
+		CreatedAt:   time.Now(),
+	}
+
+	return tx.Create(&entry).Error
+}
+
+// auditTarget names the target kind:id of an audit entry.
+func auditTarget(kind string, id int) string {
+	return fmt.Sprintf("%s:%d", kind, id)
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+)
+
+// DefaultCacheTTL is how long CachedRepository keeps a ticket option. Instances only forget the
+// ticket options changed through them, so it bounds how stale the others can be.
+const DefaultCacheTTL = 5 * time.Second
+
+// CachedRepository is a Repository keeping the ticket options GetTicket returns for a while, and
+// forgetting them when they are changed through it. Concurrent misses of a ticket option load it
+// once. Everything else goes straight to the Repository it decorates.
+//
+// Created ticket options are not forgotten, as they have ids nothing was cached under: ticket
+// options that were not found are not cached.
+type CachedRepository struct {
+	Repository
+	ttl   time.Duration
+	now   func() time.Time
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	// version changes whenever a ticket option is forgotten, so that loads started before that are
+	// not cached.
+	version uint64
+	sweptAt time.Time
+}
+
+type cacheKey struct {
+	organizerID int
+	id          int
+}
+
+type cacheEntry struct {
+	option    *ticket.Ticket
+	expiresAt time.Time
+}
+
+func NewCachedRepository(repository Repository, ttl time.Duration) *CachedRepository {
+	return &CachedRepository{
+		Repository: repository,
+		ttl:        ttl,
+		now:        time.Now,
+		entries:    map[cacheKey]cacheEntry{},
+	}
+}
+
+func (c *CachedRepository) GetTicket(ctx context.Context, id int) (*ticket.Ticket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cacheKey{organizerID: organizerID, id: id}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && c.now().Before(entry.expiresAt) {
+		option := cloneTicket(entry.option)
+		c.mu.Unlock()
+		return option, nil
+	}
+	version := c.version
+	c.mu.Unlock()
+
+	// The load is shared, so it is not cut short when the caller that started it goes away.
+	flight := c.group.DoChan(fmt.Sprintf("%d:%d:%d", organizerID, id, version), func() (interface{}, error) {
+		option, err := c.Repository.GetTicket(tenant.WithOrganizer(context.Background(), organizerID), id)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.version == version {
+			c.sweep()
+			c.entries[key] = cacheEntry{option: option, expiresAt: c.now().Add(c.ttl)}
+		}
+
+		return option, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-flight:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+
+		return cloneTicket(result.Val.(*ticket.Ticket)), nil
+	}
+}
+
+func (c *CachedRepository) UpdateTicketOption(ctx context.Context, id int, update ticket.TicketUpdate) (*ticket.Ticket, error) {
+	defer c.forget(ctx, id)
+
+	return c.Repository.UpdateTicketOption(ctx, id, update)
+}
+
+func (c *CachedRepository) AdjustAllocation(ctx context.Context, id, delta int, reason string) (*ticket.Ticket, error) {
+	defer c.forget(ctx, id)
+
+	return c.Repository.AdjustAllocation(ctx, id, delta, reason)
+}
+
+func (c *CachedRepository) ShardAllocation(ctx context.Context, id, shards int) (*ticket.Ticket, error) {
+	defer c.forget(ctx, id)
+
+	return c.Repository.ShardAllocation(ctx, id, shards)
+}
+
+func (c *CachedRepository) RebalanceShards(ctx context.Context, id int) (*ticket.Ticket, error) {
+	defer c.forget(ctx, id)
+
+	return c.Repository.RebalanceShards(ctx, id)
+}
+
+func (c *CachedRepository) PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string, codes []string,
+	quote ticket.PriceQuoter) (*ticket.Purchase, error) {
+	defer c.forget(ctx, id)
+
+	return c.Repository.PurchaseFromTicketOption(ctx, id, quantity, userID, codes, quote)
+}
+
+func (c *CachedRepository) RefundPurchase(ctx context.Context, purchaseID int) (*ticket.Purchase, error) {
+	purchase, err := c.Repository.RefundPurchase(ctx, purchaseID)
+	if err == nil {
+		c.forget(ctx, purchase.TicketID)
+	}
+
+	return purchase, err
+}
+
+func (c *CachedRepository) CreatePriceTier(ctx context.Context, tier ticket.PriceTier) (*ticket.PriceTier, error) {
+	defer c.forget(ctx, tier.TicketID)
+
+	return c.Repository.CreatePriceTier(ctx, tier)
+}
+
+func (c *CachedRepository) DeletePriceTier(ctx context.Context, ticketID, tierID int) error {
+	defer c.forget(ctx, ticketID)
+
+	return c.Repository.DeletePriceTier(ctx, ticketID, tierID)
+}
+
+func (c *CachedRepository) CreateSeatMap(ctx context.Context, ticketID int, seats []ticket.Seat, best ticket.SeatPoint) error {
+	defer c.forget(ctx, ticketID)
+
+	return c.Repository.CreateSeatMap(ctx, ticketID, seats, best)
+}
+
+func (c *CachedRepository) HoldSeats(ctx context.Context, ticketID int, seatIDs []int, userID string, now, until time.Time) error {
+	defer c.forget(ctx, ticketID)
+
+	return c.Repository.HoldSeats(ctx, ticketID, seatIDs, userID, now, until)
+}
+
+func (c *CachedRepository) PurchaseSeats(ctx context.Context, ticketID int, userID string, seatIDs []int, codes []string,
+	quote ticket.PriceQuoter, now time.Time) (*ticket.Purchase, error) {
+	defer c.forget(ctx, ticketID)
+
+	return c.Repository.PurchaseSeats(ctx, ticketID, userID, seatIDs, codes, quote, now)
+}
+
+func (c *CachedRepository) CreateOrder(ctx context.Context, userID string, items []ticket.OrderItem,
+	quotes map[int]ticket.PriceQuoter, expiresAt time.Time) (*ticket.Order, error) {
+	defer c.forget(ctx, orderTicketIDs(items)...)
+
+	return c.Repository.CreateOrder(ctx, userID, items, quotes, expiresAt)
+}
+
+func (c *CachedRepository) PayOrder(ctx context.Context, id int, codes map[int][]string) (*ticket.Order, error) {
+	order, err := c.Repository.PayOrder(ctx, id, codes)
+	if err == nil {
+		c.forget(ctx, orderTicketIDs(order.Items)...)
+	}
+
+	return order, err
+}
+
+func (c *CachedRepository) CancelOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	order, err := c.Repository.CancelOrder(ctx, id)
+	if err == nil {
+		c.forget(ctx, orderTicketIDs(order.Items)...)
+	}
+
+	return order, err
+}
+
+func (c *CachedRepository) RefundOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	order, err := c.Repository.RefundOrder(ctx, id)
+	if err == nil {
+		c.forget(ctx, orderTicketIDs(order.Items)...)
+	}
+
+	return order, err
+}
+
+// ReleaseExpiredHolds forgets the ticket options it gives seats back to, of whichever organizer.
+func (c *CachedRepository) ReleaseExpiredHolds(ctx context.Context, now time.Time, limit int) (int, []ticket.Ticket, error) {
+	released, options, err := c.Repository.ReleaseExpiredHolds(ctx, now, limit)
+	for _, option := range options {
+		c.forget(tenant.WithOrganizer(ctx, option.OrganizerID), option.ID)
+	}
+
+	return released, options, err
+}
+
+// ExpirePendingOrders forgets the ticket options it gives tickets back to, of whichever organizer.
+func (c *CachedRepository) ExpirePendingOrders(ctx context.Context, now time.Time, limit int) (int, []ticket.Ticket, error) {
+	expired, options, err := c.Repository.ExpirePendingOrders(ctx, now, limit)
+	for _, option := range options {
+		c.forget(tenant.WithOrganizer(ctx, option.OrganizerID), option.ID)
+	}
+
+	return expired, options, err
+}
+
+// forget drops the ticket options ids of the organizer of ctx from the cache, and keeps the loads
+// going on from being cached, as they may be of them.
+func (c *CachedRepository) forget(ctx context.Context, ids ...int) {
+	organizerID, ok := tenant.OrganizerFrom(ctx)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	for _, id := range ids {
+		delete(c.entries, cacheKey{organizerID: organizerID, id: id})
+	}
+}
+
+// sweep drops the expired ticket options, at most once a ttl, so that the cache only holds those
+// loaded lately. c.mu must be held.
+func (c *CachedRepository) sweep() {
+	now := c.now()
+	if now.Before(c.sweptAt.Add(c.ttl)) {
+		return
+	}
+
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	c.sweptAt = now
+}
+
+func orderTicketIDs(items []ticket.OrderItem) []int {
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.TicketID
+	}
+
+	return ids
+}
+
+// cloneTicket copies option, so that callers can change what they get without changing the cache.
+func cloneTicket(option *ticket.Ticket) *ticket.Ticket {
+	clone := *option
+	clone.PriceTiers = append([]ticket.PriceTier(nil), option.PriceTiers...)
+
+	return &clone
+}
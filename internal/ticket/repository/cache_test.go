@@ -0,0 +1,220 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Cache Unit Tests
+
+func Test_Should_Load_Ticket_Option_Once_When_Read_Again_Or_At_Once(t *testing.T) {
+	// Given
+	ctx := tenant.WithOrganizer(context.TODO(), 1)
+	release := make(chan struct{})
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).
+		DoAndReturn(func(context.Context, int) (*ticket.Ticket, error) {
+			<-release
+			return &ticket.Ticket{ID: 1, Capacity: 500, Available: 120}, nil
+		}).Times(1)
+
+	cache := repository.NewCachedRepository(mockRepository, time.Minute)
+
+	// When
+	options := make([]*ticket.Ticket, 10)
+	errs := make([]error, len(options))
+
+	var wg sync.WaitGroup
+	for i := range options {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			options[i], errs[i] = cache.GetTicket(ctx, 1)
+		}(i)
+	}
+	close(release)
+	wg.Wait()
+
+	again, err := cache.GetTicket(ctx, 1)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 120, again.Available)
+	for i := range options {
+		assert.Nil(t, errs[i])
+		assert.Equal(t, 120, options[i].Available)
+	}
+}
+
+func Test_Should_Load_Ticket_Option_Again_When_It_Changed(t *testing.T) {
+	type testCase struct {
+		change func(ctx context.Context, cache *repository.CachedRepository, mockRepository *mocks.MockRepository)
+	}
+
+	testCases := []testCase{
+		{change: func(ctx context.Context, cache *repository.CachedRepository, mockRepository *mocks.MockRepository) {
+			mockRepository.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 2, "user", nil, nil).
+				Return(&ticket.Purchase{ID: 7, TicketID: 1, Quantity: 2}, nil).Times(1)
+			_, _ = cache.PurchaseFromTicketOption(ctx, 1, 2, "user", nil, nil)
+		}},
+		{change: func(ctx context.Context, cache *repository.CachedRepository, mockRepository *mocks.MockRepository) {
+			price := 1500
+			mockRepository.EXPECT().UpdateTicketOption(gomock.Any(), 1, ticket.TicketUpdate{Price: &price}).
+				Return(&ticket.Ticket{ID: 1}, nil).Times(1)
+			_, _ = cache.UpdateTicketOption(ctx, 1, ticket.TicketUpdate{Price: &price})
+		}},
+		{change: func(ctx context.Context, cache *repository.CachedRepository, mockRepository *mocks.MockRepository) {
+			mockRepository.EXPECT().RefundPurchase(gomock.Any(), 7).Return(&ticket.Purchase{ID: 7, TicketID: 1}, nil).Times(1)
+			_, _ = cache.RefundPurchase(ctx, 7)
+		}},
+		{change: func(ctx context.Context, cache *repository.CachedRepository, mockRepository *mocks.MockRepository) {
+			mockRepository.EXPECT().ShardAllocation(gomock.Any(), 1, 8).Return(&ticket.Ticket{ID: 1, Shards: 8}, nil).Times(1)
+			_, _ = cache.ShardAllocation(ctx, 1, 8)
+		}},
+		{change: func(ctx context.Context, cache *repository.CachedRepository, mockRepository *mocks.MockRepository) {
+			mockRepository.EXPECT().ReleaseExpiredHolds(gomock.Any(), gomock.Any(), 500).
+				Return(3, []ticket.Ticket{{ID: 1, OrganizerID: 1}}, nil).Times(1)
+			_, _, _ = cache.ReleaseExpiredHolds(context.TODO(), time.Now(), 500)
+		}},
+		{change: func(ctx context.Context, cache *repository.CachedRepository, mockRepository *mocks.MockRepository) {
+			mockRepository.EXPECT().PayOrder(gomock.Any(), 2, gomock.Any()).
+				Return(&ticket.Order{ID: 2, Items: []ticket.OrderItem{{TicketID: 1}}}, nil).Times(1)
+			_, _ = cache.PayOrder(ctx, 2, nil)
+		}},
+		{change: func(ctx context.Context, cache *repository.CachedRepository, mockRepository *mocks.MockRepository) {
+			mockRepository.EXPECT().ExpirePendingOrders(gomock.Any(), gomock.Any(), 100).
+				Return(1, []ticket.Ticket{{ID: 1, OrganizerID: 1}}, nil).Times(1)
+			_, _, _ = cache.ExpirePendingOrders(context.TODO(), time.Now(), 100)
+		}},
+	}
+
+	for _, test := range testCases {
+		// Given
+		ctx := tenant.WithOrganizer(context.TODO(), 1)
+
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		gomock.InOrder(
+			mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 10}, nil).Times(1),
+			mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 8}, nil).Times(1),
+		)
+
+		cache := repository.NewCachedRepository(mockRepository, time.Minute)
+		_, err := cache.GetTicket(ctx, 1)
+		assert.Nil(t, err)
+
+		// When
+		test.change(ctx, cache, mockRepository)
+		option, err := cache.GetTicket(ctx, 1)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, 8, option.Available)
+	}
+}
+
+func Test_Should_Load_Ticket_Option_Again_When_It_Expired(t *testing.T) {
+	// Given
+	ctx := tenant.WithOrganizer(context.TODO(), 1)
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 10}, nil).Times(2)
+
+	cache := repository.NewCachedRepository(mockRepository, time.Millisecond)
+	_, err := cache.GetTicket(ctx, 1)
+	assert.Nil(t, err)
+
+	// When
+	time.Sleep(5 * time.Millisecond)
+	_, err = cache.GetTicket(ctx, 1)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func Test_Should_Cache_Ticket_Options_Per_Organizer(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).
+		DoAndReturn(func(ctx context.Context, id int) (*ticket.Ticket, error) {
+			organizerID, _ := tenant.OrganizerFrom(ctx)
+			return &ticket.Ticket{ID: id, OrganizerID: organizerID}, nil
+		}).Times(2)
+
+	cache := repository.NewCachedRepository(mockRepository, time.Minute)
+
+	// When
+	first, firstErr := cache.GetTicket(tenant.WithOrganizer(context.TODO(), 1), 1)
+	second, secondErr := cache.GetTicket(tenant.WithOrganizer(context.TODO(), 2), 1)
+
+	// Then
+	assert.Nil(t, firstErr)
+	assert.Nil(t, secondErr)
+	assert.Equal(t, 1, first.OrganizerID)
+	assert.Equal(t, 2, second.OrganizerID)
+}
+
+func Test_Should_Not_Change_Cached_Ticket_Option_When_Caller_Changes_It(t *testing.T) {
+	// Given
+	ctx := tenant.WithOrganizer(context.TODO(), 1)
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).
+		Return(&ticket.Ticket{ID: 1, Available: 10, PriceTiers: []ticket.PriceTier{{ID: 1, Price: 500}}}, nil).Times(1)
+
+	cache := repository.NewCachedRepository(mockRepository, time.Minute)
+	changed, err := cache.GetTicket(ctx, 1)
+	assert.Nil(t, err)
+
+	// When
+	changed.Available = 0
+	changed.PriceTiers[0].Price = 0
+	option, err := cache.GetTicket(ctx, 1)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 10, option.Available)
+	assert.Equal(t, 500, option.PriceTiers[0].Price)
+}
+
+func Test_Should_Keep_Only_Ticket_Options_Loaded_Lately(t *testing.T) {
+	// Given
+	ctx := tenant.WithOrganizer(context.TODO(), 1)
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().UpdateTicketOption(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&ticket.Ticket{}, nil).Times(100)
+	mockRepository.EXPECT().GetTicket(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id int) (*ticket.Ticket, error) {
+			return &ticket.Ticket{ID: id}, nil
+		}).Times(101)
+
+	cache := repository.NewCachedRepository(mockRepository, time.Millisecond)
+
+	// When
+	for id := 1; id <= 100; id++ {
+		_, _ = cache.UpdateTicketOption(ctx, id, ticket.TicketUpdate{})
+	}
+	forgotten := cache.Entries()
+
+	for id := 1; id <= 100; id++ {
+		_, err := cache.GetTicket(ctx, id)
+		assert.Nil(t, err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	_, err := cache.GetTicket(ctx, 101)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 0, forgotten)
+	assert.Equal(t, 1, cache.Entries())
+}
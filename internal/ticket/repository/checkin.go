@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+// CheckIn marks the ticket with code as used in a single conditional update, so two gates scanning
+// the same code at once cannot both admit it. When nothing was updated the ticket is read back to
+// tell the caller why.
+func (df *DefaultRepository) CheckIn(ctx context.Context, code string, eventID int, gateID string, at time.Time) (*ticket.IssuedTicket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := ticket.IssuedTicket{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&ticket.IssuedTicket{}).Scopes(ofOrganizerTickets(organizerID)).
+			Where("code = ? AND ticket_id = ? AND status = ?", code, eventID, ticket.IssuedTicketValid).
+			Updates(map[string]interface{}{
+				"status":        ticket.IssuedTicketUsed,
+				"checked_in_at": at,
+				"gate_id":       gateID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := tx.Scopes(ofOrganizerTickets(organizerID)).First(&issued, "code = ?", code).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBIssuedTicketNotFound
+			}
+			return err
+		}
+
+		if result.RowsAffected == 1 {
+			return writeAudit(tx, ticket.AuditIssuedTicketCheckedIn, auditTarget("issued_ticket", issued.ID),
+				map[string]interface{}{"status": ticket.IssuedTicketValid},
+				map[string]interface{}{"status": issued.Status, "checked_in_at": at, "gate_id": gateID})
+		}
+
+		switch {
+		case issued.TicketID != eventID:
+			return ErrDBIssuedTicketWrongEvent
+		case issued.Status == ticket.IssuedTicketRefunded:
+			return ErrDBIssuedTicketRefunded
+		default:
+			return ErrDBIssuedTicketAlreadyUsed
+		}
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBIssuedTicketNotFound) && !errors.Is(err, ErrDBIssuedTicketWrongEvent) &&
+			!errors.Is(err, ErrDBIssuedTicketRefunded) && !errors.Is(err, ErrDBIssuedTicketAlreadyUsed) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &issued, nil
+}
+
+func (df *DefaultRepository) GetCheckinStats(ctx context.Context, eventID int) (*ticket.CheckinStats, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := ticket.CheckinStats{EventID: eventID, ByGate: map[string]int{}}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	var rows []struct {
+		Status ticket.IssuedTicketStatus
+		GateID string
+		Count  int
+	}
+
+	err = df.reader().WithContext(timeoutCtx).Model(&ticket.IssuedTicket{}).Scopes(ofOrganizerTickets(organizerID)).
+		Select("status, gate_id, count(*) AS count").
+		Where("ticket_id = ? AND status IN ?", eventID, []ticket.IssuedTicketStatus{ticket.IssuedTicketValid, ticket.IssuedTicketUsed}).
+		Group("status, gate_id").
+		Scan(&rows).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		stats.Issued += row.Count
+		if row.Status == ticket.IssuedTicketUsed {
+			stats.CheckedIn += row.Count
+			stats.ByGate[row.GateID] += row.Count
+		}
+	}
+	stats.NotArrived = stats.Issued - stats.CheckedIn
+
+	return &stats, nil
+}
@@ -0,0 +1,9 @@
+package repository
+
+// Entries returns how many ticket options c keeps.
+func (c *CachedRepository) Entries() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
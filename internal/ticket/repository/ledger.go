@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+func (df *DefaultRepository) ListLedgerEntries(ctx context.Context, filter ticket.LedgerFilter) ([]ticket.LedgerEntry, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ticket.LedgerEntry
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.reader(ticketKey(organizerID, filter.TicketID)).WithContext(timeoutCtx).Scopes(ofOrganizer(organizerID)).
+		Where("ticket_id = ? AND id > ?", filter.TicketID, filter.AfterID).
+		Order("id").Limit(filter.Limit).Find(&entries).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// ReconcileAllocations returns the ticket options whose available tickets, with those of their
+// shards, are not the sum of their ledger entries, or not what is left of their capacity once sold
+// and held tickets are taken off, in id order.
+func (df *DefaultRepository) ReconcileAllocations(ctx context.Context) ([]ticket.AllocationDrift, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []ticket.AllocationDrift
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Raw(`
+SELECT c.* FROM (
+	SELECT t.id AS ticket_id, t.capacity, t.sold + COALESCE(s.sold, 0) AS sold, t.held,
+		t.available + COALESCE(s.available, 0) AS available,
+		(SELECT COALESCE(SUM(l.delta), 0) FROM ledger_entries l WHERE l.ticket_id = t.id) AS ledger
+	FROM tickets t LEFT JOIN (
+		SELECT ticket_id, SUM(available) AS available, SUM(sold) AS sold FROM allocation_shards GROUP BY ticket_id
+	) s ON s.ticket_id = t.id
+	WHERE t.organizer_id = ? AND t.deleted_at IS NULL
+) c
+WHERE c.available <> c.ledger OR c.available <> c.capacity - c.sold - c.held
+ORDER BY c.ticket_id`, organizerID).Scan(&drifts).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return drifts, nil
+}
+
+// ReleaseExpiredHolds gives back to their ticket options up to limit seats whose hold ran out at
+// now, of every organizer, and returns how many it gave back and the ticket options it gave them
+// back to, as they are afterwards. Seats locked by a purchase or hold going on are left for the
+// next call.
+func (df *DefaultRepository) ReleaseExpiredHolds(ctx context.Context, now time.Time, limit int) (int, []ticket.Ticket, error) {
+	released := 0
+	var options []ticket.Ticket
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Second) //nolint:gomnd
+	defer cancel()
+
+	err := df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		var seats []ticket.Seat
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND held_until <= ?", ticket.SeatHeld, now).
+			Order("id").Limit(limit).Find(&seats).Error
+		if err != nil || len(seats) == 0 {
+			return err
+		}
+
+		seatIDs := make([]int, len(seats))
+		perTicket := map[int]int{}
+		for i, seat := range seats {
+			seatIDs[i] = seat.ID
+			perTicket[seat.TicketID]++
+		}
+
+		err = tx.Model(&ticket.Seat{}).Where("id IN ?", seatIDs).
+			Updates(map[string]interface{}{"status": ticket.SeatAvailable, "held_by": "", "held_until": nil}).Error
+		if err != nil {
+			return err
+		}
+
+		ticketIDs := make([]int, 0, len(perTicket))
+		for id := range perTicket {
+			ticketIDs = append(ticketIDs, id)
+		}
+		sort.Ints(ticketIDs)
+
+		var owners []ticket.Ticket
+		if err = tx.Select("id", "organizer_id").Where("id IN ?", ticketIDs).Order("id").Find(&owners).Error; err != nil {
+			return err
+		}
+
+		for _, option := range owners {
+			err = moveAllocation(tx, ticket.LedgerEntry{
+				OrganizerID: option.OrganizerID,
+				TicketID:    option.ID,
+				Kind:        ticket.LedgerRelease,
+				Delta:       perTicket[option.ID],
+				Reason:      "hold expired",
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if options, err = releasedTo(tx, ticketIDs); err != nil {
+			return err
+		}
+
+		released = len(seats)
+
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return 0, nil, err
+	}
+
+	return released, options, nil
+}
+
+// releasedTo reads the ticket options ids, of any organizer, as they are after tickets were given
+// back to them.
+func releasedTo(tx *gorm.DB, ids []int) ([]ticket.Ticket, error) {
+	var options []ticket.Ticket
+	if err := tx.Where("id IN ?", ids).Order("id").Find(&options).Error; err != nil {
+		return nil, err
+	}
+
+	sharded := make([]*ticket.Ticket, len(options))
+	for i := range options {
+		sharded[i] = &options[i]
+	}
+
+	if err := addShards(tx, sharded...); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// moveAllocation changes the counters of the ticket option of entry by entry.Delta and records
+// entry in the ledger, so that they move together.
+func moveAllocation(tx *gorm.DB, entry ticket.LedgerEntry) error {
+	if err := moveCounters(tx, entry); err != nil {
+		return err
+	}
+
+	return writeLedger(tx, entry)
+}
+
+// moveCounters changes the available tickets of the ticket option of entry by entry.Delta, and
+// its capacity, sold or held tickets as the kind of entry says, without recording entry. It takes
+// tickets only when that many are available, whatever was read before, and returns
+// ErrDBNotEnoughAllocation otherwise.
+func moveCounters(tx *gorm.DB, entry ticket.LedgerEntry) error {
+	counter, sign := ledgerCounter(entry.Kind)
+
+	query := tx.Model(ticket.Ticket{}).Scopes(ofOrganizer(entry.OrganizerID)).Where("id = ?", entry.TicketID)
+	if entry.Delta < 0 {
+		query = query.Where("available >= ?", -entry.Delta)
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"available": gorm.Expr("available + ?", entry.Delta),
+		counter:     gorm.Expr(counter+" + ?", sign*entry.Delta),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		if entry.Delta >= 0 {
+			return ErrDBTicketNotFound
+		}
+
+		var found int64
+		err := tx.Model(ticket.Ticket{}).Scopes(ofOrganizer(entry.OrganizerID)).Where("id = ?", entry.TicketID).
+			Count(&found).Error
+		if err != nil {
+			return err
+		}
+
+		if found == 0 {
+			return ErrDBTicketNotFound
+		}
+
+		return ErrDBNotEnoughAllocation
+	}
+
+	return nil
+}
+
+// ledgerCounter returns the column of tickets an entry of kind moves, and whether it moves it
+// with the available tickets (1) or against them (-1).
+func ledgerCounter(kind ticket.LedgerKind) (string, int) {
+	switch kind {
+	case ticket.LedgerPurchase, ticket.LedgerRefund:
+		return "sold", -1
+	case ticket.LedgerHold, ticket.LedgerRelease:
+		return "held", -1
+	default:
+		return "capacity", 1
+	}
+}
+
+// writeLedger appends entry to the ledger using tx, for changes of the allocation made otherwise.
+func writeLedger(tx *gorm.DB, entry ticket.LedgerEntry) error {
+	entry.CreatedAt = time.Now()
+
+	return tx.Create(&entry).Error
+}
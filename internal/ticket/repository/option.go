@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+// UpdateTicketOption applies the fields of update that are set to the ticket option id.
+func (df *DefaultRepository) UpdateTicketOption(ctx context.Context, id int, update ticket.TicketUpdate) (*ticket.Ticket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	option := ticket.Ticket{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTicketOption(tx, organizerID, id, &option); err != nil {
+			return err
+		}
+
+		before := option
+		changes := map[string]interface{}{}
+		if update.Name != nil {
+			option.Name, changes["name"] = *update.Name, *update.Name
+		}
+		if update.Desc != nil {
+			option.Desc, changes["desc"] = *update.Desc, *update.Desc
+		}
+		if update.Price != nil {
+			option.Price, changes["price"] = *update.Price, *update.Price
+		}
+		if update.StartsAt != nil {
+			option.StartsAt, changes["starts_at"] = update.StartsAt, *update.StartsAt
+		}
+		if update.SaleStartsAt != nil {
+			option.SaleStartsAt, changes["sale_starts_at"] = update.SaleStartsAt, *update.SaleStartsAt
+		}
+		if update.SaleEndsAt != nil {
+			option.SaleEndsAt, changes["sale_ends_at"] = update.SaleEndsAt, *update.SaleEndsAt
+		}
+
+		if len(changes) == 0 {
+			return addShards(tx, &option)
+		}
+
+		if err := tx.Model(&option).Updates(changes).Error; err != nil {
+			return err
+		}
+
+		if err := writeAudit(tx, ticket.AuditTicketOptionUpdated, auditTarget("ticket_option", id), before, option); err != nil {
+			return err
+		}
+
+		return addShards(tx, &option)
+	})
+	if err != nil {
+		if isUniqueViolation(err, "idx_tickets_organizer_name") {
+			return nil, ErrDBDuplicatedTicketName
+		}
+		if !errors.Is(err, ErrDBTicketNotFound) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+// AdjustAllocation adds delta, which may be negative, to the allocation of the ticket option id.
+// reason is kept with the change in the audit log.
+func (df *DefaultRepository) AdjustAllocation(ctx context.Context, id, delta int, reason string) (*ticket.Ticket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	option := ticket.Ticket{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTicketOption(tx, organizerID, id, &option); err != nil {
+			return err
+		}
+
+		if option.Seated {
+			return ErrDBTicketOptionIsSeated
+		}
+
+		if err := foldShards(tx, &option); err != nil {
+			return err
+		}
+
+		if option.Available+delta < 0 {
+			return ErrDBNotEnoughAllocation
+		}
+
+		before := option
+		option.Capacity += delta
+		option.Available += delta
+		err := moveAllocation(tx, ticket.LedgerEntry{
+			OrganizerID: organizerID,
+			TicketID:    id,
+			Kind:        ticket.LedgerAdjustment,
+			Delta:       delta,
+			Reason:      reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		if option.Shards > 0 {
+			if err = spreadShards(tx, &option); err != nil {
+				return err
+			}
+		}
+
+		return writeAudit(tx, ticket.AuditAllocationAdjusted, auditTarget("ticket_option", id),
+			map[string]interface{}{"capacity": before.Capacity, "available": before.Available},
+			map[string]interface{}{"capacity": option.Capacity, "available": option.Available, "reason": reason})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBTicketNotFound) && !errors.Is(err, ErrDBTicketOptionIsSeated) &&
+			!errors.Is(err, ErrDBNotEnoughAllocation) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+func lockTicketOption(tx *gorm.DB, organizerID, id int, option *ticket.Ticket) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizer(organizerID)).First(option, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrDBTicketNotFound
+	}
+
+	return err
+}
+
+// BulkRowError tells which of the rows of a bulk insert, numbered from 0, could not be inserted.
+type BulkRowError struct {
+	Row int
+	Err error
+}
+
+func (e *BulkRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *BulkRowError) Unwrap() error {
+	return e.Err
+}
+
+// CreateTicketOptions creates a ticket option of each of drafts in one transaction, so that either
+// all of them are created or, returning a BulkRowError for the first that fails, none of them.
+func (df *DefaultRepository) CreateTicketOptions(ctx context.Context, drafts []ticket.TicketDraft) ([]ticket.Ticket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]ticket.Ticket, len(drafts))
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		for i, draft := range drafts {
+			options[i] = ticket.Ticket{
+				OrganizerID:  organizerID,
+				Name:         draft.Name,
+				Desc:         draft.Desc,
+				Capacity:     draft.Allocation,
+				Available:    draft.Allocation,
+				Price:        draft.Price,
+				StartsAt:     draft.StartsAt,
+				SaleStartsAt: draft.SaleStartsAt,
+				SaleEndsAt:   draft.SaleEndsAt,
+			}
+
+			if err := insertTicketOption(tx, &options[i]); err != nil {
+				if isUniqueViolation(err, "idx_tickets_organizer_name") {
+					return &BulkRowError{Row: i, Err: ErrDBDuplicatedTicketName}
+				}
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBDuplicatedTicketName) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return options, nil
+}
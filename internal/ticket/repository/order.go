@@ -0,0 +1,456 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+var (
+	ErrDBOrderNotFound          = errors.New("order not found")
+	ErrDBOrderStatusConflict    = errors.New("order cannot change to that status")
+	ErrDBTicketOptionIsSeated   = errors.New("ticket option sells seats")
+	ErrDBNotEnoughAllocation    = errors.New("not enough tickets left")
+	ErrDBPurchaseInOrder        = errors.New("purchase is an item of an order")
+	errDBOrderItemCodesNotGiven = errors.New("codes were not given for every ticket of the order")
+)
+
+// CreateOrder places a pending order of items for userID, to be paid before expiresAt. The ticket
+// options of all items are locked in id order, so that concurrent orders cannot deadlock, and either
+// every item is held off its allocation or none is. quotes prices each item by its ticket id once its
+// ticket option is locked.
+func (df *DefaultRepository) CreateOrder(ctx context.Context, userID string, items []ticket.OrderItem,
+	quotes map[int]ticket.PriceQuoter, expiresAt time.Time) (*ticket.Order, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var order ticket.Order
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.TicketID
+	}
+	sort.Ints(ids)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = transactWithRetry(df.database.WithContext(timeoutCtx), func(tx *gorm.DB) error {
+		order = ticket.Order{OrganizerID: organizerID, UserID: userID, Status: ticket.OrderPending, ExpiresAt: &expiresAt}
+
+		var options []ticket.Ticket
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizer(organizerID)).
+			Where("id IN ?", ids).Order("id").Find(&options).Error
+		if err != nil {
+			return err
+		}
+
+		if len(options) != len(ids) {
+			return ErrDBTicketNotFound
+		}
+
+		sharded := make([]*ticket.Ticket, len(options))
+		for i := range options {
+			sharded[i] = &options[i]
+		}
+
+		if err = addShards(tx, sharded...); err != nil {
+			return err
+		}
+
+		allocations := make(map[int]ticket.Ticket, len(options))
+		for _, option := range options {
+			allocations[option.ID] = option
+		}
+
+		for _, item := range items {
+			option := allocations[item.TicketID]
+			if option.Seated {
+				return ErrDBTicketOptionIsSeated
+			}
+			if option.Available < item.Quantity {
+				return ErrDBNotEnoughAllocation
+			}
+		}
+
+		if err = tx.Create(&order).Error; err != nil {
+			return err
+		}
+
+		order.Items = make([]ticket.OrderItem, len(items))
+		for i, item := range items {
+			orderID := order.ID
+			purchase := ticket.Purchase{
+				OrganizerID: organizerID,
+				UserID:      userID,
+				TicketID:    item.TicketID,
+				Quantity:    item.Quantity,
+				OrderID:     &orderID,
+			}
+			if err = holdPurchase(tx, &purchase, quotes[item.TicketID]); err != nil {
+				return err
+			}
+
+			order.Items[i] = ticket.OrderItem{
+				OrderID:    order.ID,
+				TicketID:   item.TicketID,
+				Quantity:   item.Quantity,
+				TotalPrice: purchase.TotalPrice,
+				PurchaseID: purchase.ID,
+			}
+			order.TotalPrice += purchase.TotalPrice
+		}
+
+		if err = tx.Create(&order.Items).Error; err != nil {
+			return err
+		}
+
+		if err = tx.Model(&order).Update("total_price", order.TotalPrice).Error; err != nil {
+			return err
+		}
+
+		if err = writeAudit(tx, ticket.AuditOrderCreated, auditTarget("order", order.ID), nil, order); err != nil {
+			return err
+		}
+
+		return writeEvent(tx, order.OrganizerID, ticket.EventOrderStatusChanged, ticket.OrderStatusChangedPayload{
+			OrderID:    order.ID,
+			UserID:     order.UserID,
+			To:         order.Status,
+			TotalPrice: order.TotalPrice,
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBTicketNotFound) && !errors.Is(err, ErrDBTicketOptionIsSeated) &&
+			!errors.Is(err, ErrDBNotEnoughAllocation) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	written := []writeKey{userKey(organizerID, userID)}
+	for _, id := range ids {
+		written = append(written, ticketKey(organizerID, id))
+	}
+	df.wrote(written...)
+
+	return &order, nil
+}
+
+func (df *DefaultRepository) GetOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order := ticket.Order{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Scopes(ofOrganizer(organizerID)).Preload("Items", orderByID).
+		First(&order, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDBOrderNotFound
+		}
+
+		log.Error(err)
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// PayOrder marks a pending order as paid, sells the tickets held for it and issues them,
+// codes[item.ID] holding one code per ticket of each item.
+func (df *DefaultRepository) PayOrder(ctx context.Context, id int, codes map[int][]string) (*ticket.Order, error) {
+	return df.changeOrderStatus(ctx, id, ticket.OrderPaid, func(tx *gorm.DB, order *ticket.Order) error {
+		var issued []ticket.IssuedTicket
+		for _, item := range order.Items {
+			if len(codes[item.ID]) != item.Quantity {
+				return errDBOrderItemCodesNotGiven
+			}
+
+			if err := sellHeldItem(tx, order, item); err != nil {
+				return err
+			}
+
+			for _, code := range codes[item.ID] {
+				issued = append(issued, ticket.IssuedTicket{
+					Code:       code,
+					PurchaseID: item.PurchaseID,
+					TicketID:   item.TicketID,
+					UserID:     order.UserID,
+					Status:     ticket.IssuedTicketValid,
+				})
+			}
+		}
+
+		return tx.Create(&issued).Error
+	})
+}
+
+// CancelOrder gives the tickets held for a pending order back to their allocations.
+func (df *DefaultRepository) CancelOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	return df.changeOrderStatus(ctx, id, ticket.OrderCancelled, releaseOrderItems("order cancelled"))
+}
+
+// RefundOrder gives the tickets of a paid order back to their allocations and voids them.
+func (df *DefaultRepository) RefundOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	return df.changeOrderStatus(ctx, id, ticket.OrderRefunded, refundOrderItems)
+}
+
+// changeOrderStatus locks the order, checks that it can move to status and applies the change
+// in the same transaction.
+func (df *DefaultRepository) changeOrderStatus(ctx context.Context, id int, status ticket.OrderStatus,
+	apply func(tx *gorm.DB, order *ticket.Order) error) (*ticket.Order, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order := ticket.Order{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizer(organizerID)).
+			First(&order, "id = ?", id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBOrderNotFound
+			}
+			return err
+		}
+
+		if err = tx.Order("id").Where("order_id = ?", order.ID).Find(&order.Items).Error; err != nil {
+			return err
+		}
+
+		return moveOrder(tx, &order, status, apply)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBOrderNotFound) && !errors.Is(err, ErrDBOrderStatusConflict) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// ExpirePendingOrders cancels up to limit pending orders, of every organizer, that were not paid by
+// now, gives the tickets held for them back, and returns how many it cancelled and the ticket
+// options it gave tickets back to, as they are afterwards. Orders being paid or cancelled meanwhile
+// are left for the next call.
+func (df *DefaultRepository) ExpirePendingOrders(ctx context.Context, now time.Time, limit int) (int, []ticket.Ticket, error) {
+	expired := 0
+	var options []ticket.Ticket
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Second) //nolint:gomnd
+	defer cancel()
+
+	err := transactWithRetry(df.database.WithContext(timeoutCtx), func(tx *gorm.DB) error {
+		expired, options = 0, nil
+
+		var orders []ticket.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND expires_at <= ?", ticket.OrderPending, now).
+			Order("id").Limit(limit).Find(&orders).Error
+		if err != nil || len(orders) == 0 {
+			return err
+		}
+
+		var ticketIDs []int
+		for i := range orders {
+			order := &orders[i]
+			if err = tx.Order("id").Where("order_id = ?", order.ID).Find(&order.Items).Error; err != nil {
+				return err
+			}
+
+			if err = moveOrder(tx, order, ticket.OrderCancelled, releaseOrderItems("order expired")); err != nil {
+				return err
+			}
+
+			ticketIDs = append(ticketIDs, orderTicketIDs(order.Items)...)
+		}
+
+		if options, err = releasedTo(tx, ticketIDs); err != nil {
+			return err
+		}
+
+		expired = len(orders)
+
+		return nil
+	})
+	if err != nil {
+		log.Error(err)
+		return 0, nil, err
+	}
+
+	return expired, options, nil
+}
+
+// moveOrder checks that order, which must be locked and have its items, can move to status, applies
+// the change to its items and records it.
+func moveOrder(tx *gorm.DB, order *ticket.Order, status ticket.OrderStatus,
+	apply func(tx *gorm.DB, order *ticket.Order) error) error {
+	if !order.Status.CanBecome(status) {
+		return ErrDBOrderStatusConflict
+	}
+
+	if err := apply(tx, order); err != nil {
+		return err
+	}
+
+	before := *order
+	before.Items = nil
+
+	from, now := order.Status, time.Now()
+	order.Status = status
+	changes := map[string]interface{}{"status": status}
+	switch status {
+	case ticket.OrderPaid:
+		order.PaidAt = &now
+		changes["paid_at"] = now
+	case ticket.OrderCancelled:
+		order.CancelledAt = &now
+		changes["cancelled_at"] = now
+	case ticket.OrderRefunded:
+		order.RefundedAt = &now
+		changes["refunded_at"] = now
+	}
+
+	if err := tx.Model(order).Updates(changes).Error; err != nil {
+		return err
+	}
+
+	after := *order
+	after.Items = nil
+	if err := writeAudit(tx, ticket.AuditOrderStatusChanged, auditTarget("order", order.ID), before, after); err != nil {
+		return err
+	}
+
+	return writeEvent(tx, order.OrganizerID, ticket.EventOrderStatusChanged, ticket.OrderStatusChangedPayload{
+		OrderID:    order.ID,
+		UserID:     order.UserID,
+		From:       from,
+		To:         order.Status,
+		TotalPrice: order.TotalPrice,
+	})
+}
+
+// sellHeldItem sells the tickets held for item of order as they are paid for.
+func sellHeldItem(tx *gorm.DB, order *ticket.Order, item ticket.OrderItem) error {
+	err := moveAllocation(tx, ticket.LedgerEntry{
+		OrganizerID: order.OrganizerID,
+		TicketID:    item.TicketID,
+		Kind:        ticket.LedgerRelease,
+		Delta:       item.Quantity,
+		PurchaseID:  &item.PurchaseID,
+		Reason:      "order paid",
+	})
+	if err != nil {
+		return err
+	}
+
+	err = moveAllocation(tx, ticket.LedgerEntry{
+		OrganizerID: order.OrganizerID,
+		TicketID:    item.TicketID,
+		Kind:        ticket.LedgerPurchase,
+		Delta:       -item.Quantity,
+		PurchaseID:  &item.PurchaseID,
+	})
+	if err != nil {
+		return err
+	}
+
+	remaining, err := remainingAllocation(tx, item.TicketID)
+	if err != nil {
+		return err
+	}
+
+	return writeEvent(tx, order.OrganizerID, ticket.EventTicketPurchased, ticket.TicketPurchasedPayload{
+		PurchaseID: item.PurchaseID,
+		TicketID:   item.TicketID,
+		UserID:     order.UserID,
+		Quantity:   item.Quantity,
+		Remaining:  remaining,
+	})
+}
+
+// releaseOrderItems gives the tickets held for the items of a pending order back to their
+// allocations for reason, and voids the purchases recording the items.
+func releaseOrderItems(reason string) func(tx *gorm.DB, order *ticket.Order) error {
+	return func(tx *gorm.DB, order *ticket.Order) error {
+		for _, item := range order.Items {
+			purchase := ticket.Purchase{}
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&purchase, "id = ?", item.PurchaseID).Error
+			if err != nil {
+				return err
+			}
+
+			if purchase.RefundedAt != nil {
+				continue
+			}
+
+			before := purchase
+
+			now := time.Now()
+			purchase.RefundedAt = &now
+			if err = tx.Model(&purchase).Update("refunded_at", now).Error; err != nil {
+				return err
+			}
+
+			err = writeAudit(tx, ticket.AuditPurchaseRefunded, auditTarget("purchase", purchase.ID), before, purchase)
+			if err != nil {
+				return err
+			}
+
+			err = moveAllocation(tx, ticket.LedgerEntry{
+				OrganizerID: purchase.OrganizerID,
+				TicketID:    purchase.TicketID,
+				Kind:        ticket.LedgerRelease,
+				Delta:       purchase.Quantity,
+				PurchaseID:  &purchase.ID,
+				Reason:      reason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func refundOrderItems(tx *gorm.DB, order *ticket.Order) error {
+	for _, item := range order.Items {
+		purchase := ticket.Purchase{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&purchase, "id = ?", item.PurchaseID).Error
+		if err != nil {
+			return err
+		}
+
+		if purchase.RefundedAt != nil {
+			continue
+		}
+
+		if err = refundPurchase(tx, &purchase); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
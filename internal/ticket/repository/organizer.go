@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+)
+
+var (
+	ErrDBOrganizerNotFound       = errors.New("organizer not found")
+	ErrDBOrganizerNotGiven       = errors.New("context does not act for an organizer")
+	ErrDBDuplicatedOrganizerName = errors.New("organizer name exists already")
+)
+
+func (df *DefaultRepository) CreateOrganizer(ctx context.Context, name, tokenHash string) (*ticket.Organizer, error) {
+	organizer := ticket.Organizer{Name: name, TokenHash: tokenHash}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	if err := df.database.WithContext(timeoutCtx).Create(&organizer).Error; err != nil {
+		if isUniqueViolation(err, "idx_organizers_name") {
+			return nil, ErrDBDuplicatedOrganizerName
+		}
+		log.Error(err)
+		return nil, err
+	}
+
+	return &organizer, nil
+}
+
+// SetOrganizerTokenHash replaces the hash of the token the organizer with id authenticates with, so
+// that its earlier token no longer does.
+func (df *DefaultRepository) SetOrganizerTokenHash(ctx context.Context, id int, tokenHash string) (*ticket.Organizer, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	result := df.database.WithContext(timeoutCtx).Model(&ticket.Organizer{}).Where("id = ?", id).
+		Update("token_hash", tokenHash)
+	if result.Error != nil {
+		log.Error(result.Error)
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, ErrDBOrganizerNotFound
+	}
+
+	return df.GetOrganizer(ctx, id)
+}
+
+func (df *DefaultRepository) GetOrganizer(ctx context.Context, id int) (*ticket.Organizer, error) {
+	return df.getOrganizer(ctx, "id = ?", id)
+}
+
+func (df *DefaultRepository) GetOrganizerByTokenHash(ctx context.Context, tokenHash string) (*ticket.Organizer, error) {
+	return df.getOrganizer(ctx, "token_hash = ? AND token_hash <> ''", tokenHash)
+}
+
+func (df *DefaultRepository) getOrganizer(ctx context.Context, query string, args ...interface{}) (*ticket.Organizer, error) {
+	organizer := ticket.Organizer{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	if err := df.database.WithContext(timeoutCtx).Where(query, args...).First(&organizer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDBOrganizerNotFound
+		}
+
+		log.Error(err)
+		return nil, err
+	}
+
+	return &organizer, nil
+}
+
+// organizerOf returns the organizer ctx acts for. Every query of DefaultRepository is scoped by it.
+func organizerOf(ctx context.Context) (int, error) {
+	organizerID, ok := tenant.OrganizerFrom(ctx)
+	if !ok {
+		log.Error(ErrDBOrganizerNotGiven)
+		return 0, ErrDBOrganizerNotGiven
+	}
+
+	return organizerID, nil
+}
+
+// ofOrganizer keeps the rows of tables with an organizer_id column owned by organizerID.
+func ofOrganizer(organizerID int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("organizer_id = ?", organizerID)
+	}
+}
+
+// ofOrganizerSubscriptions keeps the rows of tables with a subscription_id column whose webhook
+// subscription is owned by organizerID.
+func ofOrganizerSubscriptions(organizerID int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("subscription_id IN (SELECT id FROM webhook_subscriptions WHERE organizer_id = ?)", organizerID)
+	}
+}
+
+// ofOrganizerTickets keeps the rows of tables with a ticket_id column whose ticket option is owned
+// by organizerID.
+func ofOrganizerTickets(organizerID int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("ticket_id IN (SELECT id FROM tickets WHERE organizer_id = ?)", organizerID)
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+type OutboxRepository interface {
+	FetchPendingEvents(ctx context.Context, now time.Time, limit int) ([]ticket.OutboxMessage, error)
+	MarkEventPublished(ctx context.Context, id int, publishedAt time.Time) error
+	MarkEventFailed(ctx context.Context, id int, attempts int, nextAttemptAt time.Time, lastErr string, dead bool) error
+}
+
+type DefaultOutboxRepository struct {
+	database *gorm.DB
+}
+
+func NewDefaultOutboxRepository(database *gorm.DB) *DefaultOutboxRepository {
+	return &DefaultOutboxRepository{
+		database: database,
+	}
+}
+
+func (r *DefaultOutboxRepository) FetchPendingEvents(ctx context.Context, now time.Time, limit int) ([]ticket.OutboxMessage, error) {
+	var messages []ticket.OutboxMessage
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err := r.database.WithContext(timeoutCtx).
+		Where("status = ? AND next_attempt_at <= ?", ticket.OutboxStatusPending, now).
+		Order("id").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *DefaultOutboxRepository) MarkEventPublished(ctx context.Context, id int, publishedAt time.Time) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	return r.database.WithContext(timeoutCtx).Model(&ticket.OutboxMessage{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       ticket.OutboxStatusPublished,
+			"published_at": publishedAt,
+		}).Error
+}
+
+func (r *DefaultOutboxRepository) MarkEventFailed(ctx context.Context, id int, attempts int, nextAttemptAt time.Time, lastErr string, dead bool) error {
+	status := ticket.OutboxStatusPending
+	if dead {
+		status = ticket.OutboxStatusDead
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	return r.database.WithContext(timeoutCtx).Model(&ticket.OutboxMessage{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"attempts":        attempts,
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastErr,
+		}).Error
+}
+
+// writeEvent appends an event of organizerID to the outbox using tx, so it is committed or rolled
+// back together with the change that raised it.
+func writeEvent(tx *gorm.DB, organizerID int, eventType ticket.EventType, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	message := ticket.OutboxMessage{
+		OrganizerID:   organizerID,
+		EventType:     eventType,
+		Payload:       body,
+		Status:        ticket.OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	return tx.Create(&message).Error
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+func (df *DefaultRepository) CreatePriceTier(ctx context.Context, tier ticket.PriceTier) (*ticket.PriceTier, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&ticket.Ticket{}).Scopes(ofOrganizer(organizerID)).Where("id = ?", tier.TicketID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return ErrDBTicketNotFound
+		}
+
+		if err = tx.Create(&tier).Error; err != nil {
+			return err
+		}
+
+		return writeAudit(tx, ticket.AuditPriceTierCreated, auditTarget("price_tier", tier.ID), nil, tier)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBTicketNotFound) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &tier, nil
+}
+
+func (df *DefaultRepository) DeletePriceTier(ctx context.Context, ticketID, tierID int) error {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		tier := ticket.PriceTier{}
+		if err := tx.Scopes(ofOrganizerTickets(organizerID)).First(&tier, "id = ? AND ticket_id = ?", tierID, ticketID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBPriceTierNotFound
+			}
+			return err
+		}
+
+		if err := tx.Delete(&tier).Error; err != nil {
+			return err
+		}
+
+		return writeAudit(tx, ticket.AuditPriceTierDeleted, auditTarget("price_tier", tier.ID), tier, nil)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBPriceTierNotFound) {
+			log.Error(err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+func (df *DefaultRepository) GetPurchase(ctx context.Context, id int) (*ticket.Purchase, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	purchase := ticket.Purchase{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Scopes(ofOrganizer(organizerID)).Preload("IssuedTickets", orderByID).
+		First(&purchase, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDBPurchaseNotFound
+		}
+
+		log.Error(err)
+		return nil, err
+	}
+
+	return &purchase, nil
+}
+
+func (df *DefaultRepository) ListPurchases(ctx context.Context, filter ticket.PurchaseFilter) ([]ticket.Purchase, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var purchases []ticket.Purchase
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	// Buyers listing their purchases, or those of a ticket option, see the ones they just made.
+	var written []writeKey
+	if filter.UserID != "" {
+		written = append(written, userKey(organizerID, filter.UserID))
+	}
+	if filter.TicketID > 0 {
+		written = append(written, ticketKey(organizerID, filter.TicketID))
+	}
+
+	query := df.reader(written...).WithContext(timeoutCtx).Scopes(ofOrganizer(organizerID)).Where("id > ?", filter.AfterID)
+
+	if filter.TicketID > 0 {
+		query = query.Where("ticket_id = ?", filter.TicketID)
+	}
+
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+
+	if err = query.Order("id").Limit(filter.Limit).Find(&purchases).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return purchases, nil
+}
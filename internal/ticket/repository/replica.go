@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultReadYourWritesWindow is how long reads of what a purchase changed go to the primary after it.
+const DefaultReadYourWritesWindow = 5 * time.Second
+
+// ReadReplicas gives the database to make the reads that can do with a moment old data on.
+type ReadReplicas interface {
+	Reader() *gorm.DB
+}
+
+type Option func(df *DefaultRepository)
+
+// WithReadReplicas makes GetTicket, and the queries listing or reporting on many rows, on replicas.
+// Writes, and reads made within a transaction, are made on the primary.
+func WithReadReplicas(replicas ReadReplicas) Option {
+	return func(df *DefaultRepository) {
+		df.replicas = replicas
+	}
+}
+
+// WithReadYourWrites reads a ticket option, and the purchases of a user, from the primary for
+// window after they were purchased or refunded through the repository, so that buyers see what
+// they bought while replicas catch up.
+func WithReadYourWrites(window time.Duration) Option {
+	return func(df *DefaultRepository) {
+		df.writes = &recentWrites{window: window, now: time.Now, at: map[writeKey]time.Time{}}
+	}
+}
+
+// writeKey is a ticket option or a user of an organizer, whichever is set.
+type writeKey struct {
+	organizerID int
+	ticketID    int
+	userID      string
+}
+
+func ticketKey(organizerID, ticketID int) writeKey {
+	return writeKey{organizerID: organizerID, ticketID: ticketID}
+}
+
+func userKey(organizerID int, userID string) writeKey {
+	return writeKey{organizerID: organizerID, userID: userID}
+}
+
+// recentWrites remembers what was written within the last window.
+type recentWrites struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu       sync.Mutex
+	at       map[writeKey]time.Time
+	prunedAt time.Time
+}
+
+func (w *recentWrites) wrote(keys ...writeKey) {
+	now := w.now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Writes older than the window are dropped at most once per window, so that remembering one is cheap.
+	if now.Sub(w.prunedAt) > w.window {
+		for key, at := range w.at {
+			if now.Sub(at) > w.window {
+				delete(w.at, key)
+			}
+		}
+		w.prunedAt = now
+	}
+
+	for _, key := range keys {
+		w.at[key] = now
+	}
+}
+
+func (w *recentWrites) recent(keys ...writeKey) bool {
+	now := w.now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, key := range keys {
+		if at, ok := w.at[key]; ok && now.Sub(at) <= w.window {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reader returns the database to read on, which is a replica unless there are none, or what is
+// read was written within the read-your-writes window.
+func (df *DefaultRepository) reader(keys ...writeKey) *gorm.DB {
+	if df.replicas == nil || (df.writes != nil && df.writes.recent(keys...)) {
+		return df.database
+	}
+
+	return df.replicas.Reader()
+}
+
+// wrote remembers the ticket options or users of keys were written, if reads are to see it.
+func (df *DefaultRepository) wrote(keys ...writeKey) {
+	if df.writes != nil {
+		df.writes.wrote(keys...)
+	}
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+// salesEvents has a row for each sale when it was made and another for each refund when it was
+// made, so both can be summed up by when they happened. A purchase is sold when it is made, unless
+// it is the item of an order, which is sold when the order is paid. Items of orders that were
+// never paid sold nothing, so their cancellation is no refund either.
+const salesEvents = `
+SELECT p.ticket_id, COALESCE(o.paid_at, p.created_at) AS at, p.quantity AS sold, p.total_price AS revenue,
+	0 AS refunded, 0 AS refunds
+FROM tickets_purchases p
+LEFT JOIN order_items i ON i.purchase_id = p.id
+LEFT JOIN orders o ON o.id = i.order_id
+WHERE p.deleted_at IS NULL AND (i.id IS NULL OR o.paid_at IS NOT NULL)
+UNION ALL
+SELECT p.ticket_id, p.refunded_at, 0, 0, p.quantity, p.total_price
+FROM tickets_purchases p
+LEFT JOIN order_items i ON i.purchase_id = p.id
+LEFT JOIN orders o ON o.id = i.order_id
+WHERE p.deleted_at IS NULL AND p.refunded_at IS NOT NULL AND (i.id IS NULL OR o.paid_at IS NOT NULL)`
+
+// StreamSalesReport calls emit with each row of the sales report selected by filter, ordered by
+// ticket option and period, without holding the whole report in memory. It stops at the first
+// error emit returns. Ticket options without sales are reported only when not split into periods.
+//
+// On SQLite the report is read in full before any row is emitted, as reading it keeps the single
+// connection from everything else until it is done.
+func (df *DefaultRepository) StreamSalesReport(ctx context.Context, filter ticket.SalesFilter,
+	emit func(ticket.SalesRow) error) error {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second) //nolint:gomnd
+	defer cancel()
+
+	db := df.reader()
+
+	period := "NULL::timestamp"
+	switch filter.Interval {
+	case ticket.SalesByDay:
+		period = "date_trunc('day', e.at AT TIME ZONE 'UTC')"
+	case ticket.SalesByHour:
+		period = "date_trunc('hour', e.at AT TIME ZONE 'UTC')"
+	}
+
+	// SQLite keeps times as text, which strftime reads and writes back in UTC.
+	if db.Dialector.Name() == "sqlite" {
+		period = "NULL"
+		switch filter.Interval {
+		case ticket.SalesByDay:
+			period = "strftime('%Y-%m-%d 00:00:00', e.at)"
+		case ticket.SalesByHour:
+			period = "strftime('%Y-%m-%d %H:00:00', e.at)"
+		}
+	}
+
+	joinOn, args := "e.ticket_id = t.id", []interface{}{}
+	if filter.From != nil {
+		joinOn += " AND e.at >= ?"
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		joinOn += " AND e.at < ?"
+		args = append(args, *filter.To)
+	}
+
+	where := "t.organizer_id = ? AND t.deleted_at IS NULL"
+	args = append(args, organizerID)
+	if filter.TicketID != 0 {
+		where += " AND t.id = ?"
+		args = append(args, filter.TicketID)
+	}
+	if filter.Interval != ticket.SalesTotal {
+		where += " AND e.ticket_id IS NOT NULL"
+	}
+
+	query := `
+SELECT t.id AS ticket_id, t.name AS ticket_name, ` + period + ` AS period,
+	COALESCE(SUM(e.sold), 0) AS sold, COALESCE(SUM(e.revenue), 0) AS revenue,
+	COALESCE(SUM(e.refunded), 0) AS refunded, COALESCE(SUM(e.refunds), 0) AS refunds,
+	t.available + (SELECT COALESCE(SUM(s.available), 0) FROM allocation_shards s WHERE s.ticket_id = t.id) AS remaining,
+	t.capacity
+FROM tickets t LEFT JOIN (` + salesEvents + `) e ON ` + joinOn + `
+WHERE ` + where + `
+GROUP BY t.id, 3
+ORDER BY t.id, 3`
+
+	rows, err := db.WithContext(timeoutCtx).Raw(query, args...).Rows()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	buffered := db.Dialector.Name() == "sqlite"
+	var pending []ticket.SalesRow
+
+	for rows.Next() {
+		row := ticket.SalesRow{}
+		err := rows.Scan(&row.TicketID, &row.TicketName, periodScanner{&row.Period}, &row.Sold, &row.Revenue,
+			&row.Refunded, &row.Refunds, &row.Remaining, &row.Capacity)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+
+		if buffered {
+			pending = append(pending, row)
+			continue
+		}
+
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	if err := rows.Close(); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	for _, row := range pending {
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// periodScanner scans the period of a sales row into period, from the text SQLite gives it as too.
+type periodScanner struct {
+	period **time.Time
+}
+
+func (p periodScanner) Scan(src interface{}) error {
+	var text string
+
+	switch value := src.(type) {
+	case nil:
+		*p.period = nil
+		return nil
+	case time.Time:
+		*p.period = &value
+		return nil
+	case string:
+		text = value
+	case []byte:
+		text = string(value)
+	default:
+		return fmt.Errorf("period cannot be scanned from %T", src)
+	}
+
+	period, err := time.Parse("2006-01-02 15:04:05", text)
+	if err != nil {
+		return err
+	}
+	*p.period = &period
+
+	return nil
+}
@@ -2,69 +2,196 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/labstack/gommon/log"
 
 	"github.com/dilaragorum/ticket-api/internal/ticket"
 )
 
+// uniqueViolation is the SQLSTATE of an insert or update breaking a unique index.
+const uniqueViolation = "23505"
+
+// uniqueColumns are the columns of the unique indexes isUniqueViolation is asked about, which
+// SQLite names in its errors instead of the index.
+var uniqueColumns = map[string]string{
+	"idx_tickets_organizer_name": "tickets.organizer_id, tickets.name",
+	"idx_organizers_name":        "organizers.name",
+}
+
 var (
 	ErrDBTicketNotFound       = errors.New("ticket not found")
-	ErrDBDuplicatedTicketName = errors.New(`pq: duplicate key value violates unique constraint "tickets_name_uindex"`)
+	ErrDBDuplicatedTicketName = errors.New("ticket name exists already for the organizer")
+
+	ErrDBPurchaseNotFound        = errors.New("purchase not found")
+	ErrDBPurchaseAlreadyRefunded = errors.New("purchase already refunded")
+	ErrDBIssuedTicketNotFound    = errors.New("issued ticket not found")
+	ErrDBIssuedTicketAlreadyUsed = errors.New("issued ticket already used")
+	ErrDBIssuedTicketRefunded    = errors.New("issued ticket refunded")
+	ErrDBIssuedTicketWrongEvent  = errors.New("issued ticket belongs to another event")
+	ErrDBIssuedTicketNotOwned    = errors.New("issued ticket belongs to another user")
+	ErrDBPriceTierNotFound       = errors.New("price tier not found")
+
+	ErrDBResaleListingNotFound     = errors.New("resale listing not found")
+	ErrDBResaleListingExists       = errors.New("issued ticket is listed already")
+	ErrDBResaleListingNotAvailable = errors.New("resale listing is not listed")
 )
 
 type Repository interface {
-	CreateTicketOption(ctx context.Context, name, description string, allocation int) (*ticket.Ticket, error)
+	CreateTicketOption(ctx context.Context, name, description string, allocation, price int,
+		startsAt, saleStartsAt, saleEndsAt *time.Time) (*ticket.Ticket, error)
+	CreateTicketOptions(ctx context.Context, drafts []ticket.TicketDraft) ([]ticket.Ticket, error)
 	GetTicket(ctx context.Context, id int) (*ticket.Ticket, error)
-	PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string) error
+	ListTicketOptions(ctx context.Context, filter ticket.TicketFilter) ([]ticket.Ticket, error)
+	UpdateTicketOption(ctx context.Context, id int, update ticket.TicketUpdate) (*ticket.Ticket, error)
+	AdjustAllocation(ctx context.Context, id, delta int, reason string) (*ticket.Ticket, error)
+	ShardAllocation(ctx context.Context, id, shards int) (*ticket.Ticket, error)
+	RebalanceShards(ctx context.Context, id int) (*ticket.Ticket, error)
+	PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string, codes []string,
+		quote ticket.PriceQuoter) (*ticket.Purchase, error)
+	RefundPurchase(ctx context.Context, purchaseID int) (*ticket.Purchase, error)
+	GetPurchase(ctx context.Context, id int) (*ticket.Purchase, error)
+	ListPurchases(ctx context.Context, filter ticket.PurchaseFilter) ([]ticket.Purchase, error)
+	GetPurchaseTickets(ctx context.Context, purchaseID int) ([]ticket.IssuedTicket, error)
+	GetIssuedTicket(ctx context.Context, code string) (*ticket.IssuedTicket, error)
+	CheckIn(ctx context.Context, code string, eventID int, gateID string, at time.Time) (*ticket.IssuedTicket, error)
+	GetCheckinStats(ctx context.Context, eventID int) (*ticket.CheckinStats, error)
+	CountSoldTickets(ctx context.Context, id int) (int, error)
+	CreatePriceTier(ctx context.Context, tier ticket.PriceTier) (*ticket.PriceTier, error)
+	CreateSeatMap(ctx context.Context, ticketID int, seats []ticket.Seat, best ticket.SeatPoint) error
+	HoldSeats(ctx context.Context, ticketID int, seatIDs []int, userID string, now, until time.Time) error
+	GetSeats(ctx context.Context, ticketID int) ([]ticket.Seat, error)
+	PurchaseSeats(ctx context.Context, ticketID int, userID string, seatIDs []int, codes []string,
+		quote ticket.PriceQuoter, now time.Time) (*ticket.Purchase, error)
+	DeletePriceTier(ctx context.Context, ticketID, tierID int) error
+	TransferIssuedTicket(ctx context.Context, code, toUserID, newCode string) (*ticket.IssuedTicket, error)
+	GetIssuedTicketTransfers(ctx context.Context, code string) ([]ticket.TicketTransfer, error)
+	CreateResaleListing(ctx context.Context, code, sellerID string, price int) (*ticket.ResaleListing, error)
+	GetResaleListing(ctx context.Context, id int) (*ticket.ResaleListing, error)
+	ListResaleListings(ctx context.Context, ticketID int) ([]ticket.ResaleListing, error)
+	BuyResaleListing(ctx context.Context, id int, buyerID, newCode string) (*ticket.ResaleListing, error)
+	CancelResaleListing(ctx context.Context, id int, sellerID string) (*ticket.ResaleListing, error)
+	CreateOrder(ctx context.Context, userID string, items []ticket.OrderItem, quotes map[int]ticket.PriceQuoter, expiresAt time.Time) (*ticket.Order, error)
+	GetOrder(ctx context.Context, id int) (*ticket.Order, error)
+	PayOrder(ctx context.Context, id int, codes map[int][]string) (*ticket.Order, error)
+	CancelOrder(ctx context.Context, id int) (*ticket.Order, error)
+	RefundOrder(ctx context.Context, id int) (*ticket.Order, error)
+	ExpirePendingOrders(ctx context.Context, now time.Time, limit int) (int, []ticket.Ticket, error)
+	ListAuditEntries(ctx context.Context, filter ticket.AuditFilter) ([]ticket.AuditEntry, error)
+	StreamSalesReport(ctx context.Context, filter ticket.SalesFilter, emit func(ticket.SalesRow) error) error
+	ListLedgerEntries(ctx context.Context, filter ticket.LedgerFilter) ([]ticket.LedgerEntry, error)
+	ReconcileAllocations(ctx context.Context) ([]ticket.AllocationDrift, error)
+	ReleaseExpiredHolds(ctx context.Context, now time.Time, limit int) (int, []ticket.Ticket, error)
+	CreateOrganizer(ctx context.Context, name, tokenHash string) (*ticket.Organizer, error)
+	SetOrganizerTokenHash(ctx context.Context, id int, tokenHash string) (*ticket.Organizer, error)
+	GetOrganizer(ctx context.Context, id int) (*ticket.Organizer, error)
+	GetOrganizerByTokenHash(ctx context.Context, tokenHash string) (*ticket.Organizer, error)
 }
 
 type DefaultRepository struct {
 	database *gorm.DB
+	replicas ReadReplicas
+	writes   *recentWrites
 }
 
-func NewDefaultRepository(database *gorm.DB) *DefaultRepository {
-	return &DefaultRepository{
+// NewDefaultRepository returns a DefaultRepository making every query on database, which is the
+// primary when read replicas are given with WithReadReplicas.
+func NewDefaultRepository(database *gorm.DB, options ...Option) *DefaultRepository {
+	df := &DefaultRepository{
 		database: database,
 	}
+
+	for _, option := range options {
+		option(df)
+	}
+
+	return df
 }
 
-func (df *DefaultRepository) CreateTicketOption(ctx context.Context, name, description string, allocation int) (*ticket.Ticket, error) {
-	ticket := ticket.Ticket{
-		Name:       name,
-		Desc:       description,
-		Allocation: allocation,
+func (df *DefaultRepository) CreateTicketOption(ctx context.Context, name, description string, allocation, price int,
+	startsAt, saleStartsAt, saleEndsAt *time.Time) (*ticket.Ticket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	option := ticket.Ticket{
+		OrganizerID:  organizerID,
+		Name:         name,
+		Desc:         description,
+		Capacity:     allocation,
+		Available:    allocation,
+		Price:        price,
+		StartsAt:     startsAt,
+		SaleStartsAt: saleStartsAt,
+		SaleEndsAt:   saleEndsAt,
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
 	defer cancel()
 
-	err := df.database.WithContext(timeoutCtx).Model(&ticket).Create(&ticket).Error
-
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		return insertTicketOption(tx, &option)
+	})
 	if err != nil {
-		if err.Error() == ErrDBDuplicatedTicketName.Error() {
+		if isUniqueViolation(err, "idx_tickets_organizer_name") {
 			return nil, ErrDBDuplicatedTicketName
 		}
 		log.Error(err)
 		return nil, err
 	}
 
-	return &ticket, nil
+	return &option, nil
+}
+
+// insertTicketOption inserts option within tx, with its initial ledger entry, audit entry and outbox event.
+func insertTicketOption(tx *gorm.DB, option *ticket.Ticket) error {
+	if err := tx.Model(option).Create(option).Error; err != nil {
+		return err
+	}
+
+	err := writeLedger(tx, ticket.LedgerEntry{
+		OrganizerID: option.OrganizerID,
+		TicketID:    option.ID,
+		Kind:        ticket.LedgerInitial,
+		Delta:       option.Available,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writeAudit(tx, ticket.AuditTicketOptionCreated, auditTarget("ticket_option", option.ID), nil, *option); err != nil {
+		return err
+	}
+
+	return writeEvent(tx, option.OrganizerID, ticket.EventTicketOptionCreated, ticket.TicketOptionCreatedPayload{
+		TicketID:   option.ID,
+		Name:       option.Name,
+		Allocation: option.Available,
+	})
 }
 
 func (df *DefaultRepository) GetTicket(ctx context.Context, id int) (*ticket.Ticket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	ticket := ticket.Ticket{}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
 	defer cancel()
 
-	if err := df.database.WithContext(timeoutCtx).Model(&ticket).First(&ticket, "id = ?", id).Error; err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+	db := df.reader(ticketKey(organizerID, id)).WithContext(timeoutCtx)
+	if err := db.Model(&ticket).Scopes(ofOrganizer(organizerID)).
+		Preload("PriceTiers", orderByID).First(&ticket, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrDBTicketNotFound
 		}
 
@@ -72,47 +199,406 @@ func (df *DefaultRepository) GetTicket(ctx context.Context, id int) (*ticket.Tic
 		return nil, err
 	}
 
+	if err := addShards(db, &ticket); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
 	return &ticket, nil
 }
 
-func (df *DefaultRepository) PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string) error {
+func (df *DefaultRepository) ListTicketOptions(ctx context.Context, filter ticket.TicketFilter) ([]ticket.Ticket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets []ticket.Ticket
+
 	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
 	defer cancel()
 
-	tx := df.database.WithContext(timeoutCtx).Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	db := df.reader().WithContext(timeoutCtx)
+	query := db.
+		Scopes(ofOrganizer(organizerID)).
+		Preload("PriceTiers", orderByID).
+		Where("id > ?", filter.AfterID)
+
+	if filter.OnSale {
+		query = query.
+			Where("sale_starts_at IS NULL OR sale_starts_at <= ?", filter.At).
+			Where("sale_ends_at IS NULL OR sale_ends_at > ?", filter.At)
+	}
+
+	err = query.Order("id").Limit(filter.Limit).Find(&tickets).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	options := make([]*ticket.Ticket, len(tickets))
+	for i := range tickets {
+		options[i] = &tickets[i]
+	}
+
+	if err = addShards(db, options...); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// PurchaseFromTicketOption records the purchase and issues one ticket per code; len(codes) must equal quantity.
+// quote prices the purchase once the ticket option is locked, so concurrent purchases are priced in
+// the order they sell. Purchases of a sharded ticket option only lock a shard, so concurrent ones
+// may be priced as if the others had not sold yet.
+func (df *DefaultRepository) PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string,
+	codes []string, quote ticket.PriceQuoter) (*ticket.Purchase, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var purchase ticket.Purchase
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = transactWithRetry(df.database.WithContext(timeoutCtx), func(tx *gorm.DB) error {
+		purchase = ticket.Purchase{
+			OrganizerID: organizerID,
+			UserID:      userID,
+			TicketID:    id,
+			Quantity:    quantity,
+			Model:       gorm.Model{},
+		}
+
+		return createPurchase(tx, &purchase, codes, nil, quote)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBTicketNotFound) && !errors.Is(err, ErrDBNotEnoughAllocation) {
+			log.Error(err.Error())
+		}
+		return nil, err
+	}
+
+	df.wrote(ticketKey(organizerID, id), userKey(organizerID, userID))
+
+	return &purchase, nil
+}
+
+func (df *DefaultRepository) RefundPurchase(ctx context.Context, purchaseID int) (*ticket.Purchase, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	purchase := ticket.Purchase{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizer(organizerID)).
+			First(&purchase, "id = ?", purchaseID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBPurchaseNotFound
+			}
+			return err
+		}
+
+		if purchase.RefundedAt != nil {
+			return ErrDBPurchaseAlreadyRefunded
+		}
+
+		if purchase.OrderID != nil {
+			return ErrDBPurchaseInOrder
 		}
-	}()
 
-	if err := tx.Error; err != nil {
+		return refundPurchase(tx, &purchase)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBPurchaseNotFound) && !errors.Is(err, ErrDBPurchaseAlreadyRefunded) &&
+			!errors.Is(err, ErrDBPurchaseInOrder) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	df.wrote(ticketKey(organizerID, purchase.TicketID), userKey(organizerID, purchase.UserID))
+
+	return &purchase, nil
+}
+
+func (df *DefaultRepository) GetPurchaseTickets(ctx context.Context, purchaseID int) ([]ticket.IssuedTicket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var issued []ticket.IssuedTicket
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&ticket.Purchase{}).Scopes(ofOrganizer(organizerID)).Where("id = ?", purchaseID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return ErrDBPurchaseNotFound
+		}
+
+		return tx.Where("purchase_id = ?", purchaseID).Order("id").Find(&issued).Error
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBPurchaseNotFound) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return issued, nil
+}
+
+func (df *DefaultRepository) GetIssuedTicket(ctx context.Context, code string) (*ticket.IssuedTicket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := ticket.IssuedTicket{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Scopes(ofOrganizerTickets(organizerID)).First(&issued, "code = ?", code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDBIssuedTicketNotFound
+		}
+
+		log.Error(err)
+		return nil, err
+	}
+
+	return &issued, nil
+}
+
+// createPurchase takes purchase.Quantity tickets off the available tickets of the ticket option, prices them
+// with quote and records purchase with one issued ticket per code. seatIDs, when given, holds the
+// seat of each issued ticket. The ticket option must belong to purchase.OrganizerID.
+func createPurchase(tx *gorm.DB, purchase *ticket.Purchase, codes []string, seatIDs []int, quote ticket.PriceQuoter) error {
+	id, quantity, userID := purchase.TicketID, purchase.Quantity, purchase.UserID
+
+	if err := recordPurchase(tx, purchase, ticket.LedgerPurchase, quote); err != nil {
+		return err
+	}
+
+	issued := make([]ticket.IssuedTicket, len(codes))
+	for i, code := range codes {
+		issued[i] = ticket.IssuedTicket{
+			Code:       code,
+			PurchaseID: purchase.ID,
+			TicketID:   id,
+			UserID:     userID,
+			Status:     ticket.IssuedTicketValid,
+		}
+		if seatIDs != nil {
+			issued[i].SeatID = &seatIDs[i]
+		}
+	}
+
+	if len(issued) > 0 {
+		if err := tx.Create(&issued).Error; err != nil {
+			return err
+		}
+	}
+	purchase.IssuedTickets = issued
+
+	remaining, err := remainingAllocation(tx, id)
+	if err != nil {
 		return err
 	}
 
-	err := tx.Model(ticket.Ticket{}).Where("id = ?", id).
-		Update("allocation", gorm.Expr("allocation - ?", quantity)).Error
+	err = writeEvent(tx, purchase.OrganizerID, ticket.EventTicketPurchased, ticket.TicketPurchasedPayload{
+		PurchaseID: purchase.ID,
+		TicketID:   id,
+		UserID:     userID,
+		Quantity:   quantity,
+		Remaining:  remaining,
+	})
 	if err != nil {
-		log.Error(err.Error())
 		return err
 	}
 
-	purchase := ticket.Purchase{
-		UserID:   userID,
-		TicketID: id,
-		Quantity: quantity,
-		Model:    gorm.Model{},
+	if remaining == 0 {
+		return writeEvent(tx, purchase.OrganizerID, ticket.EventTicketSoldOut, ticket.TicketSoldOutPayload{TicketID: id})
 	}
 
-	if err = tx.Model(&ticket.Purchase{}).Create(&purchase).Error; err != nil {
-		log.Error(err)
+	return nil
+}
+
+// holdPurchase holds purchase.Quantity tickets of the ticket option off its available tickets,
+// prices them with quote and records purchase without issuing any ticket, for a pending order that
+// has yet to be paid. The ticket option must belong to purchase.OrganizerID.
+func holdPurchase(tx *gorm.DB, purchase *ticket.Purchase, quote ticket.PriceQuoter) error {
+	if err := recordPurchase(tx, purchase, ticket.LedgerHold, quote); err != nil {
 		return err
 	}
 
-	if err = tx.Commit().Error; err != nil {
-		log.Error(err.Error())
+	remaining, err := remainingAllocation(tx, purchase.TicketID)
+	if err != nil {
 		return err
 	}
 
+	if remaining == 0 {
+		return writeEvent(tx, purchase.OrganizerID, ticket.EventTicketSoldOut, ticket.TicketSoldOutPayload{TicketID: purchase.TicketID})
+	}
+
 	return nil
 }
+
+// recordPurchase takes the tickets of purchase off the ticket option by an entry of kind, prices
+// them with quote and records purchase and the entry.
+func recordPurchase(tx *gorm.DB, purchase *ticket.Purchase, kind ticket.LedgerKind, quote ticket.PriceQuoter) error {
+	entry := ticket.LedgerEntry{
+		OrganizerID: purchase.OrganizerID,
+		TicketID:    purchase.TicketID,
+		Kind:        kind,
+		Delta:       -purchase.Quantity,
+	}
+	if err := takeTickets(tx, entry); err != nil {
+		return err
+	}
+
+	sold, err := soldTickets(tx, purchase.TicketID)
+	if err != nil {
+		return err
+	}
+	purchase.TotalPrice = quote(sold).Total
+
+	if err = tx.Model(&ticket.Purchase{}).Create(purchase).Error; err != nil {
+		return err
+	}
+
+	entry.PurchaseID = &purchase.ID
+	if err = writeLedger(tx, entry); err != nil {
+		return err
+	}
+
+	return writeAudit(tx, ticket.AuditPurchaseCreated, auditTarget("purchase", purchase.ID), nil, *purchase)
+}
+
+// refundPurchase returns the tickets of purchase, which must be locked and not refunded, to the
+// allocation of its ticket option and voids its issued tickets.
+func refundPurchase(tx *gorm.DB, purchase *ticket.Purchase) error {
+	before := *purchase
+
+	now := time.Now()
+	purchase.RefundedAt = &now
+	if err := tx.Model(purchase).Update("refunded_at", now).Error; err != nil {
+		return err
+	}
+
+	err := writeAudit(tx, ticket.AuditPurchaseRefunded, auditTarget("purchase", purchase.ID), before, *purchase)
+	if err != nil {
+		return err
+	}
+
+	entry := ticket.LedgerEntry{
+		OrganizerID: purchase.OrganizerID,
+		TicketID:    purchase.TicketID,
+		Kind:        ticket.LedgerRefund,
+		Delta:       purchase.Quantity,
+		PurchaseID:  &purchase.ID,
+	}
+	if err = returnTickets(tx, entry); err != nil {
+		return err
+	}
+
+	if err = writeLedger(tx, entry); err != nil {
+		return err
+	}
+
+	err = tx.Model(&ticket.IssuedTicket{}).Where("purchase_id = ?", purchase.ID).
+		Update("status", ticket.IssuedTicketRefunded).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Model(&ticket.Seat{}).Where("purchase_id = ?", purchase.ID).
+		Updates(map[string]interface{}{"status": ticket.SeatAvailable, "purchase_id": nil}).Error
+	if err != nil {
+		return err
+	}
+
+	remaining, err := remainingAllocation(tx, purchase.TicketID)
+	if err != nil {
+		return err
+	}
+
+	return writeEvent(tx, purchase.OrganizerID, ticket.EventPurchaseRefunded, ticket.PurchaseRefundedPayload{
+		PurchaseID: purchase.ID,
+		TicketID:   purchase.TicketID,
+		UserID:     purchase.UserID,
+		Quantity:   purchase.Quantity,
+		Remaining:  remaining,
+	})
+}
+
+// CountSoldTickets returns how many tickets of the ticket option were sold and not refunded.
+func (df *DefaultRepository) CountSoldTickets(ctx context.Context, id int) (int, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	sold, err := soldTickets(df.database.WithContext(timeoutCtx).Scopes(ofOrganizer(organizerID)), id)
+	if err != nil {
+		log.Error(err)
+		return 0, err
+	}
+
+	return sold, nil
+}
+
+func soldTickets(tx *gorm.DB, ticketID int) (int, error) {
+	var sold int
+	err := tx.Model(&ticket.Purchase{}).Select("COALESCE(SUM(quantity), 0)").
+		Where("ticket_id = ? AND refunded_at IS NULL", ticketID).Scan(&sold).Error
+
+	return sold, err
+}
+
+// isUniqueViolation tells whether err was caused by a row breaking the unique index named index.
+func isUniqueViolation(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == uniqueViolation && pgErr.ConstraintName == index
+	}
+
+	return err != nil && uniqueColumns[index] != "" &&
+		strings.Contains(err.Error(), "UNIQUE constraint failed: "+uniqueColumns[index]+" (")
+}
+
+func orderByID(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+func remainingAllocation(tx *gorm.DB, ticketID int) (int, error) {
+	var remaining int
+	err := tx.Model(ticket.Ticket{}).
+		Select("available + (SELECT COALESCE(SUM(s.available), 0) FROM allocation_shards s WHERE s.ticket_id = tickets.id)").
+		Where("id = ?", ticketID).Scan(&remaining).Error
+
+	return remaining, err
+}
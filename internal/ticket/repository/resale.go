@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+// CreateResaleListing lists the ticket with code for resale. The ticket is locked so it cannot be
+// used or handed over by its seller while the listing is written.
+func (df *DefaultRepository) CreateResaleListing(ctx context.Context, code, sellerID string, price int) (*ticket.ResaleListing, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := ticket.ResaleListing{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		issued := ticket.IssuedTicket{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizerTickets(organizerID)).
+			First(&issued, "code = ?", code).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBIssuedTicketNotFound
+			}
+			return err
+		}
+
+		switch {
+		case issued.Status == ticket.IssuedTicketUsed:
+			return ErrDBIssuedTicketAlreadyUsed
+		case issued.Status == ticket.IssuedTicketRefunded:
+			return ErrDBIssuedTicketRefunded
+		case issued.UserID != sellerID:
+			return ErrDBIssuedTicketNotOwned
+		}
+
+		var count int64
+		err = tx.Model(&ticket.ResaleListing{}).
+			Where("issued_ticket_id = ? AND status = ?", issued.ID, ticket.ResaleListingListed).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return ErrDBResaleListingExists
+		}
+
+		listing = ticket.ResaleListing{
+			IssuedTicketID: issued.ID,
+			TicketID:       issued.TicketID,
+			SellerID:       sellerID,
+			Price:          price,
+			Status:         ticket.ResaleListingListed,
+		}
+
+		if err = tx.Create(&listing).Error; err != nil {
+			return err
+		}
+
+		return writeAudit(tx, ticket.AuditResaleListingCreated, auditTarget("resale_listing", listing.ID), nil, listing)
+	})
+	if err != nil {
+		if !isResaleError(err) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+func (df *DefaultRepository) GetResaleListing(ctx context.Context, id int) (*ticket.ResaleListing, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := ticket.ResaleListing{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Scopes(ofOrganizerTickets(organizerID)).Preload("IssuedTicket").
+		First(&listing, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDBResaleListingNotFound
+		}
+
+		log.Error(err)
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+// ListResaleListings returns the listings of a ticket option that can still be bought, cheapest
+// first. Listings whose ticket has since been used or refunded are left out.
+func (df *DefaultRepository) ListResaleListings(ctx context.Context, ticketID int) ([]ticket.ResaleListing, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var listings []ticket.ResaleListing
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.reader().WithContext(timeoutCtx).
+		Joins("JOIN issued_tickets ON issued_tickets.id = resale_listings.issued_ticket_id").
+		Where("resale_listings.ticket_id IN (SELECT id FROM tickets WHERE organizer_id = ?)", organizerID).
+		Where("resale_listings.ticket_id = ? AND resale_listings.status = ? AND issued_tickets.status = ?",
+			ticketID, ticket.ResaleListingListed, ticket.IssuedTicketValid).
+		Order("resale_listings.price, resale_listings.id").
+		Find(&listings).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return listings, nil
+}
+
+// BuyResaleListing sells the listing to buyerID in one transaction: the listing is marked sold, the
+// ticket moves to the buyer under newCode and the sale is recorded for settlement. The ticket
+// option's allocation is not touched.
+func (df *DefaultRepository) BuyResaleListing(ctx context.Context, id int, buyerID, newCode string) (*ticket.ResaleListing, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := ticket.ResaleListing{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizerTickets(organizerID)).
+			First(&listing, "id = ?", id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBResaleListingNotFound
+			}
+			return err
+		}
+
+		if listing.Status != ticket.ResaleListingListed {
+			return ErrDBResaleListingNotAvailable
+		}
+
+		issued := ticket.IssuedTicket{}
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&issued, "id = ?", listing.IssuedTicketID).Error
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case issued.Status == ticket.IssuedTicketUsed:
+			return ErrDBIssuedTicketAlreadyUsed
+		case issued.Status == ticket.IssuedTicketRefunded:
+			return ErrDBIssuedTicketRefunded
+		case issued.UserID != listing.SellerID:
+			return ErrDBResaleListingNotAvailable
+		}
+
+		before := listing
+
+		now := time.Now()
+		listing.Status = ticket.ResaleListingSold
+		listing.BuyerID = buyerID
+		listing.SoldAt = &now
+		err = tx.Model(&listing).Updates(map[string]interface{}{
+			"status":   listing.Status,
+			"buyer_id": buyerID,
+			"sold_at":  now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		err = writeAudit(tx, ticket.AuditResaleListingSold, auditTarget("resale_listing", listing.ID), before, listing)
+		if err != nil {
+			return err
+		}
+
+		if err = transferIssuedTicket(tx, organizerID, &issued, buyerID, newCode); err != nil {
+			return err
+		}
+		listing.IssuedTicket = &issued
+
+		return writeEvent(tx, organizerID, ticket.EventResaleListingSold, ticket.ResaleListingSoldPayload{
+			ListingID:      listing.ID,
+			IssuedTicketID: issued.ID,
+			TicketID:       issued.TicketID,
+			SellerID:       listing.SellerID,
+			BuyerID:        buyerID,
+			Price:          listing.Price,
+		})
+	})
+	if err != nil {
+		if !isResaleError(err) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+func (df *DefaultRepository) CancelResaleListing(ctx context.Context, id int, sellerID string) (*ticket.ResaleListing, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := ticket.ResaleListing{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizerTickets(organizerID)).
+			First(&listing, "id = ?", id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBResaleListingNotFound
+			}
+			return err
+		}
+
+		if listing.SellerID != sellerID {
+			return ErrDBIssuedTicketNotOwned
+		}
+
+		if listing.Status != ticket.ResaleListingListed {
+			return ErrDBResaleListingNotAvailable
+		}
+
+		before := listing
+		listing.Status = ticket.ResaleListingCancelled
+
+		if err = tx.Model(&listing).Update("status", listing.Status).Error; err != nil {
+			return err
+		}
+
+		return writeAudit(tx, ticket.AuditResaleListingCancelled, auditTarget("resale_listing", listing.ID), before, listing)
+	})
+	if err != nil {
+		if !isResaleError(err) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+func isResaleError(err error) bool {
+	switch err {
+	case ErrDBIssuedTicketNotFound, ErrDBIssuedTicketAlreadyUsed, ErrDBIssuedTicketRefunded, ErrDBIssuedTicketNotOwned,
+		ErrDBResaleListingNotFound, ErrDBResaleListingExists, ErrDBResaleListingNotAvailable:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	// serializationFailure and deadlockDetected are the SQLSTATEs of transactions Postgres aborted to
+	// resolve a conflict with another one, which succeed when tried again.
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+
+	// purchaseAttempts is how many times a purchase transaction is tried.
+	purchaseAttempts = 3
+	retryBackoff     = 10 * time.Millisecond
+)
+
+// transactWithRetry runs fn in a transaction on db, and again in a new one, after a backoff, when
+// Postgres aborted it on a serialization failure or a deadlock, or SQLite found the database
+// locked. fn must not depend on what earlier attempts changed outside of the database.
+func transactWithRetry(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	backoff := retryBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := db.Transaction(fn)
+		if attempt == purchaseAttempts || !isRetryable(err) {
+			return err
+		}
+
+		// Jitter keeps the transactions that conflicted from conflicting again.
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff))) //nolint:gosec
+		select {
+		case <-db.Statement.Context.Done():
+			return err
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+	}
+
+	return err != nil && strings.HasPrefix(err.Error(), "database is locked")
+}
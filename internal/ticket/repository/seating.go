@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+var (
+	ErrDBSeatMapExists        = errors.New("ticket option has a seat map already")
+	ErrDBTicketOptionHasSales = errors.New("ticket option has sold tickets already")
+	ErrDBSeatNotFound         = errors.New("seat not found")
+	ErrDBSeatNotAvailable     = errors.New("seat not available")
+)
+
+const seatBatchSize = 1000
+
+// CreateSeatMap gives the ticket option its seats and turns it into a seated ticket option whose
+// allocation is its number of seats. Only ticket options without sales can get a seat map.
+func (df *DefaultRepository) CreateSeatMap(ctx context.Context, ticketID int, seats []ticket.Seat, best ticket.SeatPoint) error {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		option := ticket.Ticket{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizer(organizerID)).
+			First(&option, "id = ?", ticketID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBTicketNotFound
+			}
+			return err
+		}
+
+		if option.Seated {
+			return ErrDBSeatMapExists
+		}
+
+		sold, err := soldTickets(tx, ticketID)
+		if err != nil {
+			return err
+		}
+
+		if sold > 0 {
+			return ErrDBTicketOptionHasSales
+		}
+
+		// Seats are held and sold one by one, so seated ticket options are not sharded.
+		if option.Shards > 0 {
+			if err = setShards(tx, &option, 0); err != nil {
+				return err
+			}
+		}
+
+		if err = tx.CreateInBatches(&seats, seatBatchSize).Error; err != nil {
+			return err
+		}
+
+		before := option
+		err = tx.Model(&option).Updates(map[string]interface{}{
+			"seated":      true,
+			"best_seat_x": best.X,
+			"best_seat_y": best.Y,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		err = moveAllocation(tx, ticket.LedgerEntry{
+			OrganizerID: organizerID,
+			TicketID:    ticketID,
+			Kind:        ticket.LedgerAdjustment,
+			Delta:       len(seats) - before.Available,
+			Reason:      "seat map created",
+		})
+		if err != nil {
+			return err
+		}
+
+		after := before
+		after.Seated, after.Capacity, after.Available = true, before.Capacity+len(seats)-before.Available, len(seats)
+
+		return writeAudit(tx, ticket.AuditSeatMapCreated, auditTarget("ticket_option", ticketID), before, after)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBTicketNotFound) && !errors.Is(err, ErrDBSeatMapExists) &&
+			!errors.Is(err, ErrDBTicketOptionHasSales) {
+			log.Error(err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetSeats returns the seats of the ticket option in layout order.
+func (df *DefaultRepository) GetSeats(ctx context.Context, ticketID int) ([]ticket.Seat, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var seats []ticket.Seat
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Second) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Scopes(ofOrganizerTickets(organizerID)).
+		Where("ticket_id = ?", ticketID).Order("id").Find(&seats).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return seats, nil
+}
+
+// PurchaseSeats buys all of seatIDs or none of them, if any is taken at now. The seats are locked in
+// id order, so two purchases sharing seats cannot deadlock and only the first one to lock them gets
+// them.
+func (df *DefaultRepository) PurchaseSeats(ctx context.Context, ticketID int, userID string, seatIDs []int,
+	codes []string, quote ticket.PriceQuoter, now time.Time) (*ticket.Purchase, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var purchase ticket.Purchase
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = transactWithRetry(df.database.WithContext(timeoutCtx), func(tx *gorm.DB) error {
+		purchase = ticket.Purchase{
+			OrganizerID: organizerID,
+			UserID:      userID,
+			TicketID:    ticketID,
+			Quantity:    len(seatIDs),
+		}
+
+		seats, err := lockSeats(tx, organizerID, ticketID, seatIDs)
+		if err != nil {
+			return err
+		}
+
+		if len(seats) != len(seatIDs) {
+			return ErrDBSeatNotFound
+		}
+
+		for _, seat := range seats {
+			if !seat.AvailableToAt(userID, now) {
+				return ErrDBSeatNotAvailable
+			}
+		}
+
+		if err = releaseHolds(tx, organizerID, ticketID, seats, "seats purchased"); err != nil {
+			return err
+		}
+
+		if err = createPurchase(tx, &purchase, codes, seatIDs, quote); err != nil {
+			return err
+		}
+
+		return tx.Model(&ticket.Seat{}).Where("id IN ?", seatIDs).
+			Updates(map[string]interface{}{
+				"status":      ticket.SeatSold,
+				"purchase_id": purchase.ID,
+				"held_by":     "",
+				"held_until":  nil,
+			}).Error
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBSeatNotFound) && !errors.Is(err, ErrDBSeatNotAvailable) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	df.wrote(ticketKey(organizerID, ticketID), userKey(organizerID, userID))
+
+	return &purchase, nil
+}
+
+// HoldSeats sets all of seatIDs aside for userID until until, or none of them if any is taken at now.
+// Held seats are off the allocation until they are bought or their hold is released.
+func (df *DefaultRepository) HoldSeats(ctx context.Context, ticketID int, seatIDs []int, userID string, now, until time.Time) error {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		seats, err := lockSeats(tx, organizerID, ticketID, seatIDs)
+		if err != nil {
+			return err
+		}
+
+		if len(seats) != len(seatIDs) {
+			return ErrDBSeatNotAvailable
+		}
+
+		for _, seat := range seats {
+			if !seat.AvailableAt(now) {
+				return ErrDBSeatNotAvailable
+			}
+		}
+
+		if err = releaseHolds(tx, organizerID, ticketID, seats, "hold expired"); err != nil {
+			return err
+		}
+
+		err = tx.Model(&ticket.Seat{}).Where("id IN ?", seatIDs).
+			Updates(map[string]interface{}{
+				"status":     ticket.SeatHeld,
+				"held_by":    userID,
+				"held_until": until,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		err = moveAllocation(tx, ticket.LedgerEntry{
+			OrganizerID: organizerID,
+			TicketID:    ticketID,
+			Kind:        ticket.LedgerHold,
+			Delta:       -len(seatIDs),
+		})
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(tx, ticket.AuditSeatsHeld, auditTarget("ticket_option", ticketID), nil, map[string]interface{}{
+			"seat_ids":   seatIDs,
+			"held_by":    userID,
+			"held_until": until,
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBSeatNotAvailable) {
+			log.Error(err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// lockSeats locks the seats of seatIDs that belong to the ticket option, in id order so that two
+// transactions sharing seats cannot deadlock.
+func lockSeats(tx *gorm.DB, organizerID, ticketID int, seatIDs []int) ([]ticket.Seat, error) {
+	var seats []ticket.Seat
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizerTickets(organizerID)).
+		Where("ticket_id = ? AND id IN ?", ticketID, seatIDs).
+		Order("id").
+		Find(&seats).Error
+
+	return seats, err
+}
+
+// releaseHolds gives the held ones of seats, which must be locked, back to the allocation of the
+// ticket option before they are taken again.
+func releaseHolds(tx *gorm.DB, organizerID, ticketID int, seats []ticket.Seat, reason string) error {
+	held := 0
+	for _, seat := range seats {
+		if seat.Status == ticket.SeatHeld {
+			held++
+		}
+	}
+
+	if held == 0 {
+		return nil
+	}
+
+	return moveAllocation(tx, ticket.LedgerEntry{
+		OrganizerID: organizerID,
+		TicketID:    ticketID,
+		Kind:        ticket.LedgerRelease,
+		Delta:       held,
+		Reason:      reason,
+	})
+}
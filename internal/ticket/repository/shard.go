@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+var ErrDBTicketOptionIsNotSharded = errors.New("ticket option is not sharded")
+
+// ShardAllocation splits the available tickets of the ticket option evenly across shards shards,
+// or counts them all in its row again when shards is 0.
+func (df *DefaultRepository) ShardAllocation(ctx context.Context, id, shards int) (*ticket.Ticket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	option := ticket.Ticket{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Second) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTicketOption(tx, organizerID, id, &option); err != nil {
+			return err
+		}
+
+		if option.Seated {
+			return ErrDBTicketOptionIsSeated
+		}
+
+		before := option.Shards
+		if err := setShards(tx, &option, shards); err != nil {
+			return err
+		}
+
+		return writeAudit(tx, ticket.AuditAllocationSharded, auditTarget("ticket_option", id),
+			map[string]interface{}{"shards": before}, map[string]interface{}{"shards": shards})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBTicketNotFound) && !errors.Is(err, ErrDBTicketOptionIsSeated) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+// RebalanceShards spreads the available tickets of a sharded ticket option evenly across its
+// shards again, so that purchases find a shard with enough of them.
+func (df *DefaultRepository) RebalanceShards(ctx context.Context, id int) (*ticket.Ticket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	option := ticket.Ticket{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Second) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTicketOption(tx, organizerID, id, &option); err != nil {
+			return err
+		}
+
+		if option.Shards == 0 {
+			return ErrDBTicketOptionIsNotSharded
+		}
+
+		if err := foldShards(tx, &option); err != nil {
+			return err
+		}
+
+		return spreadShards(tx, &option)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBTicketNotFound) && !errors.Is(err, ErrDBTicketOptionIsNotSharded) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+// takeTickets takes the -entry.Delta tickets of a purchase or a hold off the ticket option of entry,
+// without recording entry. Sharded ticket options take purchased tickets from a shard with enough of
+// them that no other purchase is taking from, without locking the ticket option. Only when there is
+// none do they wait, and when no shard has enough of them, or the tickets are held, they are
+// rebalanced.
+func takeTickets(tx *gorm.DB, entry ticket.LedgerEntry) error {
+	option := ticket.Ticket{}
+	err := tx.Select("id", "shards").Scopes(ofOrganizer(entry.OrganizerID)).First(&option, "id = ?", entry.TicketID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDBTicketNotFound
+		}
+		return err
+	}
+
+	if option.Shards == 0 {
+		return moveCounters(tx, entry)
+	}
+
+	quantity := -entry.Delta
+	if entry.Kind == ticket.LedgerPurchase {
+		// Purchases start looking at different shards, so that they spread across them.
+		order := fmt.Sprintf("(shard + %d) %% %d", rand.Intn(option.Shards), option.Shards) //nolint:gosec
+
+		for _, locking := range []clause.Locking{{Strength: "UPDATE", Options: "SKIP LOCKED"}, {Strength: "UPDATE"}} {
+			shard := ticket.AllocationShard{}
+			result := tx.Clauses(locking).Where("ticket_id = ? AND available >= ?", option.ID, quantity).
+				Order(order).Limit(1).Find(&shard)
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 1 {
+				return tx.Model(&ticket.AllocationShard{}).Where("ticket_id = ? AND shard = ?", shard.TicketID, shard.Shard).
+					Updates(map[string]interface{}{
+						"available": gorm.Expr("available - ?", quantity),
+						"sold":      gorm.Expr("sold + ?", quantity),
+					}).Error
+			}
+		}
+	}
+
+	option = ticket.Ticket{}
+	if err = lockTicketOption(tx, entry.OrganizerID, entry.TicketID, &option); err != nil {
+		return err
+	}
+
+	if err = foldShards(tx, &option); err != nil {
+		return err
+	}
+
+	if option.Available < quantity {
+		return ErrDBNotEnoughAllocation
+	}
+
+	if err = moveCounters(tx, entry); err != nil {
+		return err
+	}
+
+	return spreadShards(tx, &option)
+}
+
+// returnTickets gives the entry.Delta tickets of a refund back to the ticket option of entry,
+// without recording entry. A sharded ticket option may have sold them from any of its shards, so
+// its shards are folded back into its row before, and spread again after.
+func returnTickets(tx *gorm.DB, entry ticket.LedgerEntry) error {
+	option := ticket.Ticket{}
+	err := tx.Select("id", "shards").Scopes(ofOrganizer(entry.OrganizerID)).First(&option, "id = ?", entry.TicketID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDBTicketNotFound
+		}
+		return err
+	}
+
+	if option.Shards == 0 {
+		return moveCounters(tx, entry)
+	}
+
+	option = ticket.Ticket{}
+	if err = lockTicketOption(tx, entry.OrganizerID, entry.TicketID, &option); err != nil {
+		return err
+	}
+
+	if err = foldShards(tx, &option); err != nil {
+		return err
+	}
+
+	if err = moveCounters(tx, entry); err != nil {
+		return err
+	}
+
+	return spreadShards(tx, &option)
+}
+
+// setShards splits the available tickets of option, which must be locked, across shards shards.
+func setShards(tx *gorm.DB, option *ticket.Ticket, shards int) error {
+	if err := foldShards(tx, option); err != nil {
+		return err
+	}
+
+	option.Shards = shards
+	if err := tx.Model(option).Update("shards", shards).Error; err != nil {
+		return err
+	}
+
+	if shards == 0 {
+		return tx.Where("ticket_id = ?", option.ID).Delete(&ticket.AllocationShard{}).Error
+	}
+
+	return spreadShards(tx, option)
+}
+
+// foldShards moves the available and sold tickets of the shards of option, which must be locked,
+// back to its row, and adds them to option.
+func foldShards(tx *gorm.DB, option *ticket.Ticket) error {
+	var shards []ticket.AllocationShard
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("ticket_id = ?", option.ID).
+		Order("shard").Find(&shards).Error
+	if err != nil || len(shards) == 0 {
+		return err
+	}
+
+	available, sold := 0, 0
+	for _, shard := range shards {
+		available += shard.Available
+		sold += shard.Sold
+	}
+
+	err = tx.Model(&ticket.AllocationShard{}).Where("ticket_id = ?", option.ID).
+		Updates(map[string]interface{}{"available": 0, "sold": 0}).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Model(ticket.Ticket{}).Where("id = ?", option.ID).Updates(map[string]interface{}{
+		"available": gorm.Expr("available + ?", available),
+		"sold":      gorm.Expr("sold + ?", sold),
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	option.Available += available
+	option.Sold += sold
+
+	return nil
+}
+
+// spreadShards moves the available tickets of the row of option, whose shards must be locked and
+// empty, evenly across option.Shards shards. option keeps counting them.
+func spreadShards(tx *gorm.DB, option *ticket.Ticket) error {
+	var available int
+	if err := tx.Model(ticket.Ticket{}).Select("available").Where("id = ?", option.ID).Scan(&available).Error; err != nil {
+		return err
+	}
+
+	shards := make([]ticket.AllocationShard, option.Shards)
+	for i := range shards {
+		shards[i] = ticket.AllocationShard{TicketID: option.ID, Shard: i, Available: available / option.Shards}
+		if i < available%option.Shards {
+			shards[i].Available++
+		}
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "ticket_id"}, {Name: "shard"}},
+		DoUpdates: clause.AssignmentColumns([]string{"available", "sold"}),
+	}).Create(&shards).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Where("ticket_id = ? AND shard >= ?", option.ID, option.Shards).Delete(&ticket.AllocationShard{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(ticket.Ticket{}).Where("id = ?", option.ID).
+		Update("available", gorm.Expr("available - ?", available)).Error
+}
+
+// addShards adds the tickets counted in the shards of the sharded options to them.
+func addShards(tx *gorm.DB, options ...*ticket.Ticket) error {
+	sharded := make(map[int]*ticket.Ticket, len(options))
+	for _, option := range options {
+		if option.Shards > 0 {
+			sharded[option.ID] = option
+		}
+	}
+
+	if len(sharded) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(sharded))
+	for id := range sharded {
+		ids = append(ids, id)
+	}
+
+	var sums []ticket.AllocationShard
+	err := tx.Model(&ticket.AllocationShard{}).
+		Select("ticket_id, SUM(available) AS available, SUM(sold) AS sold").
+		Where("ticket_id IN ?", ids).Group("ticket_id").Scan(&sums).Error
+	if err != nil {
+		return err
+	}
+
+	for _, sum := range sums {
+		sharded[sum.TicketID].Available += sum.Available
+		sharded[sum.TicketID].Sold += sum.Sold
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+// TransferIssuedTicket hands the ticket with code over to toUserID under newCode. The row is locked
+// so a transfer cannot interleave with a check-in or another transfer of the same ticket.
+func (df *DefaultRepository) TransferIssuedTicket(ctx context.Context, code, toUserID, newCode string) (*ticket.IssuedTicket, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := ticket.IssuedTicket{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(ofOrganizerTickets(organizerID)).
+			First(&issued, "code = ?", code).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBIssuedTicketNotFound
+			}
+			return err
+		}
+
+		switch issued.Status {
+		case ticket.IssuedTicketUsed:
+			return ErrDBIssuedTicketAlreadyUsed
+		case ticket.IssuedTicketRefunded:
+			return ErrDBIssuedTicketRefunded
+		}
+
+		return transferIssuedTicket(tx, organizerID, &issued, toUserID, newCode)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBIssuedTicketNotFound) && !errors.Is(err, ErrDBIssuedTicketAlreadyUsed) &&
+			!errors.Is(err, ErrDBIssuedTicketRefunded) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &issued, nil
+}
+
+// GetIssuedTicketTransfers returns the transfers of the ticket currently holding code, oldest first.
+func (df *DefaultRepository) GetIssuedTicketTransfers(ctx context.Context, code string) ([]ticket.TicketTransfer, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers []ticket.TicketTransfer
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = df.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		issued := ticket.IssuedTicket{}
+		if err := tx.Scopes(ofOrganizerTickets(organizerID)).First(&issued, "code = ?", code).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBIssuedTicketNotFound
+			}
+			return err
+		}
+
+		return tx.Where("issued_ticket_id = ?", issued.ID).Order("id").Find(&transfers).Error
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBIssuedTicketNotFound) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// transferIssuedTicket records the transfer of the locked issued ticket of organizerID and rewrites
+// it to belong to toUserID under newCode. Listings of the ticket that are still up are taken down: the seller no
+// longer owns it.
+func transferIssuedTicket(tx *gorm.DB, organizerID int, issued *ticket.IssuedTicket, toUserID, newCode string) error {
+	transfer := ticket.TicketTransfer{
+		IssuedTicketID: issued.ID,
+		FromUserID:     issued.UserID,
+		ToUserID:       toUserID,
+		OldCode:        issued.Code,
+	}
+	if err := tx.Create(&transfer).Error; err != nil {
+		return err
+	}
+
+	issued.Code = newCode
+	issued.UserID = toUserID
+	err := tx.Model(issued).Updates(map[string]interface{}{"code": newCode, "user_id": toUserID}).Error
+	if err != nil {
+		return err
+	}
+
+	// Codes admit whoever holds them, so only the owners go into the audit log.
+	err = writeAudit(tx, ticket.AuditIssuedTicketTransferred, auditTarget("issued_ticket", issued.ID),
+		map[string]string{"user_id": transfer.FromUserID}, map[string]string{"user_id": toUserID})
+	if err != nil {
+		return err
+	}
+
+	err = tx.Model(&ticket.ResaleListing{}).
+		Where("issued_ticket_id = ? AND status = ?", issued.ID, ticket.ResaleListingListed).
+		Update("status", ticket.ResaleListingCancelled).Error
+	if err != nil {
+		return err
+	}
+
+	return writeEvent(tx, organizerID, ticket.EventTicketTransferred, ticket.TicketTransferredPayload{
+		IssuedTicketID: issued.ID,
+		TicketID:       issued.TicketID,
+		FromUserID:     transfer.FromUserID,
+		ToUserID:       toUserID,
+	})
+}
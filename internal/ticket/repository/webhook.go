@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+var (
+	ErrDBWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrDBWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+type WebhookRepository interface {
+	CreateWebhookSubscription(ctx context.Context, url, secret string, eventTypes ticket.EventTypes) (*ticket.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id int) (*ticket.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]ticket.WebhookSubscription, error)
+	CreateWebhookDeliveries(ctx context.Context, deliveries []ticket.WebhookDelivery) error
+	ListWebhookDeliveries(ctx context.Context, subscriptionID int) ([]ticket.WebhookDelivery, error)
+	ResetWebhookDelivery(ctx context.Context, subscriptionID, deliveryID int, nextAttemptAt time.Time) (*ticket.WebhookDelivery, error)
+	FetchDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]ticket.WebhookDelivery, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, delivery *ticket.WebhookDelivery, attempt ticket.WebhookDeliveryAttempt) error
+}
+
+type DefaultWebhookRepository struct {
+	database *gorm.DB
+}
+
+func NewDefaultWebhookRepository(database *gorm.DB) *DefaultWebhookRepository {
+	return &DefaultWebhookRepository{
+		database: database,
+	}
+}
+
+// CreateWebhookSubscription subscribes url to the events of eventTypes of the organizer of ctx.
+func (r *DefaultWebhookRepository) CreateWebhookSubscription(ctx context.Context, url, secret string,
+	eventTypes ticket.EventTypes) (*ticket.WebhookSubscription, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := ticket.WebhookSubscription{
+		OrganizerID: organizerID,
+		URL:         url,
+		EventTypes:  eventTypes,
+		Secret:      secret,
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = r.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&subscription).Error; err != nil {
+			return err
+		}
+
+		return writeAudit(tx, ticket.AuditWebhookCreated, auditTarget("webhook", subscription.ID), nil, subscription)
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (r *DefaultWebhookRepository) GetWebhookSubscription(ctx context.Context, id int) (*ticket.WebhookSubscription, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := ticket.WebhookSubscription{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = r.database.WithContext(timeoutCtx).Scopes(ofOrganizer(organizerID)).First(&subscription, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDBWebhookNotFound
+		}
+
+		log.Error(err)
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// ListWebhookSubscriptions returns the webhook subscriptions of the organizer of ctx.
+func (r *DefaultWebhookRepository) ListWebhookSubscriptions(ctx context.Context) ([]ticket.WebhookSubscription, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []ticket.WebhookSubscription
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	if err = r.database.WithContext(timeoutCtx).Scopes(ofOrganizer(organizerID)).Order("id").Find(&subscriptions).Error; err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// CreateWebhookDeliveries ignores deliveries that already exist for the same subscription and event,
+// so an event relayed twice by the outbox is still delivered once per subscription.
+func (r *DefaultWebhookRepository) CreateWebhookDeliveries(ctx context.Context, deliveries []ticket.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err := r.database.WithContext(timeoutCtx).Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *DefaultWebhookRepository) ListWebhookDeliveries(ctx context.Context, subscriptionID int) ([]ticket.WebhookDelivery, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []ticket.WebhookDelivery
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = r.database.WithContext(timeoutCtx).
+		Preload("AttemptLog", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Scopes(ofOrganizerSubscriptions(organizerID)).
+		Where("subscription_id = ?", subscriptionID).
+		Order("id DESC").
+		Find(&deliveries).Error
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *DefaultWebhookRepository) ResetWebhookDelivery(ctx context.Context, subscriptionID, deliveryID int,
+	nextAttemptAt time.Time) (*ticket.WebhookDelivery, error) {
+	organizerID, err := organizerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := ticket.WebhookDelivery{}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err = r.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Scopes(ofOrganizerSubscriptions(organizerID)).
+			First(&delivery, "id = ? AND subscription_id = ?", deliveryID, subscriptionID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDBWebhookDeliveryNotFound
+			}
+			return err
+		}
+
+		before := delivery
+		delivery.Status = ticket.WebhookDeliveryPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = nextAttemptAt
+
+		if err = tx.Model(&delivery).Select("status", "attempts", "next_attempt_at").Updates(&delivery).Error; err != nil {
+			return err
+		}
+
+		return writeAudit(tx, ticket.AuditWebhookDeliveryRedelivered, auditTarget("webhook_delivery", delivery.ID), before, delivery)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDBWebhookDeliveryNotFound) {
+			log.Error(err)
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// FetchDueWebhookDeliveries returns the deliveries of every organizer that are due by now.
+func (r *DefaultWebhookRepository) FetchDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]ticket.WebhookDelivery, error) {
+	var deliveries []ticket.WebhookDelivery
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	err := r.database.WithContext(timeoutCtx).
+		Preload("Subscription").
+		Where("status = ? AND next_attempt_at <= ?", ticket.WebhookDeliveryPending, now).
+		Order("id").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RecordWebhookDeliveryAttempt appends attempt to the delivery log and stores the delivery's new state.
+func (r *DefaultWebhookRepository) RecordWebhookDeliveryAttempt(ctx context.Context, delivery *ticket.WebhookDelivery,
+	attempt ticket.WebhookDeliveryAttempt) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond) //nolint:gomnd
+	defer cancel()
+
+	return r.database.WithContext(timeoutCtx).Transaction(func(tx *gorm.DB) error {
+		attempt.DeliveryID = delivery.ID
+		if err := tx.Create(&attempt).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(delivery).
+			Select("status", "attempts", "next_attempt_at", "delivered_at").
+			Updates(delivery).Error
+	})
+}
@@ -0,0 +1,29 @@
+package ticket
+
+import (
+	"time"
+)
+
+type ResaleListingStatus string
+
+const (
+	ResaleListingListed    ResaleListingStatus = "listed"
+	ResaleListingSold      ResaleListingStatus = "sold"
+	ResaleListingCancelled ResaleListingStatus = "cancelled"
+)
+
+// ResaleListing offers an issued ticket to other users at Price, in minor units. An issued ticket
+// has at most one listing that is still listed.
+type ResaleListing struct {
+	ID             int                 `gorm:"primaryKey" json:"id"`
+	IssuedTicketID int                 `gorm:"not null;uniqueIndex:idx_resale_listings_listed,where:status = 'listed'" json:"issued_ticket_id"`
+	TicketID       int                 `gorm:"not null;index" json:"ticket_id"`
+	SellerID       string              `gorm:"not null" json:"seller_id"`
+	BuyerID        string              `json:"buyer_id,omitempty"`
+	Price          int                 `gorm:"not null;check:chk_resale_listings_price_non_negative,price >= 0" json:"price"`
+	Status         ResaleListingStatus `gorm:"not null;index" json:"status"`
+	SoldAt         *time.Time          `json:"sold_at,omitempty"`
+	IssuedTicket   *IssuedTicket       `json:"issued_ticket,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
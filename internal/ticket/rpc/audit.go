@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/audit"
+)
+
+// AuditOrigin puts who makes a call, from the x-actor metadata, and its x-request-id into the call
+// context, so that the changes it makes are audited under them.
+func AuditOrigin() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		return handler(audit.WithOrigin(ctx, audit.Origin{
+			Actor:     first(md.Get("x-actor")),
+			RequestID: first(md.Get("x-request-id")),
+		}), req)
+	}
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+)
+
+// ResolveOrganizer makes every call act for the organizer making it, resolved from the authorization
+// bearer token or, when trustHeader is set, the x-organizer-id metadata, as the HTTP API does.
+func ResolveOrganizer(svc service.Service, trustHeader bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		organizer, err := resolveOrganizer(ctx, svc, md, trustHeader)
+		if err != nil {
+			switch err {
+			case service.ErrOrganizerWasNotFound, service.ErrOrganizerTokenIsEmpty, service.ErrIDLowerThanOne:
+				return nil, status.Error(codes.Unauthenticated, "a valid bearer token or organizer id is needed")
+			default:
+				return nil, status.Error(codes.Internal, internalErrorMessage)
+			}
+		}
+
+		return handler(tenant.WithOrganizer(ctx, organizer.ID), req)
+	}
+}
+
+func resolveOrganizer(ctx context.Context, svc service.Service, md metadata.MD, trustHeader bool) (*ticket.Organizer, error) {
+	if authorization := first(md.Get("authorization")); authorization != "" || !trustHeader {
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		if token == authorization {
+			return nil, service.ErrOrganizerTokenIsEmpty
+		}
+		return svc.AuthenticateOrganizer(ctx, token)
+	}
+
+	id, err := strconv.Atoi(first(md.Get("x-organizer-id")))
+	if err != nil {
+		return nil, service.ErrIDLowerThanOne
+	}
+
+	return svc.GetOrganizer(ctx, id)
+}
@@ -0,0 +1,61 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	ticketv1 "github.com/dilaragorum/ticket-api/api/ticket/v1"
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/rpc"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_Should_Act_For_Organizer_Of_Bearer_Token_Over_GRPC(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().AuthenticateOrganizer(gomock.Any(), "secret-token").Return(&ticket.Organizer{ID: 2}, nil).Times(1)
+	mockService.EXPECT().GetTicket(gomock.Any(), 1).
+		DoAndReturn(func(ctx context.Context, id int) (*ticket.Ticket, error) {
+			organizerID, _ := tenant.OrganizerFrom(ctx)
+			assert.Equal(t, 2, organizerID)
+			return &ticket.Ticket{ID: id, OrganizerID: organizerID}, nil
+		}).Times(1)
+
+	client := newClient(t, mockService, grpc.UnaryInterceptor(rpc.ResolveOrganizer(mockService, false)))
+	ctx := metadata.AppendToOutgoingContext(context.TODO(), "authorization", "Bearer secret-token")
+
+	// When
+	option, err := client.GetTicket(ctx, &ticketv1.GetTicketRequest{Id: 1})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), option.GetId())
+}
+
+func Test_Should_Return_Unauthenticated_When_Organizer_Is_Not_Resolved_Over_GRPC(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().AuthenticateOrganizer(gomock.Any(), "unknown-token").Return(nil, service.ErrOrganizerWasNotFound).Times(1)
+
+	client := newClient(t, mockService, grpc.UnaryInterceptor(rpc.ResolveOrganizer(mockService, false)))
+
+	for _, ctx := range []context.Context{
+		context.TODO(),
+		metadata.AppendToOutgoingContext(context.TODO(), "x-organizer-id", "2"),
+		metadata.AppendToOutgoingContext(context.TODO(), "authorization", "Bearer unknown-token"),
+	} {
+		// When
+		_, err := client.GetTicket(ctx, &ticketv1.GetTicketRequest{Id: 1})
+
+		// Then
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+}
@@ -0,0 +1,167 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ticketv1 "github.com/dilaragorum/ticket-api/api/ticket/v1"
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+)
+
+const internalErrorMessage = "an error occurred please try again later"
+
+type DefaultTicketServer struct {
+	ticketv1.UnimplementedTicketServiceServer
+	service service.Service
+}
+
+func NewDefaultTicketServer(s *grpc.Server, service service.Service) *DefaultTicketServer {
+	t := DefaultTicketServer{service: service}
+
+	ticketv1.RegisterTicketServiceServer(s, &t)
+
+	return &t
+}
+
+func (t *DefaultTicketServer) CreateTicketOption(ctx context.Context, req *ticketv1.CreateTicketOptionRequest) (*ticketv1.TicketOption, error) {
+	option, err := t.service.CreateTicketOption(ctx, req.GetName(), req.GetDesc(), int(req.GetAllocation()),
+		int(req.GetPrice()), nil, toTime(req.GetSaleStartsAt()), toTime(req.GetSaleEndsAt()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toTicketOption(option), nil
+}
+
+func (t *DefaultTicketServer) GetTicket(ctx context.Context, req *ticketv1.GetTicketRequest) (*ticketv1.TicketOption, error) {
+	option, err := t.service.GetTicket(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toTicketOption(option), nil
+}
+
+func (t *DefaultTicketServer) ListTicketOptions(ctx context.Context, req *ticketv1.ListTicketOptionsRequest) (*ticketv1.ListTicketOptionsResponse, error) {
+	filter := ticket.TicketFilter{Limit: int(req.GetPageSize()), OnSale: req.GetOnSale()}
+
+	if req.GetPageToken() != "" {
+		afterID, err := strconv.Atoi(req.GetPageToken())
+		if err != nil || afterID < 0 {
+			return nil, status.Error(codes.InvalidArgument, "page_token is not valid")
+		}
+		filter.AfterID = afterID
+	}
+
+	options, err := t.service.ListTicketOptions(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &ticketv1.ListTicketOptionsResponse{TicketOptions: make([]*ticketv1.TicketOption, 0, len(options))}
+	for i := range options {
+		res.TicketOptions = append(res.TicketOptions, toTicketOption(&options[i]))
+	}
+
+	// A short page is the last one; a full page may be followed by more.
+	if len(options) > 0 && len(options) >= pageSize(filter.Limit) {
+		res.NextPageToken = strconv.Itoa(options[len(options)-1].ID)
+	}
+
+	return res, nil
+}
+
+func (t *DefaultTicketServer) PurchaseFromTicketOption(ctx context.Context,
+	req *ticketv1.PurchaseFromTicketOptionRequest) (*ticketv1.PurchaseFromTicketOptionResponse, error) {
+	purchase, err := t.service.PurchaseFromTicketOption(ctx, int(req.GetId()), int(req.GetQuantity()), req.GetUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &ticketv1.PurchaseFromTicketOptionResponse{
+		PurchaseId: int64(purchase.ID),
+		TotalPrice: int64(purchase.TotalPrice),
+	}
+	for _, it := range purchase.IssuedTickets {
+		res.TicketCodes = append(res.TicketCodes, it.Code)
+	}
+
+	return res, nil
+}
+
+func pageSize(requested int) int {
+	switch {
+	case requested < 1:
+		return service.DefaultListLimit
+	case requested > service.MaxListLimit:
+		return service.MaxListLimit
+	default:
+		return requested
+	}
+}
+
+func toTicketOption(t *ticket.Ticket) *ticketv1.TicketOption {
+	option := &ticketv1.TicketOption{
+		Id:         int64(t.ID),
+		Name:       t.Name,
+		Desc:       t.Desc,
+		Allocation: int64(t.Available),
+		Capacity:   int64(t.Capacity),
+		Sold:       int64(t.Sold),
+		Held:       int64(t.Held),
+		Price:      int64(t.Price),
+		CreatedAt:  timestamppb.New(t.CreatedAt),
+		UpdatedAt:  timestamppb.New(t.UpdatedAt),
+	}
+	if t.SaleStartsAt != nil {
+		option.SaleStartsAt = timestamppb.New(*t.SaleStartsAt)
+	}
+	if t.SaleEndsAt != nil {
+		option.SaleEndsAt = timestamppb.New(*t.SaleEndsAt)
+	}
+
+	return option
+}
+
+// toTime returns nil for an unset timestamp.
+func toTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime()
+	return &t
+}
+
+// toStatus maps the service errors to gRPC status codes the same way the REST handlers map
+// them to HTTP status codes. Unknown errors are not leaked to the client.
+func toStatus(err error) error {
+	switch err {
+	case service.ErrNameIsEmpty,
+		service.ErrDescriptionIsEmpty,
+		service.ErrAllocationIsLowerThanOne,
+		service.ErrPriceIsNegative,
+		service.ErrSaleWindowIsInvalid,
+		service.ErrIDLowerThanOne,
+		service.ErrQuantityLowerThanOne:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case service.ErrNameIsDuplicate:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case service.ErrTicketWasNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case service.ErrPurchaseTicketMoreThanAvailable,
+		service.ErrTicketOptionIsSeated,
+		service.ErrSaleNotStarted,
+		service.ErrSaleEnded:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, internalErrorMessage)
+	}
+}
@@ -0,0 +1,159 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	ticketv1 "github.com/dilaragorum/ticket-api/api/ticket/v1"
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/rpc"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newClient(t *testing.T, svc service.Service, opts ...grpc.ServerOption) ticketv1.TicketServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(opts...)
+	rpc.NewDefaultTicketServer(server, svc)
+
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return ticketv1.NewTicketServiceClient(conn)
+}
+
+func Test_Should_Return_Ticket_Option_When_Create_Over_GRPC(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().CreateTicketOption(gomock.Any(), "example", "sample description", 100, 0, nil, nil, nil).
+		Return(&ticket.Ticket{ID: 1, Name: "example", Desc: "sample description", Available: 100}, nil).Times(1)
+
+	client := newClient(t, mockService)
+
+	// When
+	option, err := client.CreateTicketOption(context.TODO(),
+		&ticketv1.CreateTicketOptionRequest{Name: "example", Desc: "sample description", Allocation: 100})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), option.GetId())
+	assert.Equal(t, "example", option.GetName())
+	assert.Equal(t, int64(100), option.GetAllocation())
+}
+
+func Test_Should_Return_Capacity_Sold_And_Held_When_Get_Ticket_Over_GRPC(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetTicket(gomock.Any(), 1).
+		Return(&ticket.Ticket{ID: 1, Name: "example", Capacity: 120, Sold: 15, Held: 5, Available: 100}, nil).Times(1)
+
+	client := newClient(t, mockService)
+
+	// When
+	option, err := client.GetTicket(context.TODO(), &ticketv1.GetTicketRequest{Id: 1})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{120, 15, 5, 100},
+		[]int64{option.GetCapacity(), option.GetSold(), option.GetHeld(), option.GetAllocation()})
+}
+
+func Test_Should_Map_Service_Errors_To_GRPC_Status_Codes(t *testing.T) {
+	type testCase struct {
+		name         string
+		serviceErr   error
+		expectedCode codes.Code
+	}
+
+	testCases := []testCase{
+		{name: "invalid id", serviceErr: service.ErrIDLowerThanOne, expectedCode: codes.InvalidArgument},
+		{name: "invalid quantity", serviceErr: service.ErrQuantityLowerThanOne, expectedCode: codes.InvalidArgument},
+		{name: "not found", serviceErr: service.ErrTicketWasNotFound, expectedCode: codes.NotFound},
+		{name: "not enough tickets", serviceErr: service.ErrPurchaseTicketMoreThanAvailable, expectedCode: codes.FailedPrecondition},
+		{name: "unknown error", serviceErr: errors.New("connection refused"), expectedCode: codes.Internal},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			mockService := mocks.NewMockService(gomock.NewController(t))
+			mockService.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 2, "user").Return(nil, test.serviceErr).Times(1)
+
+			client := newClient(t, mockService)
+
+			// When
+			_, err := client.PurchaseFromTicketOption(context.TODO(),
+				&ticketv1.PurchaseFromTicketOptionRequest{Id: 1, Quantity: 2, UserId: "user"})
+
+			// Then
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			assert.NotContains(t, err.Error(), "connection refused")
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		// Given
+		mockService := mocks.NewMockService(gomock.NewController(t))
+		mockService.EXPECT().CreateTicketOption(gomock.Any(), "example", "desc", 1, 0, nil, nil, nil).Return(nil, service.ErrNameIsDuplicate).Times(1)
+
+		client := newClient(t, mockService)
+
+		// When
+		_, err := client.CreateTicketOption(context.TODO(), &ticketv1.CreateTicketOptionRequest{Name: "example", Desc: "desc", Allocation: 1})
+
+		// Then
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+}
+
+func Test_Should_Page_Through_Ticket_Options_Over_GRPC(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().ListTicketOptions(gomock.Any(), ticket.TicketFilter{Limit: 2}).
+		Return([]ticket.Ticket{{ID: 1}, {ID: 2}}, nil).Times(1)
+	mockService.EXPECT().ListTicketOptions(gomock.Any(), ticket.TicketFilter{Limit: 2, AfterID: 2}).
+		Return([]ticket.Ticket{{ID: 3}}, nil).Times(1)
+
+	client := newClient(t, mockService)
+
+	// When
+	first, err := client.ListTicketOptions(context.TODO(), &ticketv1.ListTicketOptionsRequest{PageSize: 2})
+	assert.Nil(t, err)
+	last, err := client.ListTicketOptions(context.TODO(),
+		&ticketv1.ListTicketOptionsRequest{PageSize: 2, PageToken: first.GetNextPageToken()})
+	assert.Nil(t, err)
+
+	// Then
+	assert.Len(t, first.GetTicketOptions(), 2)
+	assert.Equal(t, "2", first.GetNextPageToken())
+	assert.Len(t, last.GetTicketOptions(), 1)
+	assert.Empty(t, last.GetNextPageToken())
+}
+
+func Test_Should_Return_Invalid_Argument_When_Page_Token_Is_Not_Valid(t *testing.T) {
+	// Given
+	client := newClient(t, mocks.NewMockService(gomock.NewController(t)))
+
+	// When
+	_, err := client.ListTicketOptions(context.TODO(), &ticketv1.ListTicketOptionsRequest{PageToken: "abc"})
+
+	// Then
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
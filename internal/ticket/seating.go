@@ -0,0 +1,92 @@
+package ticket
+
+import (
+	"time"
+)
+
+type SeatStatus string
+
+const (
+	SeatAvailable SeatStatus = "available"
+	SeatHeld      SeatStatus = "held"
+	SeatSold      SeatStatus = "sold"
+)
+
+// SeatPoint is a position on a seat map. Y counts rows from the front, across sections in layout
+// order. X counts half seats from the middle of the row, so that the seats of every row are
+// centered on X = 0.
+type SeatPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Seat is one place of the seat map of a seated ticket option. A held seat is set aside for HeldBy
+// until HeldUntil, after which it is available again.
+type Seat struct {
+	ID         int        `gorm:"primaryKey" json:"id"`
+	TicketID   int        `gorm:"not null;uniqueIndex:idx_seats_position" json:"ticket_id"`
+	Section    string     `gorm:"not null;uniqueIndex:idx_seats_position" json:"section"`
+	Row        string     `gorm:"not null;uniqueIndex:idx_seats_position" json:"row"`
+	Number     int        `gorm:"not null;uniqueIndex:idx_seats_position" json:"number"`
+	X          int        `gorm:"not null" json:"x"`
+	Y          int        `gorm:"not null" json:"y"`
+	Status     SeatStatus `gorm:"not null" json:"status"`
+	HeldBy     string     `json:"-"`
+	HeldUntil  *time.Time `json:"held_until,omitempty"`
+	PurchaseID *int       `gorm:"index" json:"purchase_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// AvailableAt tells whether the seat can be taken at now.
+func (s Seat) AvailableAt(now time.Time) bool {
+	return s.Status == SeatAvailable || (s.Status == SeatHeld && s.HeldUntil != nil && !now.Before(*s.HeldUntil))
+}
+
+// AvailableToAt tells whether userID can take the seat at now, counting the holds of userID.
+func (s Seat) AvailableToAt(userID string, now time.Time) bool {
+	return s.AvailableAt(now) || (s.Status == SeatHeld && s.HeldBy == userID)
+}
+
+// SeatMapLayout describes the seats to create for a ticket option. Seats of a row are numbered from
+// one. Best is where best available seats are looked for around, the front middle if not given.
+type SeatMapLayout struct {
+	Sections []SectionLayout `json:"sections"`
+	Best     *SeatPoint      `json:"best,omitempty"`
+}
+
+type SectionLayout struct {
+	Name string      `json:"name"`
+	Rows []RowLayout `json:"rows"`
+}
+
+type RowLayout struct {
+	Name  string `json:"name"`
+	Seats int    `json:"seats"`
+}
+
+// SeatMap is the seats of a ticket option grouped by section and row, in layout order.
+type SeatMap struct {
+	TicketID  int           `json:"ticket_id"`
+	Available int           `json:"available"`
+	Best      SeatPoint     `json:"best"`
+	Sections  []SeatSection `json:"sections"`
+}
+
+type SeatSection struct {
+	Name string    `json:"name"`
+	Rows []SeatRow `json:"rows"`
+}
+
+type SeatRow struct {
+	Name  string `json:"name"`
+	Seats []Seat `json:"seats"`
+}
+
+// SeatHold sets Seats aside for UserID until ExpiresAt, for them to purchase.
+type SeatHold struct {
+	TicketID  int       `json:"ticket_id"`
+	UserID    string    `json:"user_id"`
+	Seats     []Seat    `json:"seats"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,66 @@
+// Package seating finds best available seats on seat maps.
+package seating
+
+import (
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+// BestAvailable returns the quantity contiguous seats of one row, all available at now, whose middle
+// is closest to best; nil if no row has that many contiguous available seats. One row counts as far
+// as one seat. Ties go to the window met first in seats, which must be in layout order: rows one
+// after the other, each row by seat number.
+//
+// It runs in a single pass over seats, so it stays linear in the size of the venue.
+func BestAvailable(seats []ticket.Seat, quantity int, best ticket.SeatPoint, now time.Time) []ticket.Seat {
+	if quantity < 1 {
+		return nil
+	}
+
+	bestStart, bestScore := -1, 0
+	runStart := 0
+
+	for i, seat := range seats {
+		if !seat.AvailableAt(now) {
+			runStart = i + 1
+			continue
+		}
+
+		if i > runStart && !follows(seats[i-1], seat) {
+			runStart = i
+		}
+
+		if i-runStart+1 < quantity {
+			continue
+		}
+
+		start := i - quantity + 1
+		if score := distance(seats[start], seat, best); bestStart < 0 || score < bestScore {
+			bestStart, bestScore = start, score
+		}
+	}
+
+	if bestStart < 0 {
+		return nil
+	}
+
+	window := make([]ticket.Seat, quantity)
+	copy(window, seats[bestStart:bestStart+quantity])
+
+	return window
+}
+
+// follows tells whether seat is next to previous in the same row.
+func follows(previous, seat ticket.Seat) bool {
+	return seat.Section == previous.Section && seat.Row == previous.Row && seat.Number == previous.Number+1
+}
+
+// distance is the squared distance from the middle of the window first..last to best, times 16 so
+// that it is an exact integer: X is in half seats and the middle of a window can fall on a half of it.
+func distance(first, last ticket.Seat, best ticket.SeatPoint) int {
+	dx := first.X + last.X - 2*best.X
+	dy := 4 * (first.Y - best.Y) //nolint:gomnd
+
+	return dx*dx + dy*dy
+}
@@ -0,0 +1,187 @@
+package seating_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/seating"
+	"github.com/stretchr/testify/assert"
+)
+
+// venue lays out rows of the given sizes in one section, the way the service numbers them.
+func venue(rows ...int) []ticket.Seat {
+	var seats []ticket.Seat
+	id := 1
+
+	for y, size := range rows {
+		for number := 1; number <= size; number++ {
+			seats = append(seats, ticket.Seat{
+				ID:      id,
+				Section: "Stalls",
+				Row:     fmt.Sprintf("R%d", y+1),
+				Number:  number,
+				X:       2*number - size - 1,
+				Y:       y,
+				Status:  ticket.SeatAvailable,
+			})
+			id++
+		}
+	}
+
+	return seats
+}
+
+func ids(seats []ticket.Seat) []int {
+	if seats == nil {
+		return nil
+	}
+
+	result := make([]int, 0, len(seats))
+	for _, seat := range seats {
+		result = append(result, seat.ID)
+	}
+
+	return result
+}
+
+func Test_Should_Pick_Best_Available_Seats(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	type testCase struct {
+		name        string
+		seats       func() []ticket.Seat
+		quantity    int
+		best        ticket.SeatPoint
+		expectedIDs []int
+	}
+
+	testCases := []testCase{
+		{
+			name:        "middle of the front row",
+			seats:       func() []ticket.Seat { return venue(5, 5) },
+			quantity:    3,
+			expectedIDs: []int{2, 3, 4},
+		},
+		{
+			name:        "even quantity in an odd row takes the first of the two middle windows",
+			seats:       func() []ticket.Seat { return venue(5) },
+			quantity:    2,
+			expectedIDs: []int{2, 3},
+		},
+		{
+			name: "seats across a sold seat are not contiguous",
+			seats: func() []ticket.Seat {
+				seats := venue(5, 5)
+				seats[2].Status = ticket.SeatSold
+				return seats
+			},
+			quantity:    3,
+			expectedIDs: []int{7, 8, 9},
+		},
+		{
+			name: "seats of different rows are not contiguous",
+			seats: func() []ticket.Seat {
+				return venue(2, 2)
+			},
+			quantity:    3,
+			expectedIDs: nil,
+		},
+		{
+			name: "held seats are taken while the hold lasts",
+			seats: func() []ticket.Seat {
+				seats := venue(3, 3)
+				seats[1].Status, seats[1].HeldUntil = ticket.SeatHeld, &future
+				return seats
+			},
+			quantity:    2,
+			expectedIDs: []int{4, 5},
+		},
+		{
+			name: "held seats are free again when the hold expired",
+			seats: func() []ticket.Seat {
+				seats := venue(3, 3)
+				seats[1].Status, seats[1].HeldUntil = ticket.SeatHeld, &past
+				return seats
+			},
+			quantity:    2,
+			expectedIDs: []int{1, 2},
+		},
+		{
+			name:        "middle of a row near the best point beats the front row",
+			seats:       func() []ticket.Seat { return venue(5, 5, 5) },
+			quantity:    1,
+			best:        ticket.SeatPoint{X: 0, Y: 2},
+			expectedIDs: []int{13},
+		},
+		{
+			name: "middle of the row behind beats the edge of the front row",
+			seats: func() []ticket.Seat {
+				seats := venue(9, 9)
+				for i := 1; i < 8; i++ {
+					seats[i].Status = ticket.SeatSold
+				}
+				return seats
+			},
+			quantity:    1,
+			expectedIDs: []int{14},
+		},
+		{
+			name:        "more seats than any row has",
+			seats:       func() []ticket.Seat { return venue(3, 3) },
+			quantity:    4,
+			expectedIDs: nil,
+		},
+		{
+			name:        "quantity lower than one",
+			seats:       func() []ticket.Seat { return venue(3) },
+			quantity:    0,
+			expectedIDs: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// When
+			window := seating.BestAvailable(test.seats(), test.quantity, test.best, now)
+
+			// Then
+			assert.Equal(t, test.expectedIDs, ids(window))
+		})
+	}
+}
+
+func Test_Should_Not_Share_Seats_With_The_Seat_Map(t *testing.T) {
+	// Given
+	seats := venue(3)
+
+	// When
+	window := seating.BestAvailable(seats, 1, ticket.SeatPoint{}, time.Now())
+	window[0].Status = ticket.SeatHeld
+
+	// Then
+	assert.Equal(t, ticket.SeatAvailable, seats[1].Status)
+}
+
+func Benchmark_BestAvailable_50000_Seats(b *testing.B) {
+	rows := make([]int, 250)
+	for i := range rows {
+		rows[i] = 200
+	}
+	seats := venue(rows...)
+
+	// Every other seat of the front half is sold so that windows keep breaking.
+	for i := 0; i < len(seats)/2; i += 2 {
+		seats[i].Status = ticket.SeatSold
+	}
+
+	now := time.Now()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		seating.BestAvailable(seats, 4, ticket.SeatPoint{Y: 10}, now)
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+var ErrAuditPeriodIsInvalid = errors.New("audit period should end after it starts")
+
+// ListAuditEntries returns the page of audit entries after filter.AfterID, oldest first. Limits
+// are handled as in ListTicketOptions.
+func (s *DefaultService) ListAuditEntries(ctx context.Context, filter ticket.AuditFilter) ([]ticket.AuditEntry, error) {
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return nil, ErrAuditPeriodIsInvalid
+	}
+
+	if filter.Limit < 1 {
+		filter.Limit = DefaultListLimit
+	}
+
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+
+	if filter.AfterID < 0 {
+		filter.AfterID = 0
+	}
+
+	return s.repository.ListAuditEntries(ctx, filter)
+}
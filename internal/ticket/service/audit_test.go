@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Audit Unit Tests
+
+func Test_Should_Bound_Limit_When_List_Audit_Entries(t *testing.T) {
+	type testCase struct {
+		filter        ticket.AuditFilter
+		expectedLimit int
+	}
+
+	testCases := []testCase{
+		{filter: ticket.AuditFilter{}, expectedLimit: service.DefaultListLimit},
+		{filter: ticket.AuditFilter{Limit: 10}, expectedLimit: 10},
+		{filter: ticket.AuditFilter{Limit: 1000}, expectedLimit: service.MaxListLimit},
+	}
+
+	for _, test := range testCases {
+		// Given
+		expected := []ticket.AuditEntry{{ID: 1, Actor: "admin", Action: ticket.AuditPurchaseRefunded, Target: "purchase:1"}}
+
+		filter := test.filter
+		filter.Limit = test.expectedLimit
+
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().ListAuditEntries(gomock.Any(), filter).Return(expected, nil).Times(1)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		entries, err := ticketService.ListAuditEntries(context.TODO(), test.filter)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, expected, entries)
+	}
+}
+
+func Test_Should_Return_Error_When_Audit_Period_Ends_Before_It_Starts(t *testing.T) {
+	// Given
+	from := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	entries, err := ticketService.ListAuditEntries(context.TODO(), ticket.AuditFilter{From: &from, To: &to})
+
+	// Then
+	assert.Nil(t, entries)
+	assert.Equal(t, service.ErrAuditPeriodIsInvalid, err)
+}
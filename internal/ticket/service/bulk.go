@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+// MaxBulkRows is the most ticket options one bulk import can create.
+const MaxBulkRows = 1000
+
+var (
+	ErrBulkIsEmpty        = errors.New("bulk import has no rows")
+	ErrBulkIsTooLarge     = errors.New("bulk import has too many rows")
+	ErrBulkModeIsUnknown  = errors.New("bulk import mode is unknown")
+	ErrBulkRowsAreInvalid = errors.New("bulk import has rows that cannot be created")
+)
+
+// CreateTicketOptions creates a ticket option of each of drafts, validated as CreateTicketOption
+// validates them, and reports what became of each row. In BulkAllOrNothing mode nothing is created
+// if any row fails, and the report is returned with ErrBulkRowsAreInvalid. In BulkBestEffort mode
+// every row that can be created is.
+func (s *DefaultService) CreateTicketOptions(ctx context.Context, drafts []ticket.TicketDraft,
+	mode ticket.BulkMode) ([]ticket.BulkResult, error) {
+	if len(drafts) == 0 {
+		return nil, ErrBulkIsEmpty
+	}
+
+	if len(drafts) > MaxBulkRows {
+		return nil, ErrBulkIsTooLarge
+	}
+
+	switch mode {
+	case ticket.BulkAllOrNothing:
+		return s.createTicketOptionsAtOnce(ctx, drafts)
+	case ticket.BulkBestEffort:
+		return s.createTicketOptionsOneByOne(ctx, drafts), nil
+	default:
+		return nil, ErrBulkModeIsUnknown
+	}
+}
+
+func (s *DefaultService) createTicketOptionsAtOnce(ctx context.Context, drafts []ticket.TicketDraft) ([]ticket.BulkResult, error) {
+	results := make([]ticket.BulkResult, len(drafts))
+	names := make(map[string]bool, len(drafts))
+	invalid := false
+
+	for i, draft := range drafts {
+		results[i].Row = i + 1
+
+		err := validateTicketOption(draft.Name, draft.Desc, draft.Allocation, draft.Price, draft.SaleStartsAt, draft.SaleEndsAt)
+		if err == nil && names[draft.Name] {
+			err = ErrNameIsDuplicate
+		}
+		names[draft.Name] = true
+
+		if err != nil {
+			results[i].Err = err
+			invalid = true
+		}
+	}
+
+	if invalid {
+		return results, ErrBulkRowsAreInvalid
+	}
+
+	options, err := s.repository.CreateTicketOptions(ctx, drafts)
+	if err != nil {
+		var rowErr *repository.BulkRowError
+		if errors.As(err, &rowErr) && errors.Is(rowErr.Err, repository.ErrDBDuplicatedTicketName) {
+			results[rowErr.Row].Err = ErrNameIsDuplicate
+			return results, ErrBulkRowsAreInvalid
+		}
+		return nil, err
+	}
+
+	for i := range options {
+		results[i].Ticket = &options[i]
+	}
+
+	return results, nil
+}
+
+func (s *DefaultService) createTicketOptionsOneByOne(ctx context.Context, drafts []ticket.TicketDraft) []ticket.BulkResult {
+	results := make([]ticket.BulkResult, len(drafts))
+
+	for i, draft := range drafts {
+		results[i].Row = i + 1
+		results[i].Ticket, results[i].Err = s.CreateTicketOption(ctx, draft.Name, draft.Desc, draft.Allocation, draft.Price,
+			draft.StartsAt, draft.SaleStartsAt, draft.SaleEndsAt)
+	}
+
+	return results
+}
@@ -0,0 +1,152 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Bulk Import Unit Tests
+
+func Test_Should_Create_All_Ticket_Options_At_Once(t *testing.T) {
+	// Given
+	drafts := []ticket.TicketDraft{
+		{Name: "early bird", Desc: "sample description", Allocation: 100},
+		{Name: "regular", Desc: "sample description", Allocation: 500, Price: 2500},
+	}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().CreateTicketOptions(gomock.Any(), drafts).
+		Return([]ticket.Ticket{{ID: 1, Name: "early bird"}, {ID: 2, Name: "regular"}}, nil).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	results, err := ticketService.CreateTicketOptions(context.TODO(), drafts, ticket.BulkAllOrNothing)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []ticket.BulkResult{
+		{Row: 1, Ticket: &ticket.Ticket{ID: 1, Name: "early bird"}},
+		{Row: 2, Ticket: &ticket.Ticket{ID: 2, Name: "regular"}},
+	}, results)
+}
+
+func Test_Should_Create_Nothing_When_Any_Row_Is_Invalid(t *testing.T) {
+	// Given
+	drafts := []ticket.TicketDraft{
+		{Name: "early bird", Desc: "sample description", Allocation: 100},
+		{Name: "regular", Desc: "", Allocation: 500},
+		{Name: "early bird", Desc: "sample description", Allocation: 100},
+		{Name: "vip", Desc: "sample description", Allocation: 10, Price: -1},
+	}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().CreateTicketOptions(gomock.Any(), gomock.Any()).Times(0)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	results, err := ticketService.CreateTicketOptions(context.TODO(), drafts, ticket.BulkAllOrNothing)
+
+	// Then
+	assert.Equal(t, service.ErrBulkRowsAreInvalid, err)
+	assert.Equal(t, []ticket.BulkResult{
+		{Row: 1},
+		{Row: 2, Err: service.ErrDescriptionIsEmpty},
+		{Row: 3, Err: service.ErrNameIsDuplicate},
+		{Row: 4, Err: service.ErrPriceIsNegative},
+	}, results)
+}
+
+func Test_Should_Report_Row_Whose_Name_Exists_Already(t *testing.T) {
+	// Given
+	drafts := []ticket.TicketDraft{
+		{Name: "early bird", Desc: "sample description", Allocation: 100},
+		{Name: "regular", Desc: "sample description", Allocation: 500},
+	}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().CreateTicketOptions(gomock.Any(), drafts).
+		Return(nil, &repository.BulkRowError{Row: 1, Err: repository.ErrDBDuplicatedTicketName}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	results, err := ticketService.CreateTicketOptions(context.TODO(), drafts, ticket.BulkAllOrNothing)
+
+	// Then
+	assert.Equal(t, service.ErrBulkRowsAreInvalid, err)
+	assert.Equal(t, []ticket.BulkResult{{Row: 1}, {Row: 2, Err: service.ErrNameIsDuplicate}}, results)
+}
+
+func Test_Should_Create_Every_Row_That_Can_Be_Created_When_Best_Effort(t *testing.T) {
+	// Given
+	drafts := []ticket.TicketDraft{
+		{Name: "early bird", Desc: "sample description", Allocation: 100},
+		{Name: "regular", Desc: "sample description", Allocation: 0},
+		{Name: "vip", Desc: "sample description", Allocation: 10},
+	}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().CreateTicketOption(gomock.Any(), "early bird", "sample description", 100, 0, nil, nil, nil).
+		Return(&ticket.Ticket{ID: 1}, nil).Times(1)
+	mockRepository.EXPECT().CreateTicketOption(gomock.Any(), "vip", "sample description", 10, 0, nil, nil, nil).
+		Return(nil, repository.ErrDBDuplicatedTicketName).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	results, err := ticketService.CreateTicketOptions(context.TODO(), drafts, ticket.BulkBestEffort)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []ticket.BulkResult{
+		{Row: 1, Ticket: &ticket.Ticket{ID: 1}},
+		{Row: 2, Err: service.ErrAllocationIsLowerThanOne},
+		{Row: 3, Err: service.ErrNameIsDuplicate},
+	}, results)
+}
+
+func Test_Should_Return_Error_When_Bulk_Import_Cannot_Start(t *testing.T) {
+	type testCase struct {
+		drafts          []ticket.TicketDraft
+		mode            ticket.BulkMode
+		repositoryError error
+		expectedError   error
+	}
+
+	unavailable := errors.New("database is unavailable")
+	valid := []ticket.TicketDraft{{Name: "example", Desc: "sample description", Allocation: 1}}
+
+	testCases := []testCase{
+		{drafts: nil, mode: ticket.BulkAllOrNothing, expectedError: service.ErrBulkIsEmpty},
+		{drafts: make([]ticket.TicketDraft, service.MaxBulkRows+1), mode: ticket.BulkAllOrNothing, expectedError: service.ErrBulkIsTooLarge},
+		{drafts: valid, mode: "sometimes", expectedError: service.ErrBulkModeIsUnknown},
+		{drafts: valid, mode: ticket.BulkAllOrNothing, repositoryError: unavailable, expectedError: unavailable},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		if test.repositoryError != nil {
+			mockRepository.EXPECT().CreateTicketOptions(gomock.Any(), test.drafts).Return(nil, test.repositoryError).Times(1)
+		}
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		results, err := ticketService.CreateTicketOptions(context.TODO(), test.drafts, test.mode)
+
+		// Then
+		assert.Nil(t, results)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
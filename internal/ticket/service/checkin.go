@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+// CheckIn admits the holder of code at gateID. An event is a ticket option, so eventID is the id
+// of the ticket option the gate is admitting to. Forged codes and codes for another event are
+// rejected from the signed claims alone, before the database is touched.
+func (s *DefaultService) CheckIn(ctx context.Context, code string, eventID int, gateID string) (*ticket.IssuedTicket, error) {
+	if eventID < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	if gateID == "" {
+		return nil, ErrGateIDIsEmpty
+	}
+
+	claims, err := s.issuer.Verify(code)
+	if err != nil {
+		return nil, ErrIssuedTicketCodeIsInvalid
+	}
+
+	if claims.TicketID != eventID {
+		return nil, ErrIssuedTicketForOtherEvent
+	}
+
+	issued, err := s.repository.CheckIn(ctx, code, eventID, gateID, s.now())
+	if err != nil {
+		switch err {
+		case repository.ErrDBIssuedTicketNotFound:
+			return nil, ErrIssuedTicketWasNotFound
+		case repository.ErrDBIssuedTicketAlreadyUsed:
+			return nil, ErrIssuedTicketAlreadyUsed
+		case repository.ErrDBIssuedTicketRefunded:
+			return nil, ErrIssuedTicketRefunded
+		case repository.ErrDBIssuedTicketWrongEvent:
+			return nil, ErrIssuedTicketForOtherEvent
+		default:
+			return nil, err
+		}
+	}
+
+	return issued, nil
+}
+
+func (s *DefaultService) GetCheckinStats(ctx context.Context, eventID int) (*ticket.CheckinStats, error) {
+	if _, err := s.GetTicket(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetCheckinStats(ctx, eventID)
+}
@@ -0,0 +1,119 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketcode"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Check-in Unit Tests
+
+func Test_Should_Check_In_When_Code_Is_Genuine(t *testing.T) {
+	// Given
+	signer := ticketcode.NewRandomSigner()
+	code, _ := signer.Issue(1)
+
+	expected := &ticket.IssuedTicket{ID: 1, Code: code, TicketID: 1, Status: ticket.IssuedTicketUsed, GateID: "A"}
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().CheckIn(gomock.Any(), code, 1, "A", gomock.Any()).Return(expected, nil).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithCodeIssuer(signer))
+
+	// When
+	actual, err := ticketService.CheckIn(context.TODO(), code, 1, "A")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Reject_Check_In_Without_Touching_Repository(t *testing.T) {
+	signer := ticketcode.NewRandomSigner()
+	otherEventCode, _ := signer.Issue(2)
+	forgedCode, _ := ticketcode.NewRandomSigner().Issue(1)
+
+	type testCase struct {
+		code          string
+		eventID       int
+		gateID        string
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{code: otherEventCode, eventID: 0, gateID: "A", expectedError: service.ErrIDLowerThanOne},
+		{code: otherEventCode, eventID: 1, gateID: "", expectedError: service.ErrGateIDIsEmpty},
+		{code: forgedCode, eventID: 1, gateID: "A", expectedError: service.ErrIssuedTicketCodeIsInvalid},
+		{code: "garbage", eventID: 1, gateID: "A", expectedError: service.ErrIssuedTicketCodeIsInvalid},
+		{code: otherEventCode, eventID: 1, gateID: "A", expectedError: service.ErrIssuedTicketForOtherEvent},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().CheckIn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository, service.WithCodeIssuer(signer))
+
+		// When
+		actual, err := ticketService.CheckIn(context.TODO(), test.code, test.eventID, test.gateID)
+
+		// Then
+		assert.Nil(t, actual)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Map_Repository_Errors_When_Check_In(t *testing.T) {
+	signer := ticketcode.NewRandomSigner()
+	code, _ := signer.Issue(1)
+
+	type testCase struct {
+		repositoryError error
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{repositoryError: repository.ErrDBIssuedTicketNotFound, expectedError: service.ErrIssuedTicketWasNotFound},
+		{repositoryError: repository.ErrDBIssuedTicketAlreadyUsed, expectedError: service.ErrIssuedTicketAlreadyUsed},
+		{repositoryError: repository.ErrDBIssuedTicketRefunded, expectedError: service.ErrIssuedTicketRefunded},
+		{repositoryError: repository.ErrDBIssuedTicketWrongEvent, expectedError: service.ErrIssuedTicketForOtherEvent},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().CheckIn(gomock.Any(), code, 1, "A", gomock.Any()).Return(nil, test.repositoryError).Times(1)
+
+		ticketService := service.NewDefaultService(mockRepository, service.WithCodeIssuer(signer))
+
+		// When
+		actual, err := ticketService.CheckIn(context.TODO(), code, 1, "A")
+
+		// Then
+		assert.Nil(t, actual)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Return_Error_When_Check_In_Stats_Of_Unknown_Event(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 7).Return(nil, repository.ErrDBTicketNotFound).Times(1)
+	mockRepository.EXPECT().GetCheckinStats(gomock.Any(), gomock.Any()).Times(0)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	actual, err := ticketService.GetCheckinStats(context.TODO(), 7)
+
+	// Then
+	assert.Nil(t, actual)
+	assert.Equal(t, service.ErrTicketWasNotFound, err)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+const (
+	// HoldReleaseInterval is how often ReleaseExpiredHoldsEvery is meant to look for holds that ran out.
+	HoldReleaseInterval = 30 * time.Second
+	holdReleaseBatch    = 500
+	orderExpiryBatch    = 100
+)
+
+// ListLedgerEntries returns the page of ledger entries of a ticket option after filter.AfterID,
+// oldest first. Limits are handled as in ListTicketOptions.
+func (s *DefaultService) ListLedgerEntries(ctx context.Context, filter ticket.LedgerFilter) ([]ticket.LedgerEntry, error) {
+	if filter.TicketID < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	if filter.Limit < 1 {
+		filter.Limit = DefaultListLimit
+	}
+
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+
+	if filter.AfterID < 0 {
+		filter.AfterID = 0
+	}
+
+	return s.repository.ListLedgerEntries(ctx, filter)
+}
+
+// ReconcileAllocations returns the ticket options whose allocation has drifted from their ledger.
+func (s *DefaultService) ReconcileAllocations(ctx context.Context) ([]ticket.AllocationDrift, error) {
+	return s.repository.ReconcileAllocations(ctx)
+}
+
+// ReleaseExpiredHolds gives the seats whose hold ran out back to their ticket options, for every
+// organizer, notifies their new availability and returns how many it gave back.
+func (s *DefaultService) ReleaseExpiredHolds(ctx context.Context) (int, error) {
+	released := 0
+
+	for {
+		n, options, err := s.repository.ReleaseExpiredHolds(ctx, s.now(), holdReleaseBatch)
+		released += n
+		s.notifyOptions(options)
+		if err != nil || n < holdReleaseBatch {
+			return released, err
+		}
+	}
+}
+
+// ExpirePendingOrders cancels the pending orders that were not paid in time, for every organizer,
+// gives the tickets held for them back, notifies their new availability and returns how many it
+// cancelled.
+func (s *DefaultService) ExpirePendingOrders(ctx context.Context) (int, error) {
+	expired := 0
+
+	for {
+		n, options, err := s.repository.ExpirePendingOrders(ctx, s.now(), orderExpiryBatch)
+		expired += n
+		s.notifyOptions(options)
+		if err != nil || n < orderExpiryBatch {
+			return expired, err
+		}
+	}
+}
+
+// ReleaseExpiredHoldsEvery calls ReleaseExpiredHolds and ExpirePendingOrders every interval until
+// ctx is cancelled.
+func (s *DefaultService) ReleaseExpiredHoldsEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ReleaseExpiredHolds(ctx); err != nil && ctx.Err() == nil {
+			log.Error(err)
+		}
+
+		if _, err := s.ExpirePendingOrders(ctx); err != nil && ctx.Err() == nil {
+			log.Error(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ledger Unit Tests
+
+func Test_Should_Bound_Limit_When_List_Ledger_Entries(t *testing.T) {
+	type testCase struct {
+		filter        ticket.LedgerFilter
+		expectedLimit int
+	}
+
+	testCases := []testCase{
+		{filter: ticket.LedgerFilter{TicketID: 1}, expectedLimit: service.DefaultListLimit},
+		{filter: ticket.LedgerFilter{TicketID: 1, Limit: 10}, expectedLimit: 10},
+		{filter: ticket.LedgerFilter{TicketID: 1, Limit: 1000}, expectedLimit: service.MaxListLimit},
+	}
+
+	for _, test := range testCases {
+		// Given
+		expected := []ticket.LedgerEntry{{ID: 1, TicketID: 1, Kind: ticket.LedgerInitial, Delta: 100}}
+
+		filter := test.filter
+		filter.Limit = test.expectedLimit
+
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().ListLedgerEntries(gomock.Any(), filter).Return(expected, nil).Times(1)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		entries, err := ticketService.ListLedgerEntries(context.TODO(), test.filter)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, expected, entries)
+	}
+}
+
+func Test_Should_Return_Error_When_List_Ledger_Entries_Of_Invalid_ID(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	entries, err := ticketService.ListLedgerEntries(context.TODO(), ticket.LedgerFilter{})
+
+	// Then
+	assert.Nil(t, entries)
+	assert.Equal(t, service.ErrIDLowerThanOne, err)
+}
+
+func Test_Should_Release_Expired_Holds_Until_A_Batch_Is_Not_Full(t *testing.T) {
+	// Given
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var batch int
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	gomock.InOrder(
+		mockRepository.EXPECT().ReleaseExpiredHolds(gomock.Any(), now, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ time.Time, limit int) (int, []ticket.Ticket, error) {
+				batch = limit
+				return limit, nil, nil
+			}).Times(2),
+		mockRepository.EXPECT().ReleaseExpiredHolds(gomock.Any(), now, gomock.Any()).Return(3, nil, nil).Times(1),
+	)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithClock(func() time.Time { return now }))
+
+	// When
+	released, err := ticketService.ReleaseExpiredHolds(context.TODO())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2*batch+3, released)
+}
+
+func Test_Should_Return_What_Was_Released_When_Release_Expired_Holds_Fails(t *testing.T) {
+	// Given
+	repositoryError := errors.New("connection refused")
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	gomock.InOrder(
+		mockRepository.EXPECT().ReleaseExpiredHolds(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ time.Time, limit int) (int, []ticket.Ticket, error) {
+				return limit, nil, nil
+			}).Times(1),
+		mockRepository.EXPECT().ReleaseExpiredHolds(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(0, nil, repositoryError).Times(1),
+	)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	released, err := ticketService.ReleaseExpiredHolds(context.TODO())
+
+	// Then
+	assert.Equal(t, repositoryError, err)
+	assert.Greater(t, released, 0)
+}
+
+func Test_Should_Expire_Pending_Orders_And_Notify_Availability_Until_A_Batch_Is_Not_Full(t *testing.T) {
+	// Given
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	options := []ticket.Ticket{{ID: 2, OrganizerID: 1, Available: 7}}
+
+	var batch int
+
+	controller := gomock.NewController(t)
+	mockRepository := mocks.NewMockRepository(controller)
+	gomock.InOrder(
+		mockRepository.EXPECT().ExpirePendingOrders(gomock.Any(), now, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ time.Time, limit int) (int, []ticket.Ticket, error) {
+				batch = limit
+				return limit, options, nil
+			}).Times(1),
+		mockRepository.EXPECT().ExpirePendingOrders(gomock.Any(), now, gomock.Any()).Return(1, nil, nil).Times(1),
+	)
+
+	mockNotifier := mocks.NewMockAvailabilityNotifier(controller)
+	mockNotifier.EXPECT().NotifyAvailability(options[0]).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository,
+		service.WithClock(func() time.Time { return now }), service.WithAvailabilityNotifier(mockNotifier))
+
+	// When
+	expired, err := ticketService.ExpirePendingOrders(context.TODO())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, batch+1, expired)
+}
+
+func Test_Should_Notify_Availability_Of_Ticket_Options_Holds_Were_Released_To(t *testing.T) {
+	// Given
+	options := []ticket.Ticket{{ID: 1, OrganizerID: 1, Available: 12}, {ID: 4, OrganizerID: 2, Available: 3}}
+
+	controller := gomock.NewController(t)
+	mockRepository := mocks.NewMockRepository(controller)
+	mockRepository.EXPECT().ReleaseExpiredHolds(gomock.Any(), gomock.Any(), gomock.Any()).Return(5, options, nil).Times(1)
+
+	mockNotifier := mocks.NewMockAvailabilityNotifier(controller)
+	mockNotifier.EXPECT().NotifyAvailability(options[0]).Times(1)
+	mockNotifier.EXPECT().NotifyAvailability(options[1]).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithAvailabilityNotifier(mockNotifier))
+
+	// When
+	released, err := ticketService.ReleaseExpiredHolds(context.TODO())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 5, released)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+var (
+	ErrAllocationAdjustmentIsZero = errors.New("allocation adjustment should not be zero")
+	ErrAdjustmentReasonIsEmpty    = errors.New("allocation adjustment needs a reason")
+	ErrAllocationWouldBeNegative  = errors.New("allocation cannot go below zero")
+)
+
+// UpdateTicketOption changes the fields of update that are set, validated as CreateTicketOption
+// validates them. The sale window is checked as it will be after the update.
+func (s *DefaultService) UpdateTicketOption(ctx context.Context, id int, update ticket.TicketUpdate) (*ticket.Ticket, error) {
+	if id < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	if update.Name != nil && *update.Name == "" {
+		return nil, ErrNameIsEmpty
+	}
+
+	if update.Desc != nil && *update.Desc == "" {
+		return nil, ErrDescriptionIsEmpty
+	}
+
+	if update.Price != nil && *update.Price < 0 {
+		return nil, ErrPriceIsNegative
+	}
+
+	if update.SaleStartsAt != nil || update.SaleEndsAt != nil {
+		option, err := s.GetTicket(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		saleStartsAt, saleEndsAt := option.SaleStartsAt, option.SaleEndsAt
+		if update.SaleStartsAt != nil {
+			saleStartsAt = update.SaleStartsAt
+		}
+		if update.SaleEndsAt != nil {
+			saleEndsAt = update.SaleEndsAt
+		}
+
+		if saleStartsAt != nil && saleEndsAt != nil && !saleStartsAt.Before(*saleEndsAt) {
+			return nil, ErrSaleWindowIsInvalid
+		}
+	}
+
+	option, err := s.repository.UpdateTicketOption(ctx, id, update)
+	if err != nil {
+		switch err {
+		case repository.ErrDBTicketNotFound:
+			return nil, ErrTicketWasNotFound
+		case repository.ErrDBDuplicatedTicketName:
+			return nil, ErrNameIsDuplicate
+		default:
+			return nil, err
+		}
+	}
+
+	return option, nil
+}
+
+// AdjustAllocation adds delta, which may be negative, to the tickets left of a ticket option for
+// reason, such as tickets held back for guests.
+func (s *DefaultService) AdjustAllocation(ctx context.Context, id, delta int, reason string) (*ticket.Ticket, error) {
+	if id < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	if delta == 0 {
+		return nil, ErrAllocationAdjustmentIsZero
+	}
+
+	if reason == "" {
+		return nil, ErrAdjustmentReasonIsEmpty
+	}
+
+	option, err := s.repository.AdjustAllocation(ctx, id, delta, reason)
+	if err != nil {
+		switch err {
+		case repository.ErrDBTicketNotFound:
+			return nil, ErrTicketWasNotFound
+		case repository.ErrDBTicketOptionIsSeated:
+			return nil, ErrTicketOptionIsSeated
+		case repository.ErrDBNotEnoughAllocation:
+			return nil, ErrAllocationWouldBeNegative
+		default:
+			return nil, err
+		}
+	}
+
+	s.notifyAvailability(ctx, id)
+
+	return option, nil
+}
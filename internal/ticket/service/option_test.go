@@ -0,0 +1,196 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ticket Option Management Unit Tests
+
+func Test_Should_Return_Error_When_Update_Ticket_Option_Is_Not_Valid(t *testing.T) {
+	empty := ""
+	negative := -1
+	saleStartsAt := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+	saleEndsAt := saleStartsAt.Add(-time.Hour)
+
+	type testCase struct {
+		id            int
+		update        ticket.TicketUpdate
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{id: 0, update: ticket.TicketUpdate{}, expectedError: service.ErrIDLowerThanOne},
+		{id: 1, update: ticket.TicketUpdate{Name: &empty}, expectedError: service.ErrNameIsEmpty},
+		{id: 1, update: ticket.TicketUpdate{Desc: &empty}, expectedError: service.ErrDescriptionIsEmpty},
+		{id: 1, update: ticket.TicketUpdate{Price: &negative}, expectedError: service.ErrPriceIsNegative},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().UpdateTicketOption(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		option, err := ticketService.UpdateTicketOption(context.TODO(), test.id, test.update)
+
+		// Then
+		assert.Nil(t, option)
+		assert.Equal(t, test.expectedError, err)
+	}
+
+	t.Run("sale window as it would be after update", func(t *testing.T) {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, SaleStartsAt: &saleStartsAt}, nil).Times(1)
+		mockRepository.EXPECT().UpdateTicketOption(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		option, err := ticketService.UpdateTicketOption(context.TODO(), 1, ticket.TicketUpdate{SaleEndsAt: &saleEndsAt})
+
+		// Then
+		assert.Nil(t, option)
+		assert.Equal(t, service.ErrSaleWindowIsInvalid, err)
+	})
+}
+
+func Test_Should_Map_Repository_Errors_When_Update_Ticket_Option(t *testing.T) {
+	name := "example"
+
+	type testCase struct {
+		repositoryError error
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{repositoryError: repository.ErrDBTicketNotFound, expectedError: service.ErrTicketWasNotFound},
+		{repositoryError: repository.ErrDBDuplicatedTicketName, expectedError: service.ErrNameIsDuplicate},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().UpdateTicketOption(gomock.Any(), 1, ticket.TicketUpdate{Name: &name}).Return(nil, test.repositoryError).Times(1)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		option, err := ticketService.UpdateTicketOption(context.TODO(), 1, ticket.TicketUpdate{Name: &name})
+
+		// Then
+		assert.Nil(t, option)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Notify_Availability_When_Adjust_Allocation(t *testing.T) {
+	// Given
+	controller := gomock.NewController(t)
+	mockRepository := mocks.NewMockRepository(controller)
+	mockNotifier := mocks.NewMockAvailabilityNotifier(controller)
+
+	gomock.InOrder(
+		mockRepository.EXPECT().AdjustAllocation(gomock.Any(), 1, -20, "held back for guests").Return(&ticket.Ticket{ID: 1, Available: 80}, nil),
+		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 80}, nil),
+		mockNotifier.EXPECT().NotifyAvailability(ticket.Ticket{ID: 1, Available: 80}),
+	)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithAvailabilityNotifier(mockNotifier))
+
+	// When
+	option, err := ticketService.AdjustAllocation(context.TODO(), 1, -20, "held back for guests")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 80, option.Available)
+}
+
+func Test_Should_Return_Error_When_Adjust_Allocation_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		id              int
+		delta           int
+		reason          string
+		repositoryError error
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{id: 0, delta: 1, reason: "reason", expectedError: service.ErrIDLowerThanOne},
+		{id: 1, delta: 0, reason: "reason", expectedError: service.ErrAllocationAdjustmentIsZero},
+		{id: 1, delta: 1, reason: "", expectedError: service.ErrAdjustmentReasonIsEmpty},
+		{id: 1, delta: 1, reason: "reason", repositoryError: repository.ErrDBTicketNotFound, expectedError: service.ErrTicketWasNotFound},
+		{id: 1, delta: 1, reason: "reason", repositoryError: repository.ErrDBTicketOptionIsSeated, expectedError: service.ErrTicketOptionIsSeated},
+		{id: 1, delta: -500, reason: "reason", repositoryError: repository.ErrDBNotEnoughAllocation, expectedError: service.ErrAllocationWouldBeNegative},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		if test.repositoryError != nil {
+			mockRepository.EXPECT().AdjustAllocation(gomock.Any(), test.id, test.delta, test.reason).Return(nil, test.repositoryError).Times(1)
+		}
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		option, err := ticketService.AdjustAllocation(context.TODO(), test.id, test.delta, test.reason)
+
+		// Then
+		assert.Nil(t, option)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Return_Error_When_Purchase_Was_Not_Found(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetPurchase(gomock.Any(), 9).Return(nil, repository.ErrDBPurchaseNotFound).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	purchase, err := ticketService.GetPurchase(context.TODO(), 9)
+
+	// Then
+	assert.Nil(t, purchase)
+	assert.Equal(t, service.ErrPurchaseWasNotFound, err)
+}
+
+func Test_Should_Normalize_Filter_When_List_Purchases(t *testing.T) {
+	type testCase struct {
+		filter         ticket.PurchaseFilter
+		expectedFilter ticket.PurchaseFilter
+	}
+
+	testCases := []testCase{
+		{filter: ticket.PurchaseFilter{}, expectedFilter: ticket.PurchaseFilter{Limit: service.DefaultListLimit}},
+		{filter: ticket.PurchaseFilter{Limit: 1000, AfterID: -3}, expectedFilter: ticket.PurchaseFilter{Limit: service.MaxListLimit}},
+		{filter: ticket.PurchaseFilter{Limit: 10, TicketID: 2, UserID: "user"}, expectedFilter: ticket.PurchaseFilter{Limit: 10, TicketID: 2, UserID: "user"}},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().ListPurchases(gomock.Any(), test.expectedFilter).Return(nil, nil).Times(1)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		_, err := ticketService.ListPurchases(context.TODO(), test.filter)
+
+		// Then
+		assert.Nil(t, err)
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/pricing"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+var (
+	ErrOrderHasNoItems        = errors.New("order should have at least one item")
+	ErrOrderItemIsDuplicated  = errors.New("order should have one item per ticket option")
+	ErrOrderWasNotFound       = errors.New("order does not exist")
+	ErrOrderStatusConflict    = errors.New("order cannot change to that status")
+	ErrOrderExpired           = errors.New("order was not paid in time")
+	ErrPurchaseBelongsToOrder = errors.New("purchase is an item of an order, refund the order instead")
+)
+
+// CreateOrder places a pending order of userID for all of items or, if any of them cannot be
+// purchased, for none. Items are priced when the order is placed, and their tickets are held for
+// the order until it is paid, cancelled or expires.
+func (s *DefaultService) CreateOrder(ctx context.Context, userID string, items []ticket.OrderItem) (*ticket.Order, error) {
+	if userID == "" {
+		return nil, ErrUserIDIsEmpty
+	}
+
+	if len(items) == 0 {
+		return nil, ErrOrderHasNoItems
+	}
+
+	quotes := make(map[int]ticket.PriceQuoter, len(items))
+	for _, item := range items {
+		if item.Quantity < 1 {
+			return nil, ErrQuantityLowerThanOne
+		}
+
+		if _, ok := quotes[item.TicketID]; ok {
+			return nil, ErrOrderItemIsDuplicated
+		}
+
+		option, err := s.GetTicket(ctx, item.TicketID)
+		if err != nil {
+			return nil, err
+		}
+
+		if option.Seated {
+			return nil, ErrTicketOptionIsSeated
+		}
+
+		if err = s.checkOnSale(option); err != nil {
+			return nil, err
+		}
+
+		if option.Available < item.Quantity {
+			return nil, ErrPurchaseTicketMoreThanAvailable
+		}
+
+		quantity := item.Quantity
+		quotes[item.TicketID] = func(sold int) ticket.PriceQuote {
+			return pricing.Quote(*option, sold, quantity, s.now())
+		}
+	}
+
+	order, err := s.repository.CreateOrder(ctx, userID, items, quotes, s.now().Add(s.orderTTL))
+	if err != nil {
+		switch err {
+		case repository.ErrDBTicketNotFound:
+			return nil, ErrTicketWasNotFound
+		case repository.ErrDBTicketOptionIsSeated:
+			return nil, ErrTicketOptionIsSeated
+		case repository.ErrDBNotEnoughAllocation:
+			return nil, ErrPurchaseTicketMoreThanAvailable
+		default:
+			return nil, err
+		}
+	}
+
+	s.notifyOrderAvailability(ctx, order)
+
+	return order, nil
+}
+
+func (s *DefaultService) GetOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	if id < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	order, err := s.repository.GetOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrDBOrderNotFound) {
+			return nil, ErrOrderWasNotFound
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// PayOrder marks a pending order that has not expired as paid, and sells and issues the tickets of
+// all its items.
+func (s *DefaultService) PayOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	order, err := s.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.Status.CanBecome(ticket.OrderPaid) {
+		return nil, ErrOrderStatusConflict
+	}
+
+	if order.ExpiresAt != nil && !s.now().Before(*order.ExpiresAt) {
+		return nil, ErrOrderExpired
+	}
+
+	codes := make(map[int][]string, len(order.Items))
+	for _, item := range order.Items {
+		codes[item.ID] = make([]string, item.Quantity)
+		for i := range codes[item.ID] {
+			if codes[item.ID][i], err = s.issuer.Issue(item.TicketID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	paid, err := s.repository.PayOrder(ctx, id, codes)
+	if err != nil {
+		return nil, toOrderError(err)
+	}
+
+	return paid, nil
+}
+
+// CancelOrder gives the tickets held for a pending order back to their ticket options.
+func (s *DefaultService) CancelOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	if id < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	order, err := s.repository.CancelOrder(ctx, id)
+	if err != nil {
+		return nil, toOrderError(err)
+	}
+
+	s.notifyOrderAvailability(ctx, order)
+
+	return order, nil
+}
+
+// RefundOrder gives the tickets of a paid order back to their ticket options and voids them.
+func (s *DefaultService) RefundOrder(ctx context.Context, id int) (*ticket.Order, error) {
+	if id < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	order, err := s.repository.RefundOrder(ctx, id)
+	if err != nil {
+		return nil, toOrderError(err)
+	}
+
+	s.notifyOrderAvailability(ctx, order)
+
+	return order, nil
+}
+
+func (s *DefaultService) notifyOrderAvailability(ctx context.Context, order *ticket.Order) {
+	for _, item := range order.Items {
+		s.notifyAvailability(ctx, item.TicketID)
+	}
+}
+
+func toOrderError(err error) error {
+	switch err {
+	case repository.ErrDBOrderNotFound:
+		return ErrOrderWasNotFound
+	case repository.ErrDBOrderStatusConflict:
+		return ErrOrderStatusConflict
+	default:
+		return err
+	}
+}
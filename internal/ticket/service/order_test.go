@@ -0,0 +1,215 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Order Unit Tests
+
+func Test_Should_Create_Order_Priced_By_Each_Ticket_Option(t *testing.T) {
+	// Given
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	items := []ticket.OrderItem{{TicketID: 1, Quantity: 2}, {TicketID: 2, Quantity: 1}}
+	expectedOrder := &ticket.Order{ID: 1, UserID: "test", Status: ticket.OrderPending, TotalPrice: 2500, Items: []ticket.OrderItem{
+		{ID: 1, OrderID: 1, TicketID: 1, Quantity: 2, TotalPrice: 2000, PurchaseID: 1},
+		{ID: 2, OrderID: 1, TicketID: 2, Quantity: 1, TotalPrice: 500, PurchaseID: 2},
+	}}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 10, Price: 1000}, nil).Times(1)
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 2).Return(&ticket.Ticket{ID: 2, Available: 10, Price: 500}, nil).Times(1)
+	mockRepository.EXPECT().CreateOrder(gomock.Any(), "test", items, gomock.Any(), now.Add(time.Minute)).
+		DoAndReturn(func(_ context.Context, _ string, _ []ticket.OrderItem, quotes map[int]ticket.PriceQuoter,
+			_ time.Time) (*ticket.Order, error) {
+			assert.Equal(t, 2000, quotes[1](0).Total)
+			assert.Equal(t, 500, quotes[2](0).Total)
+			return expectedOrder, nil
+		}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository,
+		service.WithClock(func() time.Time { return now }), service.WithOrderTTL(time.Minute))
+
+	// When
+	order, err := ticketService.CreateOrder(context.TODO(), "test", items)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOrder, order)
+}
+
+func Test_Should_Return_Error_When_Create_Order_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		userID          string
+		items           []ticket.OrderItem
+		options         []*ticket.Ticket
+		repositoryError error
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{userID: "", items: []ticket.OrderItem{{TicketID: 1, Quantity: 1}}, expectedError: service.ErrUserIDIsEmpty},
+		{userID: "test", items: nil, expectedError: service.ErrOrderHasNoItems},
+		{userID: "test", items: []ticket.OrderItem{{TicketID: 1, Quantity: 0}}, expectedError: service.ErrQuantityLowerThanOne},
+		{
+			userID:        "test",
+			items:         []ticket.OrderItem{{TicketID: 1, Quantity: 1}, {TicketID: 1, Quantity: 1}},
+			options:       []*ticket.Ticket{{ID: 1, Available: 10}},
+			expectedError: service.ErrOrderItemIsDuplicated,
+		},
+		{
+			userID:        "test",
+			items:         []ticket.OrderItem{{TicketID: 1, Quantity: 1}},
+			options:       []*ticket.Ticket{{ID: 1, Available: 10, Seated: true}},
+			expectedError: service.ErrTicketOptionIsSeated,
+		},
+		{
+			userID:        "test",
+			items:         []ticket.OrderItem{{TicketID: 1, Quantity: 1}, {TicketID: 2, Quantity: 3}},
+			options:       []*ticket.Ticket{{ID: 1, Available: 10}, {ID: 2, Available: 2}},
+			expectedError: service.ErrPurchaseTicketMoreThanAvailable,
+		},
+		{
+			userID:          "test",
+			items:           []ticket.OrderItem{{TicketID: 1, Quantity: 1}, {TicketID: 2, Quantity: 2}},
+			options:         []*ticket.Ticket{{ID: 1, Available: 10}, {ID: 2, Available: 2}},
+			repositoryError: repository.ErrDBNotEnoughAllocation,
+			expectedError:   service.ErrPurchaseTicketMoreThanAvailable,
+		},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		for _, option := range test.options {
+			mockRepository.EXPECT().GetTicket(gomock.Any(), option.ID).Return(option, nil).Times(1)
+		}
+		if test.repositoryError != nil {
+			mockRepository.EXPECT().CreateOrder(gomock.Any(), test.userID, test.items, gomock.Any(), gomock.Any()).
+				Return(nil, test.repositoryError).Times(1)
+		} else {
+			mockRepository.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		}
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		order, err := ticketService.CreateOrder(context.TODO(), test.userID, test.items)
+
+		// Then
+		assert.Nil(t, order)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Issue_A_Code_Per_Ticket_When_Pay_Order(t *testing.T) {
+	// Given
+	pending := &ticket.Order{ID: 1, UserID: "test", Status: ticket.OrderPending, Items: []ticket.OrderItem{
+		{ID: 3, OrderID: 1, TicketID: 1, Quantity: 2, PurchaseID: 5},
+		{ID: 4, OrderID: 1, TicketID: 2, Quantity: 1, PurchaseID: 6},
+	}}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	gomock.InOrder(
+		mockRepository.EXPECT().GetOrder(gomock.Any(), 1).Return(pending, nil),
+		mockRepository.EXPECT().PayOrder(gomock.Any(), 1, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int, codes map[int][]string) (*ticket.Order, error) {
+				assert.Len(t, codes[3], 2)
+				assert.Len(t, codes[4], 1)
+				return &ticket.Order{ID: 1, Status: ticket.OrderPaid}, nil
+			}),
+	)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	order, err := ticketService.PayOrder(context.TODO(), 1)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, ticket.OrderPaid, order.Status)
+}
+
+func Test_Should_Return_Error_When_Pay_Expired_Order(t *testing.T) {
+	// Given
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(-time.Second)
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetOrder(gomock.Any(), 1).Return(&ticket.Order{ID: 1, Status: ticket.OrderPending, ExpiresAt: &expiresAt,
+		Items: []ticket.OrderItem{{ID: 3, OrderID: 1, TicketID: 1, Quantity: 1, PurchaseID: 5}}}, nil).Times(1)
+	mockRepository.EXPECT().PayOrder(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithClock(func() time.Time { return now }))
+
+	// When
+	order, err := ticketService.PayOrder(context.TODO(), 1)
+
+	// Then
+	assert.Nil(t, order)
+	assert.Equal(t, service.ErrOrderExpired, err)
+}
+
+func Test_Should_Return_Error_When_Order_Cannot_Change_Status(t *testing.T) {
+	type testCase struct {
+		status   ticket.OrderStatus
+		next     ticket.OrderStatus
+		expected bool
+	}
+
+	testCases := []testCase{
+		{status: ticket.OrderPending, next: ticket.OrderPaid, expected: true},
+		{status: ticket.OrderPending, next: ticket.OrderCancelled, expected: true},
+		{status: ticket.OrderPending, next: ticket.OrderRefunded, expected: false},
+		{status: ticket.OrderPaid, next: ticket.OrderRefunded, expected: true},
+		{status: ticket.OrderPaid, next: ticket.OrderCancelled, expected: false},
+		{status: ticket.OrderPaid, next: ticket.OrderPaid, expected: false},
+		{status: ticket.OrderCancelled, next: ticket.OrderPaid, expected: false},
+		{status: ticket.OrderRefunded, next: ticket.OrderPaid, expected: false},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, test.status.CanBecome(test.next), "%s to %s", test.status, test.next)
+	}
+
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetOrder(gomock.Any(), 1).Return(&ticket.Order{ID: 1, Status: ticket.OrderCancelled}, nil).Times(1)
+	mockRepository.EXPECT().PayOrder(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockRepository.EXPECT().RefundOrder(gomock.Any(), 2).Return(nil, repository.ErrDBOrderStatusConflict).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	paid, payErr := ticketService.PayOrder(context.TODO(), 1)
+	refunded, refundErr := ticketService.RefundOrder(context.TODO(), 2)
+
+	// Then
+	assert.Nil(t, paid)
+	assert.Equal(t, service.ErrOrderStatusConflict, payErr)
+	assert.Nil(t, refunded)
+	assert.Equal(t, service.ErrOrderStatusConflict, refundErr)
+}
+
+func Test_Should_Return_Error_When_Refund_Purchase_Of_Order(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().RefundPurchase(gomock.Any(), 1).Return(nil, repository.ErrDBPurchaseInOrder).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	purchase, err := ticketService.RefundPurchase(context.TODO(), 1)
+
+	// Then
+	assert.Nil(t, purchase)
+	assert.Equal(t, service.ErrPurchaseBelongsToOrder, err)
+}
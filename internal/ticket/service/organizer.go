@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+)
+
+var (
+	ErrOrganizerNameIsEmpty     = errors.New("organizer name should not be empty")
+	ErrOrganizerNameIsDuplicate = errors.New("organizer name exists already")
+	ErrOrganizerWasNotFound     = errors.New("organizer does not exist")
+	ErrOrganizerTokenIsEmpty    = errors.New("organizer token should not be empty")
+)
+
+// CreateOrganizer adds an organizer and returns it with the bearer token it authenticates with. The
+// token cannot be read back later.
+func (s *DefaultService) CreateOrganizer(ctx context.Context, name string) (*ticket.Organizer, string, error) {
+	if name == "" {
+		return nil, "", ErrOrganizerNameIsEmpty
+	}
+
+	token, err := tenant.NewToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	organizer, err := s.repository.CreateOrganizer(ctx, name, tenant.HashToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrDBDuplicatedOrganizerName) {
+			return nil, "", ErrOrganizerNameIsDuplicate
+		}
+		return nil, "", err
+	}
+
+	return organizer, token, nil
+}
+
+// IssueOrganizerToken gives the organizer with id a new bearer token and returns it. The token it
+// had before, if any, stops authenticating it. Organizers made before there were tokens, such as the
+// default one, have none until one is issued.
+func (s *DefaultService) IssueOrganizerToken(ctx context.Context, id int) (*ticket.Organizer, string, error) {
+	if id < 1 {
+		return nil, "", ErrIDLowerThanOne
+	}
+
+	token, err := tenant.NewToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	organizer, err := s.repository.SetOrganizerTokenHash(ctx, id, tenant.HashToken(token))
+	if err != nil {
+		return nil, "", toOrganizerError(err)
+	}
+
+	return organizer, token, nil
+}
+
+func (s *DefaultService) GetOrganizer(ctx context.Context, id int) (*ticket.Organizer, error) {
+	if id < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	organizer, err := s.repository.GetOrganizer(ctx, id)
+	if err != nil {
+		return nil, toOrganizerError(err)
+	}
+
+	return organizer, nil
+}
+
+// AuthenticateOrganizer returns the organizer token was issued to.
+func (s *DefaultService) AuthenticateOrganizer(ctx context.Context, token string) (*ticket.Organizer, error) {
+	if token == "" {
+		return nil, ErrOrganizerTokenIsEmpty
+	}
+
+	organizer, err := s.repository.GetOrganizerByTokenHash(ctx, tenant.HashToken(token))
+	if err != nil {
+		return nil, toOrganizerError(err)
+	}
+
+	return organizer, nil
+}
+
+func toOrganizerError(err error) error {
+	if errors.Is(err, repository.ErrDBOrganizerNotFound) {
+		return ErrOrganizerWasNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Organizer Unit Tests
+
+func Test_Should_Store_Only_Hash_Of_Token_When_Create_Organizer(t *testing.T) {
+	// Given
+	var storedHash string
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().CreateOrganizer(gomock.Any(), "promoter", gomock.Any()).
+		DoAndReturn(func(_ context.Context, name, tokenHash string) (*ticket.Organizer, error) {
+			storedHash = tokenHash
+			return &ticket.Organizer{ID: 2, Name: name, TokenHash: tokenHash}, nil
+		}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	organizer, token, err := ticketService.CreateOrganizer(context.TODO(), "promoter")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, organizer.ID)
+	assert.NotEmpty(t, token)
+	assert.NotEqual(t, token, storedHash)
+	assert.Equal(t, tenant.HashToken(token), storedHash)
+}
+
+func Test_Should_Return_Error_When_Create_Organizer_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		name            string
+		repositoryError error
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{name: "", expectedError: service.ErrOrganizerNameIsEmpty},
+		{name: "promoter", repositoryError: repository.ErrDBDuplicatedOrganizerName, expectedError: service.ErrOrganizerNameIsDuplicate},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		if test.repositoryError != nil {
+			mockRepository.EXPECT().CreateOrganizer(gomock.Any(), test.name, gomock.Any()).Return(nil, test.repositoryError).Times(1)
+		}
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		organizer, token, err := ticketService.CreateOrganizer(context.TODO(), test.name)
+
+		// Then
+		assert.Nil(t, organizer)
+		assert.Empty(t, token)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Authenticate_Organizer_By_Hash_Of_Token(t *testing.T) {
+	// Given
+	expected := &ticket.Organizer{ID: 2, Name: "promoter"}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetOrganizerByTokenHash(gomock.Any(), tenant.HashToken("secret-token")).Return(expected, nil).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	organizer, err := ticketService.AuthenticateOrganizer(context.TODO(), "secret-token")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, expected, organizer)
+}
+
+func Test_Should_Return_Error_When_Organizer_Is_Unknown(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetOrganizerByTokenHash(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDBOrganizerNotFound).Times(1)
+	mockRepository.EXPECT().GetOrganizer(gomock.Any(), 9).Return(nil, repository.ErrDBOrganizerNotFound).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	_, tokenErr := ticketService.AuthenticateOrganizer(context.TODO(), "unknown-token")
+	_, emptyErr := ticketService.AuthenticateOrganizer(context.TODO(), "")
+	_, idErr := ticketService.GetOrganizer(context.TODO(), 9)
+
+	// Then
+	assert.Equal(t, service.ErrOrganizerWasNotFound, tokenErr)
+	assert.Equal(t, service.ErrOrganizerTokenIsEmpty, emptyErr)
+	assert.Equal(t, service.ErrOrganizerWasNotFound, idErr)
+}
+
+func Test_Should_Issue_New_Token_To_Existing_Organizer(t *testing.T) {
+	// Given
+	var storedHash string
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().SetOrganizerTokenHash(gomock.Any(), ticket.DefaultOrganizerID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, id int, tokenHash string) (*ticket.Organizer, error) {
+			storedHash = tokenHash
+			return &ticket.Organizer{ID: id, Name: "default", TokenHash: tokenHash}, nil
+		}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	organizer, token, err := ticketService.IssueOrganizerToken(context.TODO(), ticket.DefaultOrganizerID)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, ticket.DefaultOrganizerID, organizer.ID)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, tenant.HashToken(token), storedHash)
+}
+
+func Test_Should_Return_Error_When_Issue_Organizer_Token_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		id              int
+		repositoryError error
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{id: 0, expectedError: service.ErrIDLowerThanOne},
+		{id: 9, repositoryError: repository.ErrDBOrganizerNotFound, expectedError: service.ErrOrganizerWasNotFound},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		if test.repositoryError != nil {
+			mockRepository.EXPECT().SetOrganizerTokenHash(gomock.Any(), test.id, gomock.Any()).Return(nil, test.repositoryError).Times(1)
+		}
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		organizer, token, err := ticketService.IssueOrganizerToken(context.TODO(), test.id)
+
+		// Then
+		assert.Nil(t, organizer)
+		assert.Empty(t, token)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
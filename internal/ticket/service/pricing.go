@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/pricing"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+// QuotePrice prices quantity tickets of the ticket option as a purchase made now would be. The quote
+// is not held: a purchase is priced again when it is made.
+func (s *DefaultService) QuotePrice(ctx context.Context, id, quantity int) (*ticket.PriceQuote, error) {
+	if quantity < 1 {
+		return nil, ErrQuantityLowerThanOne
+	}
+
+	option, err := s.GetTicket(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if option.Available < quantity {
+		return nil, ErrPurchaseTicketMoreThanAvailable
+	}
+
+	sold, err := s.repository.CountSoldTickets(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := pricing.Quote(*option, sold, quantity, s.now())
+
+	return &quote, nil
+}
+
+func (s *DefaultService) CreatePriceTier(ctx context.Context, ticketID int, tier ticket.PriceTier) (*ticket.PriceTier, error) {
+	if tier.Price < 0 {
+		return nil, ErrPriceIsNegative
+	}
+
+	switch tier.Kind {
+	case ticket.PriceTierEarlyBird:
+		if tier.Until == nil {
+			return nil, ErrPriceTierIsIncomplete
+		}
+	case ticket.PriceTierSoldStep:
+		if tier.AfterSold < 1 {
+			return nil, ErrPriceTierIsIncomplete
+		}
+	case ticket.PriceTierLastMinute:
+		if tier.From == nil {
+			return nil, ErrPriceTierIsIncomplete
+		}
+	default:
+		return nil, ErrPriceTierKindIsUnknown
+	}
+
+	if _, err := s.GetTicket(ctx, ticketID); err != nil {
+		return nil, err
+	}
+
+	tier.ID = 0
+	tier.TicketID = ticketID
+
+	return s.repository.CreatePriceTier(ctx, tier)
+}
+
+func (s *DefaultService) DeletePriceTier(ctx context.Context, ticketID, tierID int) error {
+	if ticketID < 1 || tierID < 1 {
+		return ErrIDLowerThanOne
+	}
+
+	if err := s.repository.DeletePriceTier(ctx, ticketID, tierID); err != nil {
+		if err == repository.ErrDBPriceTierNotFound {
+			return ErrPriceTierWasNotFound
+		}
+		return err
+	}
+
+	return nil
+}
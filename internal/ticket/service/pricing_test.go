@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Pricing Unit Tests
+
+func Test_Should_Quote_Price_From_Tickets_Sold_So_Far(t *testing.T) {
+	// Given
+	option := ticket.Ticket{ID: 1, Available: 10, Price: 1000, PriceTiers: []ticket.PriceTier{
+		{Kind: ticket.PriceTierSoldStep, Price: 1500, AfterSold: 5},
+	}}
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&option, nil).Times(1)
+	mockRepository.EXPECT().CountSoldTickets(gomock.Any(), 1).Return(4, nil).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	quote, err := ticketService.QuotePrice(context.TODO(), 1, 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1000, 1500}, quote.UnitPrices)
+	assert.Equal(t, 2500, quote.Total)
+}
+
+func Test_Should_Price_Purchase_With_Tiers_Of_Ticket_Option(t *testing.T) {
+	// Given
+	option := ticket.Ticket{ID: 1, Available: 10, Price: 1000, PriceTiers: []ticket.PriceTier{
+		{Kind: ticket.PriceTierSoldStep, Price: 1500, AfterSold: 5},
+	}}
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&option, nil).Times(1)
+	mockRepository.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 3, "test", gomock.Len(3), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id, quantity int, userID string, _ []string, quote ticket.PriceQuoter) (*ticket.Purchase, error) {
+			return &ticket.Purchase{ID: 7, TicketID: id, Quantity: quantity, UserID: userID, TotalPrice: quote(4).Total}, nil
+		}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	purchase, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 3, "test")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 4000, purchase.TotalPrice)
+}
+
+func Test_Should_Return_Error_When_Price_Tier_Is_Invalid(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	type testCase struct {
+		tier          ticket.PriceTier
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{tier: ticket.PriceTier{Kind: "happy_hour", Price: 500}, expectedError: service.ErrPriceTierKindIsUnknown},
+		{tier: ticket.PriceTier{Kind: ticket.PriceTierEarlyBird, Price: -1, Until: &tomorrow}, expectedError: service.ErrPriceIsNegative},
+		{tier: ticket.PriceTier{Kind: ticket.PriceTierEarlyBird, Price: 500}, expectedError: service.ErrPriceTierIsIncomplete},
+		{tier: ticket.PriceTier{Kind: ticket.PriceTierSoldStep, Price: 500}, expectedError: service.ErrPriceTierIsIncomplete},
+		{tier: ticket.PriceTier{Kind: ticket.PriceTierLastMinute, Price: 500}, expectedError: service.ErrPriceTierIsIncomplete},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().CreatePriceTier(gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		tier, err := ticketService.CreatePriceTier(context.TODO(), 1, test.tier)
+
+		// Then
+		assert.Nil(t, tier)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+)
+
+var (
+	ErrReportPeriodIsInvalid   = errors.New("report period should end after it starts")
+	ErrReportIntervalIsUnknown = errors.New("report interval is unknown")
+)
+
+// StreamSalesReport calls emit with each row of the sales report selected by filter as it is read,
+// so that large reports are never held in memory, except on SQLite. It stops at the first error emit
+// returns.
+func (s *DefaultService) StreamSalesReport(ctx context.Context, filter ticket.SalesFilter,
+	emit func(ticket.SalesRow) error) error {
+	if filter.TicketID < 0 {
+		return ErrIDLowerThanOne
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return ErrReportPeriodIsInvalid
+	}
+
+	switch filter.Interval {
+	case ticket.SalesTotal, ticket.SalesByDay, ticket.SalesByHour:
+	default:
+		return ErrReportIntervalIsUnknown
+	}
+
+	return s.repository.StreamSalesReport(ctx, filter, func(row ticket.SalesRow) error {
+		if row.Capacity > 0 {
+			row.SellThrough = float64(row.Sold-row.Refunded) * 100 / float64(row.Capacity) //nolint:gomnd
+		}
+
+		return emit(row)
+	})
+}
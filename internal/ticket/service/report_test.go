@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Sales Report Unit Tests
+
+func Test_Should_Work_Out_Sell_Through_Of_Each_Row(t *testing.T) {
+	// Given
+	filter := ticket.SalesFilter{Interval: ticket.SalesByDay}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().StreamSalesReport(gomock.Any(), filter, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ ticket.SalesFilter, emit func(ticket.SalesRow) error) error {
+			if err := emit(ticket.SalesRow{TicketID: 1, Sold: 30, Refunded: 5, Remaining: 75, Capacity: 100}); err != nil {
+				return err
+			}
+			return emit(ticket.SalesRow{TicketID: 2, Remaining: 0, Capacity: 0})
+		}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	var rows []ticket.SalesRow
+	err := ticketService.StreamSalesReport(context.TODO(), filter, func(row ticket.SalesRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 25.0, rows[0].SellThrough)
+	assert.Equal(t, 0.0, rows[1].SellThrough)
+}
+
+func Test_Should_Return_Error_When_Sales_Filter_Is_Invalid(t *testing.T) {
+	from := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	type testCase struct {
+		filter        ticket.SalesFilter
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{filter: ticket.SalesFilter{TicketID: -1}, expectedError: service.ErrIDLowerThanOne},
+		{filter: ticket.SalesFilter{From: &from, To: &to}, expectedError: service.ErrReportPeriodIsInvalid},
+		{filter: ticket.SalesFilter{Interval: "week"}, expectedError: service.ErrReportIntervalIsUnknown},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().StreamSalesReport(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		err := ticketService.StreamSalesReport(context.TODO(), test.filter, func(ticket.SalesRow) error { return nil })
+
+		// Then
+		assert.Equal(t, test.expectedError, err)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+// CreateResaleListing lets the owner of the ticket with code offer it at price, which may not exceed
+// the resale price cap applied to its face value: the unit price its purchase was made at, as
+// tiers and dynamic pricing may have moved the price of the ticket option since.
+func (s *DefaultService) CreateResaleListing(ctx context.Context, code, sellerID string, price int) (*ticket.ResaleListing, error) {
+	if sellerID == "" {
+		return nil, ErrUserIDIsEmpty
+	}
+
+	if price < 0 {
+		return nil, ErrPriceIsNegative
+	}
+
+	issued, err := s.GetIssuedTicket(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	purchase, err := s.GetPurchase(ctx, issued.PurchaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if price > purchase.TotalPrice*s.resalePriceCap/(100*purchase.Quantity) {
+		return nil, ErrResalePriceAboveCap
+	}
+
+	listing, err := s.repository.CreateResaleListing(ctx, code, sellerID, price)
+	if err != nil {
+		return nil, toResaleError(err)
+	}
+
+	return listing, nil
+}
+
+func (s *DefaultService) ListResaleListings(ctx context.Context, ticketID int) ([]ticket.ResaleListing, error) {
+	if _, err := s.GetTicket(ctx, ticketID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListResaleListings(ctx, ticketID)
+}
+
+// BuyResaleListing sells the listing to buyerID. The ticket is transferred exactly like a gift, so
+// the same cutoff applies and the seller's code stops working.
+func (s *DefaultService) BuyResaleListing(ctx context.Context, listingID int, buyerID string) (*ticket.ResaleListing, error) {
+	if listingID < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	if buyerID == "" {
+		return nil, ErrUserIDIsEmpty
+	}
+
+	listing, err := s.repository.GetResaleListing(ctx, listingID)
+	if err != nil {
+		return nil, toResaleError(err)
+	}
+
+	if listing.Status != ticket.ResaleListingListed {
+		return nil, ErrResaleListingNotAvailable
+	}
+
+	if err = s.checkTransferable(ctx, listing.IssuedTicket, buyerID); err != nil {
+		return nil, err
+	}
+
+	newCode, err := s.issuer.Issue(listing.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	sold, err := s.repository.BuyResaleListing(ctx, listingID, buyerID, newCode)
+	if err != nil {
+		return nil, toResaleError(err)
+	}
+
+	return sold, nil
+}
+
+func (s *DefaultService) CancelResaleListing(ctx context.Context, listingID int, sellerID string) (*ticket.ResaleListing, error) {
+	if listingID < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	listing, err := s.repository.CancelResaleListing(ctx, listingID, sellerID)
+	if err != nil {
+		return nil, toResaleError(err)
+	}
+
+	return listing, nil
+}
+
+func toResaleError(err error) error {
+	switch err {
+	case repository.ErrDBIssuedTicketNotFound:
+		return ErrIssuedTicketWasNotFound
+	case repository.ErrDBIssuedTicketAlreadyUsed:
+		return ErrIssuedTicketAlreadyUsed
+	case repository.ErrDBIssuedTicketRefunded:
+		return ErrIssuedTicketRefunded
+	case repository.ErrDBIssuedTicketNotOwned:
+		return ErrIssuedTicketNotOwned
+	case repository.ErrDBResaleListingNotFound:
+		return ErrResaleListingWasNotFound
+	case repository.ErrDBResaleListingExists:
+		return ErrResaleListingAlreadyExists
+	case repository.ErrDBResaleListingNotAvailable:
+		return ErrResaleListingNotAvailable
+	default:
+		return err
+	}
+}
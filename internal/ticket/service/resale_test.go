@@ -0,0 +1,143 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketcode"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Resale Unit Tests
+
+func Test_Should_Cap_Resale_Price_At_Percentage_Of_Price_Paid(t *testing.T) {
+	type testCase struct {
+		price         int
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{price: 1000, expectedError: nil},
+		{price: 1100, expectedError: nil},
+		{price: 1101, expectedError: service.ErrResalePriceAboveCap},
+	}
+
+	for _, test := range testCases {
+		// Given
+		issued := &ticket.IssuedTicket{ID: 1, Code: "code", PurchaseID: 5, TicketID: 1, UserID: "alice",
+			Status: ticket.IssuedTicketValid}
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().GetIssuedTicket(gomock.Any(), "code").Return(issued, nil).Times(1)
+		// Two tickets bought for 2000 in total, whatever the ticket option costs now.
+		mockRepository.EXPECT().GetPurchase(gomock.Any(), 5).
+			Return(&ticket.Purchase{ID: 5, TicketID: 1, Quantity: 2, TotalPrice: 2000}, nil).Times(1)
+		if test.expectedError == nil {
+			mockRepository.EXPECT().CreateResaleListing(gomock.Any(), "code", "alice", test.price).
+				Return(&ticket.ResaleListing{ID: 1, Price: test.price}, nil).Times(1)
+		}
+
+		ticketService := service.NewDefaultService(mockRepository, service.WithResalePriceCap(110))
+
+		// When
+		_, err := ticketService.CreateResaleListing(context.TODO(), "code", "alice", test.price)
+
+		// Then
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Return_Error_When_Listing_Ticket_Of_Another_User(t *testing.T) {
+	// Given
+	issued := &ticket.IssuedTicket{ID: 1, Code: "code", PurchaseID: 5, TicketID: 1, UserID: "alice",
+		Status: ticket.IssuedTicketValid}
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetIssuedTicket(gomock.Any(), "code").Return(issued, nil).Times(1)
+	mockRepository.EXPECT().GetPurchase(gomock.Any(), 5).
+		Return(&ticket.Purchase{ID: 5, TicketID: 1, Quantity: 1, TotalPrice: 1000}, nil).Times(1)
+	mockRepository.EXPECT().CreateResaleListing(gomock.Any(), "code", "mallory", 500).
+		Return(nil, repository.ErrDBIssuedTicketNotOwned).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	actual, err := ticketService.CreateResaleListing(context.TODO(), "code", "mallory", 500)
+
+	// Then
+	assert.Nil(t, actual)
+	assert.Equal(t, service.ErrIssuedTicketNotOwned, err)
+}
+
+func Test_Should_Move_Ticket_To_Buyer_Under_A_New_Code_When_Buy_Resale_Listing(t *testing.T) {
+	// Given
+	signer := ticketcode.NewRandomSigner()
+	issued := &ticket.IssuedTicket{ID: 1, Code: "old", TicketID: 1, UserID: "alice", Status: ticket.IssuedTicketValid}
+	listing := &ticket.ResaleListing{ID: 3, IssuedTicketID: 1, TicketID: 1, SellerID: "alice", Price: 900,
+		Status: ticket.ResaleListingListed, IssuedTicket: issued}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetResaleListing(gomock.Any(), 3).Return(listing, nil).Times(1)
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Price: 1000}, nil).Times(1)
+	mockRepository.EXPECT().BuyResaleListing(gomock.Any(), 3, "bob", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, buyerID, code string) (*ticket.ResaleListing, error) {
+			claims, err := signer.Verify(code)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, claims.TicketID)
+
+			return &ticket.ResaleListing{ID: 3, BuyerID: buyerID, Status: ticket.ResaleListingSold,
+				IssuedTicket: &ticket.IssuedTicket{ID: 1, Code: code, UserID: buyerID}}, nil
+		}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithCodeIssuer(signer))
+
+	// When
+	actual, err := ticketService.BuyResaleListing(context.TODO(), 3, "bob")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, ticket.ResaleListingSold, actual.Status)
+	assert.Equal(t, "bob", actual.IssuedTicket.UserID)
+}
+
+func Test_Should_Return_Error_When_Buy_Resale_Listing_Is_Not_Available(t *testing.T) {
+	issued := &ticket.IssuedTicket{ID: 1, Code: "old", TicketID: 1, UserID: "alice", Status: ticket.IssuedTicketValid}
+
+	type testCase struct {
+		buyerID       string
+		listing       *ticket.ResaleListing
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{
+			buyerID:       "bob",
+			listing:       &ticket.ResaleListing{ID: 3, TicketID: 1, SellerID: "alice", Status: ticket.ResaleListingSold, IssuedTicket: issued},
+			expectedError: service.ErrResaleListingNotAvailable,
+		},
+		{
+			buyerID:       "alice",
+			listing:       &ticket.ResaleListing{ID: 3, TicketID: 1, SellerID: "alice", Status: ticket.ResaleListingListed, IssuedTicket: issued},
+			expectedError: service.ErrTransferToSameUser,
+		},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().GetResaleListing(gomock.Any(), 3).Return(test.listing, nil).Times(1)
+		mockRepository.EXPECT().BuyResaleListing(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		actual, err := ticketService.BuyResaleListing(context.TODO(), 3, test.buyerID)
+
+		// Then
+		assert.Nil(t, actual)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/pricing"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/seating"
+)
+
+var (
+	ErrSeatMapLayoutIsInvalid  = errors.New("seat map needs named sections and rows of at least one seat")
+	ErrSeatMapAlreadyExists    = errors.New("ticket option has a seat map already")
+	ErrTicketOptionHasSales    = errors.New("ticket option has sold tickets already")
+	ErrTicketOptionIsNotSeated = errors.New("ticket option has no seat map")
+	ErrTicketOptionIsSeated    = errors.New("ticket option sells specific seats only")
+	ErrSeatSelectionIsInvalid  = errors.New("seat ids must be given once each")
+	ErrSeatWasNotFound         = errors.New("seat does not exist")
+	ErrSeatNotAvailable        = errors.New("seat is not available")
+	ErrNoContiguousSeats       = errors.New("no row has that many seats available together")
+	ErrSeatMapIsTooLarge       = errors.New("seat map has more seats than allowed")
+)
+
+const (
+	// MaxSeatsPerRow is the most seats a row of a seat map can have.
+	MaxSeatsPerRow = 500
+	// MaxSeatsPerMap is the most seats a seat map can have in all.
+	MaxSeatsPerMap = 100000
+)
+
+// holdAttempts bounds how many times HoldBestAvailable looks again after the seats it picked were
+// taken in the meantime.
+const holdAttempts = 3
+
+// CreateSeatMap lays out the seats of a ticket option. The ticket option then sells those seats only
+// and its allocation becomes its number of seats.
+func (s *DefaultService) CreateSeatMap(ctx context.Context, ticketID int, layout ticket.SeatMapLayout) (*ticket.SeatMap, error) {
+	seats, err := seatsOf(ticketID, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.GetTicket(ctx, ticketID); err != nil {
+		return nil, err
+	}
+
+	var best ticket.SeatPoint
+	if layout.Best != nil {
+		best = *layout.Best
+	}
+
+	if err = s.repository.CreateSeatMap(ctx, ticketID, seats, best); err != nil {
+		switch err {
+		case repository.ErrDBTicketNotFound:
+			return nil, ErrTicketWasNotFound
+		case repository.ErrDBSeatMapExists:
+			return nil, ErrSeatMapAlreadyExists
+		case repository.ErrDBTicketOptionHasSales:
+			return nil, ErrTicketOptionHasSales
+		default:
+			return nil, err
+		}
+	}
+
+	s.notifyAvailability(ctx, ticketID)
+
+	return s.GetSeatMap(ctx, ticketID)
+}
+
+func (s *DefaultService) GetSeatMap(ctx context.Context, ticketID int) (*ticket.SeatMap, error) {
+	option, err := s.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !option.Seated {
+		return nil, ErrTicketOptionIsNotSeated
+	}
+
+	seats, err := s.repository.GetSeats(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	return seatMapOf(ticketID, seats, option.BestSeat, s.now()), nil
+}
+
+// PurchaseSeats buys every seat of seatIDs for userID, or none of them if any is taken.
+func (s *DefaultService) PurchaseSeats(ctx context.Context, ticketID int, seatIDs []int, userID string) (*ticket.Purchase, error) {
+	if len(seatIDs) == 0 {
+		return nil, ErrQuantityLowerThanOne
+	}
+
+	seen := make(map[int]bool, len(seatIDs))
+	for _, id := range seatIDs {
+		if seen[id] {
+			return nil, ErrSeatSelectionIsInvalid
+		}
+		seen[id] = true
+	}
+
+	option, err := s.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !option.Seated {
+		return nil, ErrTicketOptionIsNotSeated
+	}
+
+	if err = s.checkOnSale(option); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, len(seatIDs))
+	for i := range codes {
+		if codes[i], err = s.issuer.Issue(ticketID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	quote := func(sold int) ticket.PriceQuote {
+		return pricing.Quote(*option, sold, len(seatIDs), now)
+	}
+
+	purchase, err := s.repository.PurchaseSeats(ctx, ticketID, userID, seatIDs, codes, quote, now)
+	if err != nil {
+		switch err {
+		case repository.ErrDBSeatNotFound:
+			return nil, ErrSeatWasNotFound
+		case repository.ErrDBSeatNotAvailable:
+			return nil, ErrSeatNotAvailable
+		default:
+			return nil, err
+		}
+	}
+
+	s.notifyAvailability(ctx, ticketID)
+
+	return purchase, nil
+}
+
+func seatsOf(ticketID int, layout ticket.SeatMapLayout) ([]ticket.Seat, error) {
+	if len(layout.Sections) == 0 {
+		return nil, ErrSeatMapLayoutIsInvalid
+	}
+
+	var seats []ticket.Seat
+	sections := make(map[string]bool, len(layout.Sections))
+	y := 0
+
+	for _, section := range layout.Sections {
+		if section.Name == "" || sections[section.Name] || len(section.Rows) == 0 {
+			return nil, ErrSeatMapLayoutIsInvalid
+		}
+		sections[section.Name] = true
+
+		rows := make(map[string]bool, len(section.Rows))
+		for _, row := range section.Rows {
+			if row.Name == "" || rows[row.Name] || row.Seats < 1 {
+				return nil, ErrSeatMapLayoutIsInvalid
+			}
+			rows[row.Name] = true
+
+			if row.Seats > MaxSeatsPerRow || len(seats)+row.Seats > MaxSeatsPerMap {
+				return nil, ErrSeatMapIsTooLarge
+			}
+
+			for number := 1; number <= row.Seats; number++ {
+				seats = append(seats, ticket.Seat{
+					TicketID: ticketID,
+					Section:  section.Name,
+					Row:      row.Name,
+					Number:   number,
+					X:        2*number - row.Seats - 1,
+					Y:        y,
+					Status:   ticket.SeatAvailable,
+				})
+			}
+			y++
+		}
+	}
+
+	return seats, nil
+}
+
+// seatMapOf groups seats, given in layout order, by section and row.
+func seatMapOf(ticketID int, seats []ticket.Seat, best ticket.SeatPoint, now time.Time) *ticket.SeatMap {
+	seatMap := &ticket.SeatMap{TicketID: ticketID, Best: best, Sections: []ticket.SeatSection{}}
+
+	for _, seat := range seats {
+		if seat.AvailableAt(now) {
+			seatMap.Available++
+		}
+
+		if n := len(seatMap.Sections); n == 0 || seatMap.Sections[n-1].Name != seat.Section {
+			seatMap.Sections = append(seatMap.Sections, ticket.SeatSection{Name: seat.Section})
+		}
+		section := &seatMap.Sections[len(seatMap.Sections)-1]
+
+		if n := len(section.Rows); n == 0 || section.Rows[n-1].Name != seat.Row {
+			section.Rows = append(section.Rows, ticket.SeatRow{Name: seat.Row})
+		}
+		row := &section.Rows[len(section.Rows)-1]
+
+		row.Seats = append(row.Seats, seat)
+	}
+
+	return seatMap
+}
+
+// HoldBestAvailable finds quantity seats side by side in one row, as close to the best point of the
+// seat map as possible, and holds them for userID for the seat hold TTL. The seats are held only if
+// all of them are still free; otherwise the search starts over on fresh seats.
+func (s *DefaultService) HoldBestAvailable(ctx context.Context, ticketID, quantity int, userID string) (*ticket.SeatHold, error) {
+	if quantity < 1 {
+		return nil, ErrQuantityLowerThanOne
+	}
+
+	if userID == "" {
+		return nil, ErrUserIDIsEmpty
+	}
+
+	option, err := s.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !option.Seated {
+		return nil, ErrTicketOptionIsNotSeated
+	}
+
+	if err = s.checkOnSale(option); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < holdAttempts; attempt++ {
+		seats, err := s.repository.GetSeats(ctx, ticketID)
+		if err != nil {
+			return nil, err
+		}
+
+		now := s.now()
+		window := seating.BestAvailable(seats, quantity, option.BestSeat, now)
+		if window == nil {
+			return nil, ErrNoContiguousSeats
+		}
+
+		seatIDs := make([]int, len(window))
+		for i := range window {
+			seatIDs[i] = window[i].ID
+		}
+
+		until := now.Add(s.seatHoldTTL)
+		err = s.repository.HoldSeats(ctx, ticketID, seatIDs, userID, now, until)
+		if errors.Is(err, repository.ErrDBSeatNotAvailable) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range window {
+			window[i].Status = ticket.SeatHeld
+			window[i].HeldBy = userID
+			window[i].HeldUntil = &until
+		}
+
+		return &ticket.SeatHold{TicketID: ticketID, UserID: userID, Seats: window, ExpiresAt: until}, nil
+	}
+
+	return nil, ErrSeatNotAvailable
+}
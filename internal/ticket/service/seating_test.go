@@ -0,0 +1,300 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Seating Unit Tests
+
+func Test_Should_Create_Numbered_Seats_From_Layout(t *testing.T) {
+	// Given
+	layout := ticket.SeatMapLayout{Sections: []ticket.SectionLayout{
+		{Name: "Stalls", Rows: []ticket.RowLayout{{Name: "A", Seats: 2}, {Name: "B", Seats: 1}}},
+		{Name: "Balcony", Rows: []ticket.RowLayout{{Name: "A", Seats: 1}}},
+	}}
+
+	expectedSeats := []ticket.Seat{
+		{TicketID: 1, Section: "Stalls", Row: "A", Number: 1, X: -1, Y: 0, Status: ticket.SeatAvailable},
+		{TicketID: 1, Section: "Stalls", Row: "A", Number: 2, X: 1, Y: 0, Status: ticket.SeatAvailable},
+		{TicketID: 1, Section: "Stalls", Row: "B", Number: 1, X: 0, Y: 1, Status: ticket.SeatAvailable},
+		{TicketID: 1, Section: "Balcony", Row: "A", Number: 1, X: 0, Y: 2, Status: ticket.SeatAvailable},
+	}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	gomock.InOrder(
+		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 4}, nil),
+		mockRepository.EXPECT().CreateSeatMap(gomock.Any(), 1, expectedSeats, ticket.SeatPoint{}).Return(nil),
+		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 4, Seated: true}, nil),
+		mockRepository.EXPECT().GetSeats(gomock.Any(), 1).Return(expectedSeats, nil),
+	)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	seatMap, err := ticketService.CreateSeatMap(context.TODO(), 1, layout)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 4, seatMap.Available)
+	assert.Len(t, seatMap.Sections, 2)
+	assert.Equal(t, "Stalls", seatMap.Sections[0].Name)
+	assert.Len(t, seatMap.Sections[0].Rows, 2)
+	assert.Len(t, seatMap.Sections[0].Rows[0].Seats, 2)
+	assert.Equal(t, "Balcony", seatMap.Sections[1].Name)
+}
+
+func Test_Should_Return_Error_When_Seat_Map_Layout_Is_Invalid(t *testing.T) {
+	testCases := []ticket.SeatMapLayout{
+		{},
+		{Sections: []ticket.SectionLayout{{Name: "", Rows: []ticket.RowLayout{{Name: "A", Seats: 1}}}}},
+		{Sections: []ticket.SectionLayout{{Name: "Stalls"}}},
+		{Sections: []ticket.SectionLayout{{Name: "Stalls", Rows: []ticket.RowLayout{{Name: "A", Seats: 0}}}}},
+		{Sections: []ticket.SectionLayout{{Name: "Stalls", Rows: []ticket.RowLayout{{Name: "A", Seats: 1}, {Name: "A", Seats: 1}}}}},
+		{Sections: []ticket.SectionLayout{
+			{Name: "Stalls", Rows: []ticket.RowLayout{{Name: "A", Seats: 1}}},
+			{Name: "Stalls", Rows: []ticket.RowLayout{{Name: "B", Seats: 1}}},
+		}},
+	}
+
+	for _, layout := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().CreateSeatMap(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		seatMap, err := ticketService.CreateSeatMap(context.TODO(), 1, layout)
+
+		// Then
+		assert.Nil(t, seatMap)
+		assert.Equal(t, service.ErrSeatMapLayoutIsInvalid, err)
+	}
+}
+
+func Test_Should_Return_Error_When_Seat_Map_Is_Too_Large(t *testing.T) {
+	rows := make([]ticket.RowLayout, service.MaxSeatsPerMap/service.MaxSeatsPerRow+1)
+	for i := range rows {
+		rows[i] = ticket.RowLayout{Name: fmt.Sprint(i), Seats: service.MaxSeatsPerRow}
+	}
+
+	testCases := []ticket.SeatMapLayout{
+		{Sections: []ticket.SectionLayout{{Name: "Stalls", Rows: []ticket.RowLayout{{Name: "A", Seats: service.MaxSeatsPerRow + 1}}}}},
+		{Sections: []ticket.SectionLayout{{Name: "Stalls", Rows: []ticket.RowLayout{{Name: "A", Seats: 100000000}}}}},
+		{Sections: []ticket.SectionLayout{{Name: "Stalls", Rows: rows}}},
+	}
+
+	for _, layout := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().CreateSeatMap(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		seatMap, err := ticketService.CreateSeatMap(context.TODO(), 1, layout)
+
+		// Then
+		assert.Nil(t, seatMap)
+		assert.Equal(t, service.ErrSeatMapIsTooLarge, err)
+	}
+}
+
+func Test_Should_Return_Error_When_Purchase_Seats_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		seatIDs         []int
+		option          *ticket.Ticket
+		repositoryError error
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{seatIDs: nil, expectedError: service.ErrQuantityLowerThanOne},
+		{seatIDs: []int{1, 1}, expectedError: service.ErrSeatSelectionIsInvalid},
+		{seatIDs: []int{1}, option: &ticket.Ticket{ID: 1, Available: 10}, expectedError: service.ErrTicketOptionIsNotSeated},
+		{
+			seatIDs:         []int{1, 2},
+			option:          &ticket.Ticket{ID: 1, Available: 10, Seated: true},
+			repositoryError: repository.ErrDBSeatNotAvailable,
+			expectedError:   service.ErrSeatNotAvailable,
+		},
+		{
+			seatIDs:         []int{1, 99},
+			option:          &ticket.Ticket{ID: 1, Available: 10, Seated: true},
+			repositoryError: repository.ErrDBSeatNotFound,
+			expectedError:   service.ErrSeatWasNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		if test.option != nil {
+			mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(test.option, nil).Times(1)
+		}
+		if test.repositoryError != nil {
+			mockRepository.EXPECT().PurchaseSeats(gomock.Any(), 1, "test", test.seatIDs, gomock.Len(len(test.seatIDs)), gomock.Any(), gomock.Any()).
+				Return(nil, test.repositoryError).Times(1)
+		}
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		purchase, err := ticketService.PurchaseSeats(context.TODO(), 1, test.seatIDs, "test")
+
+		// Then
+		assert.Nil(t, purchase)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Return_Error_When_Purchase_Seated_Ticket_Option_Without_Seats(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 10, Seated: true}, nil).Times(1)
+	mockRepository.EXPECT().PurchaseFromTicketOption(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	purchase, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 2, "test")
+
+	// Then
+	assert.Nil(t, purchase)
+	assert.Equal(t, service.ErrTicketOptionIsSeated, err)
+}
+
+func Test_Should_Purchase_Seats_As_Of_Service_Clock(t *testing.T) {
+	// Given
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	expectedPurchase := &ticket.Purchase{ID: 7, TicketID: 1, UserID: "test", Quantity: 2}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 10, Seated: true}, nil).Times(1)
+	mockRepository.EXPECT().PurchaseSeats(gomock.Any(), 1, "test", []int{1, 2}, gomock.Len(2), gomock.Any(), now).
+		Return(expectedPurchase, nil).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithClock(func() time.Time { return now }))
+
+	// When
+	purchase, err := ticketService.PurchaseSeats(context.TODO(), 1, []int{1, 2}, "test")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPurchase, purchase)
+}
+
+func Test_Should_Hold_Best_Available_Seats(t *testing.T) {
+	// Given
+	seats := []ticket.Seat{
+		{ID: 1, TicketID: 1, Section: "Stalls", Row: "A", Number: 1, X: -2, Status: ticket.SeatAvailable},
+		{ID: 2, TicketID: 1, Section: "Stalls", Row: "A", Number: 2, X: 0, Status: ticket.SeatAvailable},
+		{ID: 3, TicketID: 1, Section: "Stalls", Row: "A", Number: 3, X: 2, Status: ticket.SeatAvailable},
+	}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	gomock.InOrder(
+		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 3, Seated: true}, nil),
+		mockRepository.EXPECT().GetSeats(gomock.Any(), 1).Return(seats, nil),
+		mockRepository.EXPECT().HoldSeats(gomock.Any(), 1, []int{1, 2}, "test", gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithSeatHoldTTL(time.Minute))
+
+	// When
+	hold, err := ticketService.HoldBestAvailable(context.TODO(), 1, 2, "test")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "test", hold.UserID)
+	assert.Len(t, hold.Seats, 2)
+	assert.Equal(t, ticket.SeatHeld, hold.Seats[0].Status)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), hold.ExpiresAt, 5*time.Second)
+}
+
+func Test_Should_Look_Again_When_Best_Available_Seats_Were_Taken(t *testing.T) {
+	// Given
+	seats := []ticket.Seat{
+		{ID: 1, TicketID: 1, Section: "Stalls", Row: "A", Number: 1, X: -1, Status: ticket.SeatAvailable},
+		{ID: 2, TicketID: 1, Section: "Stalls", Row: "A", Number: 2, X: 1, Status: ticket.SeatAvailable},
+		{ID: 3, TicketID: 1, Section: "Stalls", Row: "B", Number: 1, X: 0, Y: 1, Status: ticket.SeatAvailable},
+	}
+	taken := append([]ticket.Seat{}, seats...)
+	taken[0].Status = ticket.SeatSold
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	gomock.InOrder(
+		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 3, Seated: true}, nil),
+		mockRepository.EXPECT().GetSeats(gomock.Any(), 1).Return(seats, nil),
+		mockRepository.EXPECT().HoldSeats(gomock.Any(), 1, []int{1}, "test", gomock.Any(), gomock.Any()).
+			Return(repository.ErrDBSeatNotAvailable),
+		mockRepository.EXPECT().GetSeats(gomock.Any(), 1).Return(taken, nil),
+		mockRepository.EXPECT().HoldSeats(gomock.Any(), 1, []int{2}, "test", gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	hold, err := ticketService.HoldBestAvailable(context.TODO(), 1, 1, "test")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, hold.Seats[0].ID)
+}
+
+func Test_Should_Return_Error_When_Hold_Best_Available_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		quantity      int
+		userID        string
+		option        *ticket.Ticket
+		seats         []ticket.Seat
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{quantity: 0, userID: "test", expectedError: service.ErrQuantityLowerThanOne},
+		{quantity: 1, userID: "", expectedError: service.ErrUserIDIsEmpty},
+		{quantity: 1, userID: "test", option: &ticket.Ticket{ID: 1, Available: 10}, expectedError: service.ErrTicketOptionIsNotSeated},
+		{
+			quantity: 2,
+			userID:   "test",
+			option:   &ticket.Ticket{ID: 1, Available: 2, Seated: true},
+			seats: []ticket.Seat{
+				{ID: 1, TicketID: 1, Section: "Stalls", Row: "A", Number: 1, Status: ticket.SeatAvailable},
+				{ID: 2, TicketID: 1, Section: "Stalls", Row: "B", Number: 1, Y: 1, Status: ticket.SeatAvailable},
+			},
+			expectedError: service.ErrNoContiguousSeats,
+		},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		if test.option != nil {
+			mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(test.option, nil).Times(1)
+		}
+		if test.seats != nil {
+			mockRepository.EXPECT().GetSeats(gomock.Any(), 1).Return(test.seats, nil).Times(1)
+		}
+		mockRepository.EXPECT().HoldSeats(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		hold, err := ticketService.HoldBestAvailable(context.TODO(), 1, test.quantity, test.userID)
+
+		// Then
+		assert.Nil(t, hold)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
@@ -3,9 +3,14 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
 
 	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/pricing"
 	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketcode"
 )
 
 var (
@@ -13,6 +18,12 @@ var (
 	ErrNameIsDuplicate          = errors.New("ticket name exists already")
 	ErrDescriptionIsEmpty       = errors.New("description should not be empty")
 	ErrAllocationIsLowerThanOne = errors.New("allocation should be higher than zero ")
+	ErrPriceIsNegative          = errors.New("price should not be negative")
+	ErrSaleWindowIsInvalid      = errors.New("sale should end after it starts")
+
+	ErrPriceTierKindIsUnknown = errors.New("price tier kind is unknown")
+	ErrPriceTierIsIncomplete  = errors.New("price tier is missing the setting of its kind")
+	ErrPriceTierWasNotFound   = errors.New("price tier does not exist")
 
 	ErrTicketWasNotFound = errors.New("ticket does not exist")
 	ErrIDLowerThanOne    = errors.New("id must not be lower than one")
@@ -20,36 +31,190 @@ var (
 	ErrPurchaseTicketMoreThanAvailable = errors.New("quantity of ticket wanted to be purchased must " +
 		"not be more than available ones")
 	ErrQuantityLowerThanOne = errors.New("quantity must not be lower than one")
+	ErrSaleNotStarted       = errors.New("ticket option is not on sale yet")
+	ErrSaleEnded            = errors.New("ticket option is no longer on sale")
+
+	ErrPurchaseWasNotFound     = errors.New("purchase does not exist")
+	ErrPurchaseAlreadyRefunded = errors.New("purchase has already been refunded")
+
+	ErrIssuedTicketWasNotFound   = errors.New("issued ticket does not exist")
+	ErrIssuedTicketCodeIsInvalid = errors.New("issued ticket code is not genuine")
+	ErrIssuedTicketAlreadyUsed   = errors.New("issued ticket has already been used")
+	ErrIssuedTicketRefunded      = errors.New("issued ticket has been refunded")
+	ErrIssuedTicketForOtherEvent = errors.New("issued ticket is for another event")
+	ErrGateIDIsEmpty             = errors.New("gate id should not be empty")
+
+	ErrUserIDIsEmpty        = errors.New("user id should not be empty")
+	ErrTransferToSameUser   = errors.New("issued ticket already belongs to the user")
+	ErrTransferWindowClosed = errors.New("issued ticket can no longer be transferred")
+	ErrIssuedTicketNotOwned = errors.New("issued ticket belongs to another user")
+
+	ErrResaleListingWasNotFound   = errors.New("resale listing does not exist")
+	ErrResaleListingAlreadyExists = errors.New("issued ticket is listed for resale already")
+	ErrResaleListingNotAvailable  = errors.New("resale listing is no longer available")
+	ErrResalePriceAboveCap        = errors.New("resale price is higher than allowed")
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 100
+
+	// DefaultTransferCutoff is how long before its event starts a ticket stops being transferable.
+	DefaultTransferCutoff = 24 * time.Hour
+	// DefaultResalePriceCap is the highest resale price, as a percentage of the price paid.
+	DefaultResalePriceCap = 100
+	// DefaultSeatHoldTTL is how long held seats stay set aside for their holder.
+	DefaultSeatHoldTTL = 10 * time.Minute
+	// DefaultOrderTTL is how long a pending order holds its tickets before it expires unpaid.
+	DefaultOrderTTL = 15 * time.Minute
 )
 
 type Service interface {
-	CreateTicketOption(ctx context.Context, name, description string, allocation int) (*ticket.Ticket, error)
+	CreateTicketOption(ctx context.Context, name, description string, allocation, price int,
+		startsAt, saleStartsAt, saleEndsAt *time.Time) (*ticket.Ticket, error)
+	CreateTicketOptions(ctx context.Context, drafts []ticket.TicketDraft, mode ticket.BulkMode) ([]ticket.BulkResult, error)
 	GetTicket(ctx context.Context, id int) (*ticket.Ticket, error)
-	PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string) error
+	ListTicketOptions(ctx context.Context, filter ticket.TicketFilter) ([]ticket.Ticket, error)
+	UpdateTicketOption(ctx context.Context, id int, update ticket.TicketUpdate) (*ticket.Ticket, error)
+	AdjustAllocation(ctx context.Context, id, delta int, reason string) (*ticket.Ticket, error)
+	ShardAllocation(ctx context.Context, id, shards int) (*ticket.Ticket, error)
+	RebalanceShards(ctx context.Context, id int) (*ticket.Ticket, error)
+	PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string) (*ticket.Purchase, error)
+	QuotePrice(ctx context.Context, id, quantity int) (*ticket.PriceQuote, error)
+	CreatePriceTier(ctx context.Context, ticketID int, tier ticket.PriceTier) (*ticket.PriceTier, error)
+	DeletePriceTier(ctx context.Context, ticketID, tierID int) error
+	CreateSeatMap(ctx context.Context, ticketID int, layout ticket.SeatMapLayout) (*ticket.SeatMap, error)
+	GetSeatMap(ctx context.Context, ticketID int) (*ticket.SeatMap, error)
+	PurchaseSeats(ctx context.Context, ticketID int, seatIDs []int, userID string) (*ticket.Purchase, error)
+	HoldBestAvailable(ctx context.Context, ticketID, quantity int, userID string) (*ticket.SeatHold, error)
+	CreateOrder(ctx context.Context, userID string, items []ticket.OrderItem) (*ticket.Order, error)
+	GetOrder(ctx context.Context, id int) (*ticket.Order, error)
+	PayOrder(ctx context.Context, id int) (*ticket.Order, error)
+	CancelOrder(ctx context.Context, id int) (*ticket.Order, error)
+	RefundOrder(ctx context.Context, id int) (*ticket.Order, error)
+	ExpirePendingOrders(ctx context.Context) (int, error)
+	RefundPurchase(ctx context.Context, purchaseID int) (*ticket.Purchase, error)
+	GetPurchase(ctx context.Context, id int) (*ticket.Purchase, error)
+	ListPurchases(ctx context.Context, filter ticket.PurchaseFilter) ([]ticket.Purchase, error)
+	GetPurchaseTickets(ctx context.Context, purchaseID int) ([]ticket.IssuedTicket, error)
+	GetIssuedTicket(ctx context.Context, code string) (*ticket.IssuedTicket, error)
+	CheckIn(ctx context.Context, code string, eventID int, gateID string) (*ticket.IssuedTicket, error)
+	GetCheckinStats(ctx context.Context, eventID int) (*ticket.CheckinStats, error)
+	TransferIssuedTicket(ctx context.Context, code, toUserID string) (*ticket.IssuedTicket, error)
+	GetIssuedTicketTransfers(ctx context.Context, code string) ([]ticket.TicketTransfer, error)
+	CreateResaleListing(ctx context.Context, code, sellerID string, price int) (*ticket.ResaleListing, error)
+	ListResaleListings(ctx context.Context, ticketID int) ([]ticket.ResaleListing, error)
+	BuyResaleListing(ctx context.Context, listingID int, buyerID string) (*ticket.ResaleListing, error)
+	CancelResaleListing(ctx context.Context, listingID int, sellerID string) (*ticket.ResaleListing, error)
+	ListAuditEntries(ctx context.Context, filter ticket.AuditFilter) ([]ticket.AuditEntry, error)
+	StreamSalesReport(ctx context.Context, filter ticket.SalesFilter, emit func(ticket.SalesRow) error) error
+	ListLedgerEntries(ctx context.Context, filter ticket.LedgerFilter) ([]ticket.LedgerEntry, error)
+	ReconcileAllocations(ctx context.Context) ([]ticket.AllocationDrift, error)
+	ReleaseExpiredHolds(ctx context.Context) (int, error)
+	CreateOrganizer(ctx context.Context, name string) (*ticket.Organizer, string, error)
+	IssueOrganizerToken(ctx context.Context, id int) (*ticket.Organizer, string, error)
+	GetOrganizer(ctx context.Context, id int) (*ticket.Organizer, error)
+	AuthenticateOrganizer(ctx context.Context, token string) (*ticket.Organizer, error)
 }
 
-type DefaultService struct {
-	repository repository.Repository
+// AvailabilityNotifier is told the new state of a ticket option after its allocation changed.
+type AvailabilityNotifier interface {
+	NotifyAvailability(t ticket.Ticket)
 }
 
-func NewDefaultService(repository repository.Repository) *DefaultService {
-	return &DefaultService{repository: repository}
+// CodeIssuer issues the unique code of every ticket sold for a ticket option and verifies codes it issued.
+type CodeIssuer interface {
+	Issue(ticketID int) (string, error)
+	Verify(code string) (ticketcode.Claims, error)
 }
 
-func (s *DefaultService) CreateTicketOption(ctx context.Context, name, description string, allocation int) (*ticket.Ticket, error) {
-	if name == "" {
-		return nil, ErrNameIsEmpty
+type Option func(s *DefaultService)
+
+// WithClock sets where the service reads the current time from.
+func WithClock(now func() time.Time) Option {
+	return func(s *DefaultService) {
+		s.now = now
 	}
+}
 
-	if description == "" {
-		return nil, ErrDescriptionIsEmpty
+func WithAvailabilityNotifier(notifier AvailabilityNotifier) Option {
+	return func(s *DefaultService) {
+		s.notifier = notifier
+	}
+}
+
+func WithCodeIssuer(issuer CodeIssuer) Option {
+	return func(s *DefaultService) {
+		s.issuer = issuer
 	}
+}
 
-	if allocation < 1 {
-		return nil, ErrAllocationIsLowerThanOne
+// WithTransferCutoff sets how long before its event starts a ticket stops being transferable.
+func WithTransferCutoff(cutoff time.Duration) Option {
+	return func(s *DefaultService) {
+		s.transferCutoff = cutoff
 	}
+}
 
-	option, err := s.repository.CreateTicketOption(ctx, name, description, allocation)
+// WithResalePriceCap sets the highest resale price as a percentage of the price paid.
+func WithResalePriceCap(percent int) Option {
+	return func(s *DefaultService) {
+		s.resalePriceCap = percent
+	}
+}
+
+// WithSeatHoldTTL sets how long held seats stay set aside for their holder.
+func WithSeatHoldTTL(ttl time.Duration) Option {
+	return func(s *DefaultService) {
+		s.seatHoldTTL = ttl
+	}
+}
+
+// WithOrderTTL sets how long a pending order holds its tickets before it expires unpaid.
+func WithOrderTTL(ttl time.Duration) Option {
+	return func(s *DefaultService) {
+		s.orderTTL = ttl
+	}
+}
+
+type DefaultService struct {
+	repository     repository.Repository
+	notifier       AvailabilityNotifier
+	issuer         CodeIssuer
+	transferCutoff time.Duration
+	resalePriceCap int
+	seatHoldTTL    time.Duration
+	orderTTL       time.Duration
+	now            func() time.Time
+}
+
+// NewDefaultService returns a service issuing ticket codes with a throwaway key unless WithCodeIssuer is given.
+func NewDefaultService(repository repository.Repository, opts ...Option) *DefaultService {
+	s := &DefaultService{
+		repository:     repository,
+		issuer:         ticketcode.NewRandomSigner(),
+		transferCutoff: DefaultTransferCutoff,
+		resalePriceCap: DefaultResalePriceCap,
+		seatHoldTTL:    DefaultSeatHoldTTL,
+		orderTTL:       DefaultOrderTTL,
+		now:            time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// CreateTicketOption creates a ticket option selling at price, in minor units. startsAt schedules its
+// event and may be nil. saleStartsAt and saleEndsAt bound when it can be purchased; nil leaves that side open.
+func (s *DefaultService) CreateTicketOption(ctx context.Context, name, description string, allocation, price int,
+	startsAt, saleStartsAt, saleEndsAt *time.Time) (*ticket.Ticket, error) {
+	if err := validateTicketOption(name, description, allocation, price, saleStartsAt, saleEndsAt); err != nil {
+		return nil, err
+	}
+
+	option, err := s.repository.CreateTicketOption(ctx, name, description, allocation, price, startsAt, saleStartsAt, saleEndsAt)
 	if err != nil {
 		if errors.Is(err, repository.ErrDBDuplicatedTicketName) {
 			return nil, ErrNameIsDuplicate
@@ -60,6 +225,30 @@ func (s *DefaultService) CreateTicketOption(ctx context.Context, name, descripti
 	return option, nil
 }
 
+func validateTicketOption(name, description string, allocation, price int, saleStartsAt, saleEndsAt *time.Time) error {
+	if name == "" {
+		return ErrNameIsEmpty
+	}
+
+	if description == "" {
+		return ErrDescriptionIsEmpty
+	}
+
+	if allocation < 1 {
+		return ErrAllocationIsLowerThanOne
+	}
+
+	if price < 0 {
+		return ErrPriceIsNegative
+	}
+
+	if saleStartsAt != nil && saleEndsAt != nil && !saleStartsAt.Before(*saleEndsAt) {
+		return ErrSaleWindowIsInvalid
+	}
+
+	return nil
+}
+
 func (s *DefaultService) GetTicket(ctx context.Context, id int) (*ticket.Ticket, error) {
 	if id < 1 {
 		return nil, ErrIDLowerThanOne
@@ -78,22 +267,203 @@ func (s *DefaultService) GetTicket(ctx context.Context, id int) (*ticket.Ticket,
 	return t, nil
 }
 
-func (s *DefaultService) PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string) error {
+// ListTicketOptions returns the page of ticket options after filter.AfterID. A missing limit
+// falls back to DefaultListLimit and larger ones are capped at MaxListLimit.
+func (s *DefaultService) ListTicketOptions(ctx context.Context, filter ticket.TicketFilter) ([]ticket.Ticket, error) {
+	if filter.Limit < 1 {
+		filter.Limit = DefaultListLimit
+	}
+
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+
+	if filter.AfterID < 0 {
+		filter.AfterID = 0
+	}
+
+	if filter.OnSale {
+		filter.At = s.now()
+	}
+
+	return s.repository.ListTicketOptions(ctx, filter)
+}
+
+func (s *DefaultService) PurchaseFromTicketOption(ctx context.Context, id, quantity int, userID string) (*ticket.Purchase, error) {
 	if quantity < 1 {
-		return ErrQuantityLowerThanOne
+		return nil, ErrQuantityLowerThanOne
 	}
 
 	ticketOption, err := s.GetTicket(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if ticketOption.Allocation < quantity {
-		return ErrPurchaseTicketMoreThanAvailable
+	if ticketOption.Seated {
+		return nil, ErrTicketOptionIsSeated
+	}
+
+	if err = s.checkOnSale(ticketOption); err != nil {
+		return nil, err
+	}
+
+	if ticketOption.Available < quantity {
+		return nil, ErrPurchaseTicketMoreThanAvailable
+	}
+
+	codes := make([]string, quantity)
+	for i := range codes {
+		if codes[i], err = s.issuer.Issue(id); err != nil {
+			return nil, err
+		}
+	}
+
+	quote := func(sold int) ticket.PriceQuote {
+		return pricing.Quote(*ticketOption, sold, quantity, s.now())
+	}
+
+	purchase, err := s.repository.PurchaseFromTicketOption(ctx, id, quantity, userID, codes, quote)
+	if err != nil {
+		// Others may have bought the tickets left since the ticket option was read.
+		if errors.Is(err, repository.ErrDBNotEnoughAllocation) {
+			return nil, ErrPurchaseTicketMoreThanAvailable
+		}
+		return nil, err
+	}
+
+	s.notifyAvailability(ctx, id)
+
+	return purchase, nil
+}
+
+func (s *DefaultService) RefundPurchase(ctx context.Context, purchaseID int) (*ticket.Purchase, error) {
+	if purchaseID < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	purchase, err := s.repository.RefundPurchase(ctx, purchaseID)
+	if err != nil {
+		switch err {
+		case repository.ErrDBPurchaseNotFound:
+			return nil, ErrPurchaseWasNotFound
+		case repository.ErrDBPurchaseAlreadyRefunded:
+			return nil, ErrPurchaseAlreadyRefunded
+		case repository.ErrDBPurchaseInOrder:
+			return nil, ErrPurchaseBelongsToOrder
+		default:
+			return nil, err
+		}
+	}
+
+	s.notifyAvailability(ctx, purchase.TicketID)
+
+	return purchase, nil
+}
+
+func (s *DefaultService) GetPurchase(ctx context.Context, id int) (*ticket.Purchase, error) {
+	if id < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	purchase, err := s.repository.GetPurchase(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrDBPurchaseNotFound) {
+			return nil, ErrPurchaseWasNotFound
+		}
+		return nil, err
+	}
+
+	return purchase, nil
+}
+
+// ListPurchases returns the page of purchases after filter.AfterID. Limits are handled as in
+// ListTicketOptions.
+func (s *DefaultService) ListPurchases(ctx context.Context, filter ticket.PurchaseFilter) ([]ticket.Purchase, error) {
+	if filter.Limit < 1 {
+		filter.Limit = DefaultListLimit
+	}
+
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+
+	if filter.AfterID < 0 {
+		filter.AfterID = 0
+	}
+
+	return s.repository.ListPurchases(ctx, filter)
+}
+
+func (s *DefaultService) GetPurchaseTickets(ctx context.Context, purchaseID int) ([]ticket.IssuedTicket, error) {
+	if purchaseID < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	issued, err := s.repository.GetPurchaseTickets(ctx, purchaseID)
+	if err != nil {
+		if errors.Is(err, repository.ErrDBPurchaseNotFound) {
+			return nil, ErrPurchaseWasNotFound
+		}
+		return nil, err
+	}
+
+	return issued, nil
+}
+
+func (s *DefaultService) GetIssuedTicket(ctx context.Context, code string) (*ticket.IssuedTicket, error) {
+	if code == "" {
+		return nil, ErrIssuedTicketWasNotFound
+	}
+
+	issued, err := s.repository.GetIssuedTicket(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrDBIssuedTicketNotFound) {
+			return nil, ErrIssuedTicketWasNotFound
+		}
+		return nil, err
+	}
+
+	return issued, nil
+}
+
+// notifyAvailability reads the committed allocation of ticket option id and hands it to the notifier.
+// It is best effort: the change has already been committed, so a failed read is only logged.
+func (s *DefaultService) notifyAvailability(ctx context.Context, id int) {
+	if s.notifier == nil {
+		return
+	}
+
+	t, err := s.repository.GetTicket(ctx, id)
+	if err != nil {
+		log.Errorf("could not read ticket %d to notify availability: %v", id, err)
+		return
+	}
+
+	s.notifier.NotifyAvailability(*t)
+}
+
+// notifyOptions hands ticket options already read after a change to the notifier, for changes
+// made for every organizer at once, which GetTicket cannot read them for.
+func (s *DefaultService) notifyOptions(options []ticket.Ticket) {
+	if s.notifier == nil {
+		return
+	}
+
+	for _, option := range options {
+		s.notifier.NotifyAvailability(option)
+	}
+}
+
+// checkOnSale tells why option cannot be purchased now because of its sale window, if it cannot.
+func (s *DefaultService) checkOnSale(option *ticket.Ticket) error {
+	now := s.now()
+
+	if option.SaleStartsAt != nil && now.Before(*option.SaleStartsAt) {
+		return ErrSaleNotStarted
 	}
 
-	if err := s.repository.PurchaseFromTicketOption(ctx, id, quantity, userID); err != nil {
-		return err
+	if option.SaleEndsAt != nil && !now.Before(*option.SaleEndsAt) {
+		return ErrSaleEnded
 	}
 
 	return nil
@@ -2,61 +2,82 @@ package service_test
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	ticket2 "github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/audit"
 	"github.com/dilaragorum/ticket-api/internal/ticket/database"
 	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
 	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
 	"github.com/ory/dockertest/v3"
 	"github.com/ory/dockertest/v3/docker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 type IntegrationTestSuite struct {
 	suite.Suite
+	// driver is the database the suite runs against, Postgres in Docker unless it is SQLite.
+	driver         string
 	svc            *service.DefaultService
+	ctx            context.Context
 	container      *dockertest.Resource
 	connectionPool *gorm.DB
 }
 
 func (suite *IntegrationTestSuite) SetupTest() {
-	suite.container, suite.connectionPool = createContainer()
+	if suite.driver == database.DriverSQLite {
+		suite.connectionPool = createSQLiteDatabase(suite.T())
+	} else {
+		suite.container, suite.connectionPool = createContainer()
+	}
 	defaultRepository := repository.NewDefaultRepository(suite.connectionPool)
 	suite.svc = service.NewDefaultService(defaultRepository)
+	suite.ctx = tenant.WithOrganizer(context.TODO(), ticket2.DefaultOrganizerID)
 }
 
 func TestExampleTestSuite(t *testing.T) {
 	suite.Run(t, new(IntegrationTestSuite))
 }
 
+func TestSQLiteIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, &IntegrationTestSuite{driver: database.DriverSQLite})
+}
+
 func (suite *IntegrationTestSuite) TearDownSuite() {
-	_ = suite.container.Close()
+	if suite.container != nil {
+		_ = suite.container.Close()
+	}
 }
 
 func (suite *IntegrationTestSuite) Test_Should_Insert_New_Ticket() {
 	// When
-	option, err := suite.svc.CreateTicketOption(context.TODO(), "ticket", "description", 100)
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "ticket", "description", 100, 0, nil, nil, nil)
 
 	// Then
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), 1, option.ID)
 	assert.Equal(suite.T(), "ticket", option.Name)
 	assert.Equal(suite.T(), "description", option.Desc)
-	assert.Equal(suite.T(), 100, option.Allocation)
+	assert.Equal(suite.T(), 100, option.Available)
 }
 
 func (suite *IntegrationTestSuite) Test_Should_Get_Ticket_With_ID() {
 	// Given
 	ticket := ticket2.Ticket{
-		Name:       "example2",
-		Desc:       "sample description2",
-		Allocation: 100,
+		Name:      "example2",
+		Desc:      "sample description2",
+		Capacity:  100,
+		Available: 100,
 	}
 
 	err := suite.connectionPool.Model(&ticket).Create(&ticket).Error
@@ -65,22 +86,32 @@ func (suite *IntegrationTestSuite) Test_Should_Get_Ticket_With_ID() {
 	}
 
 	// When
-	option, err := suite.svc.GetTicket(context.TODO(), ticket.ID)
+	option, err := suite.svc.GetTicket(suite.ctx, ticket.ID)
 
 	// Then
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), 1, option.ID)
 	assert.Equal(suite.T(), "example2", option.Name)
 	assert.Equal(suite.T(), "sample description2", option.Desc)
-	assert.Equal(suite.T(), 100, option.Allocation)
+	assert.Equal(suite.T(), 100, option.Available)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Return_Not_Found_For_Missing_Ticket() {
+	// When
+	option, err := suite.svc.GetTicket(suite.ctx, 404)
+
+	// Then
+	assert.Nil(suite.T(), option)
+	assert.Equal(suite.T(), service.ErrTicketWasNotFound, err)
 }
 
 func (suite *IntegrationTestSuite) Test_Should_Purchase_From_Ticket() {
 	// Given
 	ticket := ticket2.Ticket{
-		Name:       "example3",
-		Desc:       "sample description3",
-		Allocation: 100,
+		Name:      "example3",
+		Desc:      "sample description3",
+		Capacity:  100,
+		Available: 100,
 	}
 
 	err := suite.connectionPool.Model(&ticket).Create(&ticket).Error
@@ -89,7 +120,7 @@ func (suite *IntegrationTestSuite) Test_Should_Purchase_From_Ticket() {
 	}
 
 	// When
-	if err := suite.svc.PurchaseFromTicketOption(context.TODO(), ticket.ID, 50, "406c1d05-bbb2-4e94-b183-7d208c2692e1"); err != nil {
+	if _, err := suite.svc.PurchaseFromTicketOption(suite.ctx, ticket.ID, 50, "406c1d05-bbb2-4e94-b183-7d208c2692e1"); err != nil {
 		suite.T().Error(err)
 	}
 
@@ -97,6 +128,733 @@ func (suite *IntegrationTestSuite) Test_Should_Purchase_From_Ticket() {
 	assert.Nil(suite.T(), err)
 }
 
+func (suite *IntegrationTestSuite) Test_Should_Write_Outbox_Events_When_Purchase_Sells_Out_And_Is_Refunded() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example4", "sample description4", 10, 0, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	// When
+	purchase, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 10, "406c1d05-bbb2-4e94-b183-7d208c2692e1")
+	assert.Nil(suite.T(), err)
+
+	_, err = suite.svc.RefundPurchase(suite.ctx, purchase.ID)
+	assert.Nil(suite.T(), err)
+
+	// Then
+	var eventTypes []ticket2.EventType
+	suite.connectionPool.Model(&ticket2.OutboxMessage{}).Order("id").Pluck("event_type", &eventTypes)
+	assert.Equal(suite.T(), []ticket2.EventType{
+		ticket2.EventTicketOptionCreated,
+		ticket2.EventTicketPurchased,
+		ticket2.EventTicketSoldOut,
+		ticket2.EventPurchaseRefunded,
+	}, eventTypes)
+
+	refunded, err := suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 10, refunded.Available)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Issue_Tickets_When_Purchase_And_Mark_Them_Refunded() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example5", "sample description5", 10, 0, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	// When
+	purchase, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 3, "406c1d05-bbb2-4e94-b183-7d208c2692e1")
+	assert.Nil(suite.T(), err)
+
+	// Then
+	issued, err := suite.svc.GetPurchaseTickets(suite.ctx, purchase.ID)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), issued, 3)
+
+	found, err := suite.svc.GetIssuedTicket(suite.ctx, issued[0].Code)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), ticket2.IssuedTicketValid, found.Status)
+
+	_, err = suite.svc.RefundPurchase(suite.ctx, purchase.ID)
+	assert.Nil(suite.T(), err)
+
+	found, err = suite.svc.GetIssuedTicket(suite.ctx, issued[0].Code)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), ticket2.IssuedTicketRefunded, found.Status)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Admit_Ticket_Once_When_Scanned_At_Two_Gates_Concurrently() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example6", "sample description6", 10, 0, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	purchase, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "406c1d05-bbb2-4e94-b183-7d208c2692e1")
+	assert.Nil(suite.T(), err)
+	code := purchase.IssuedTickets[0].Code
+
+	// When
+	gates := []string{"A", "B", "C", "D"}
+	errs := make([]error, len(gates))
+
+	var wg sync.WaitGroup
+	for i, gate := range gates {
+		wg.Add(1)
+		go func(i int, gate string) {
+			defer wg.Done()
+			_, errs[i] = suite.svc.CheckIn(suite.ctx, code, option.ID, gate)
+		}(i, gate)
+	}
+	wg.Wait()
+
+	// Then
+	admitted := 0
+	for _, err := range errs {
+		if err == nil {
+			admitted++
+			continue
+		}
+		assert.Equal(suite.T(), service.ErrIssuedTicketAlreadyUsed, err)
+	}
+	assert.Equal(suite.T(), 1, admitted)
+
+	stats, err := suite.svc.GetCheckinStats(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, stats.Issued)
+	assert.Equal(suite.T(), 1, stats.CheckedIn)
+	assert.Equal(suite.T(), 0, stats.NotArrived)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Void_Old_Code_And_Keep_History_When_Transfer() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example7", "sample description7", 10, 0, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	purchase, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "alice")
+	assert.Nil(suite.T(), err)
+	oldCode := purchase.IssuedTickets[0].Code
+
+	// When
+	transferred, err := suite.svc.TransferIssuedTicket(suite.ctx, oldCode, "bob")
+
+	// Then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "bob", transferred.UserID)
+	assert.NotEqual(suite.T(), oldCode, transferred.Code)
+
+	_, err = suite.svc.CheckIn(suite.ctx, oldCode, option.ID, "A")
+	assert.Equal(suite.T(), service.ErrIssuedTicketWasNotFound, err)
+
+	transfers, err := suite.svc.GetIssuedTicketTransfers(suite.ctx, transferred.Code)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), transfers, 1)
+	assert.Equal(suite.T(), "alice", transfers[0].FromUserID)
+	assert.Equal(suite.T(), "bob", transfers[0].ToUserID)
+
+	_, err = suite.svc.CheckIn(suite.ctx, transferred.Code, option.ID, "A")
+	assert.Nil(suite.T(), err)
+
+	_, err = suite.svc.TransferIssuedTicket(suite.ctx, transferred.Code, "carol")
+	assert.Equal(suite.T(), service.ErrIssuedTicketAlreadyUsed, err)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Cap_Resale_Price_At_Price_Paid_When_Price_Changed() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example8b", "sample description8b", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	purchase, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "alice")
+	assert.Nil(suite.T(), err)
+
+	price := 5000
+	_, err = suite.svc.UpdateTicketOption(suite.ctx, option.ID, ticket2.TicketUpdate{Price: &price})
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, err = suite.svc.CreateResaleListing(suite.ctx, purchase.IssuedTickets[0].Code, "alice", 2000)
+
+	// Then
+	assert.Equal(suite.T(), service.ErrResalePriceAboveCap, err)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Move_Ticket_Without_Touching_Allocation_When_Resold() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example8", "sample description8", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	purchase, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "alice")
+	assert.Nil(suite.T(), err)
+	oldCode := purchase.IssuedTickets[0].Code
+
+	listing, err := suite.svc.CreateResaleListing(suite.ctx, oldCode, "alice", 1000)
+	assert.Nil(suite.T(), err)
+
+	// When
+	sold, err := suite.svc.BuyResaleListing(suite.ctx, listing.ID, "bob")
+
+	// Then
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), ticket2.ResaleListingSold, sold.Status)
+	assert.Equal(suite.T(), "bob", sold.IssuedTicket.UserID)
+	assert.NotEqual(suite.T(), oldCode, sold.IssuedTicket.Code)
+
+	option, err = suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 9, option.Available)
+
+	_, err = suite.svc.BuyResaleListing(suite.ctx, listing.ID, "carol")
+	assert.Equal(suite.T(), service.ErrResaleListingNotAvailable, err)
+
+	listings, err := suite.svc.ListResaleListings(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), listings)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Lock_Tiered_Price_Onto_Purchase() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example9", "sample description9", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	_, err = suite.svc.CreatePriceTier(suite.ctx, option.ID,
+		ticket2.PriceTier{Kind: ticket2.PriceTierSoldStep, Price: 1500, AfterSold: 2})
+	assert.Nil(suite.T(), err)
+
+	quote, err := suite.svc.QuotePrice(suite.ctx, option.ID, 3)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{1000, 1000, 1500}, quote.UnitPrices)
+
+	// When
+	first, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 3, "alice")
+	assert.Nil(suite.T(), err)
+	second, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "bob")
+	assert.Nil(suite.T(), err)
+
+	// Then
+	assert.Equal(suite.T(), 3500, first.TotalPrice)
+	assert.Equal(suite.T(), 1500, second.TotalPrice)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Sell_Each_Seat_Once_When_Purchases_Overlap() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example10", "sample description10", 1, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	seatMap, err := suite.svc.CreateSeatMap(suite.ctx, option.ID, ticket2.SeatMapLayout{Sections: []ticket2.SectionLayout{
+		{Name: "Stalls", Rows: []ticket2.RowLayout{{Name: "A", Seats: 4}}},
+	}})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 4, seatMap.Available)
+
+	seats := seatMap.Sections[0].Rows[0].Seats
+	selections := [][]int{
+		{seats[0].ID, seats[1].ID},
+		{seats[1].ID, seats[2].ID},
+		{seats[2].ID, seats[3].ID},
+	}
+
+	// When
+	errs := make([]error, len(selections))
+
+	var wg sync.WaitGroup
+	for i, selection := range selections {
+		wg.Add(1)
+		go func(i int, selection []int) {
+			defer wg.Done()
+			_, errs[i] = suite.svc.PurchaseSeats(suite.ctx, option.ID, selection, "user")
+		}(i, selection)
+	}
+	wg.Wait()
+
+	// Then
+	sold := 0
+	for _, err := range errs {
+		if err == nil {
+			sold += 2
+			continue
+		}
+		assert.Equal(suite.T(), service.ErrSeatNotAvailable, err)
+	}
+
+	seatMap, err = suite.svc.GetSeatMap(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 4-sold, seatMap.Available)
+
+	option, err = suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 4-sold, option.Available)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Hold_Different_Best_Seats_For_Concurrent_Users() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example11", "sample description11", 1, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	_, err = suite.svc.CreateSeatMap(suite.ctx, option.ID, ticket2.SeatMapLayout{Sections: []ticket2.SectionLayout{
+		{Name: "Stalls", Rows: []ticket2.RowLayout{{Name: "A", Seats: 6}, {Name: "B", Seats: 6}}},
+	}})
+	assert.Nil(suite.T(), err)
+
+	// When
+	holds := make([]*ticket2.SeatHold, 4)
+	errs := make([]error, len(holds))
+
+	var wg sync.WaitGroup
+	for i := range holds {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			holds[i], errs[i] = suite.svc.HoldBestAvailable(suite.ctx, option.ID, 2, fmt.Sprintf("user%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	// Then
+	held := map[int]bool{}
+	for i := range holds {
+		if errs[i] != nil {
+			assert.Equal(suite.T(), service.ErrSeatNotAvailable, errs[i])
+			continue
+		}
+		for _, seat := range holds[i].Seats {
+			assert.False(suite.T(), held[seat.ID])
+			held[seat.ID] = true
+		}
+	}
+
+	seatMap, err := suite.svc.GetSeatMap(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 12-len(held), seatMap.Available)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Take_Every_Item_Of_An_Order_Or_None() {
+	// Given
+	adult, err := suite.svc.CreateTicketOption(suite.ctx, "example12", "sample description12", 5, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	child, err := suite.svc.CreateTicketOption(suite.ctx, "example13", "sample description13", 1, 500, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, tooManyErr := suite.svc.CreateOrder(suite.ctx, "user", []ticket2.OrderItem{
+		{TicketID: adult.ID, Quantity: 2},
+		{TicketID: child.ID, Quantity: 2},
+	})
+	order, err := suite.svc.CreateOrder(suite.ctx, "user", []ticket2.OrderItem{
+		{TicketID: adult.ID, Quantity: 2},
+		{TicketID: child.ID, Quantity: 1},
+	})
+
+	// Then
+	assert.Equal(suite.T(), service.ErrPurchaseTicketMoreThanAvailable, tooManyErr)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), ticket2.OrderPending, order.Status)
+	assert.Equal(suite.T(), 2500, order.TotalPrice)
+
+	adult, _ = suite.svc.GetTicket(suite.ctx, adult.ID)
+	child, _ = suite.svc.GetTicket(suite.ctx, child.ID)
+	assert.Equal(suite.T(), []int{3, 2, 0}, []int{adult.Available, adult.Held, adult.Sold})
+	assert.Equal(suite.T(), []int{0, 1, 0}, []int{child.Available, child.Held, child.Sold})
+
+	paid, err := suite.svc.PayOrder(suite.ctx, order.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), ticket2.OrderPaid, paid.Status)
+
+	adult, _ = suite.svc.GetTicket(suite.ctx, adult.ID)
+	assert.Equal(suite.T(), []int{3, 0, 2}, []int{adult.Available, adult.Held, adult.Sold})
+
+	issued, err := suite.svc.GetPurchaseTickets(suite.ctx, paid.Items[0].PurchaseID)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), issued, 2)
+
+	_, err = suite.svc.RefundPurchase(suite.ctx, paid.Items[0].PurchaseID)
+	assert.Equal(suite.T(), service.ErrPurchaseBelongsToOrder, err)
+
+	refunded, err := suite.svc.RefundOrder(suite.ctx, order.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), ticket2.OrderRefunded, refunded.Status)
+
+	adult, _ = suite.svc.GetTicket(suite.ctx, adult.ID)
+	child, _ = suite.svc.GetTicket(suite.ctx, child.ID)
+	assert.Equal(suite.T(), 5, adult.Available)
+	assert.Equal(suite.T(), 1, child.Available)
+
+	_, err = suite.svc.CancelOrder(suite.ctx, order.ID)
+	assert.Equal(suite.T(), service.ErrOrderStatusConflict, err)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Give_Tickets_Of_Unpaid_Order_Back_When_It_Expires() {
+	// Given
+	expiring := service.NewDefaultService(repository.NewDefaultRepository(suite.connectionPool), service.WithOrderTTL(-time.Minute))
+
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example44", "sample description44", 5, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	order, err := expiring.CreateOrder(suite.ctx, "user", []ticket2.OrderItem{{TicketID: option.ID, Quantity: 3}})
+	assert.Nil(suite.T(), err)
+	pending, err := suite.svc.CreateOrder(suite.ctx, "user", []ticket2.OrderItem{{TicketID: option.ID, Quantity: 1}})
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, payErr := expiring.PayOrder(suite.ctx, order.ID)
+	expired, err := expiring.ExpirePendingOrders(suite.ctx)
+
+	// Then
+	assert.Equal(suite.T(), service.ErrOrderExpired, payErr)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, expired)
+
+	cancelled, err := suite.svc.GetOrder(suite.ctx, order.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), ticket2.OrderCancelled, cancelled.Status)
+
+	option, _ = suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Equal(suite.T(), []int{4, 1, 0}, []int{option.Available, option.Held, option.Sold})
+
+	pending, err = suite.svc.GetOrder(suite.ctx, pending.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), ticket2.OrderPending, pending.Status)
+
+	drifts, err := suite.svc.ReconcileAllocations(suite.ctx)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), drifts)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Not_Take_More_Tickets_Than_Available_When_Not_Checked_Before() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example46", "sample description46", 2, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	defaultRepository := repository.NewDefaultRepository(suite.connectionPool)
+	quote := func(int) ticket2.PriceQuote { return ticket2.PriceQuote{Total: 3000} }
+
+	// When
+	_, tooManyErr := defaultRepository.PurchaseFromTicketOption(suite.ctx, option.ID, 3, "user", nil, quote)
+	_, missingErr := defaultRepository.PurchaseFromTicketOption(suite.ctx, option.ID+1000, 1, "user", nil, quote)
+
+	// Then
+	assert.Equal(suite.T(), repository.ErrDBNotEnoughAllocation, tooManyErr)
+	assert.Equal(suite.T(), repository.ErrDBTicketNotFound, missingErr)
+
+	option, _ = suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Equal(suite.T(), []int{2, 0}, []int{option.Available, option.Sold})
+}
+
+func (suite *IntegrationTestSuite) Test_Should_List_Only_Ticket_Options_On_Sale() {
+	// Given
+	now := time.Now()
+	earlier, later := now.Add(-time.Hour), now.Add(time.Hour)
+
+	ended, err := suite.svc.CreateTicketOption(suite.ctx, "example14", "sample description14", 1, 0, nil, nil, &earlier)
+	assert.Nil(suite.T(), err)
+	open, err := suite.svc.CreateTicketOption(suite.ctx, "example15", "sample description15", 1, 0, nil, &earlier, &later)
+	assert.Nil(suite.T(), err)
+	upcoming, err := suite.svc.CreateTicketOption(suite.ctx, "example16", "sample description16", 1, 0, nil, &later, nil)
+	assert.Nil(suite.T(), err)
+
+	// When
+	options, err := suite.svc.ListTicketOptions(suite.ctx, ticket2.TicketFilter{Limit: service.MaxListLimit, AfterID: ended.ID - 1, OnSale: true})
+
+	// Then
+	assert.Nil(suite.T(), err)
+
+	ids := map[int]bool{}
+	for _, option := range options {
+		ids[option.ID] = true
+	}
+	assert.False(suite.T(), ids[ended.ID])
+	assert.True(suite.T(), ids[open.ID])
+	assert.False(suite.T(), ids[upcoming.ID])
+
+	_, err = suite.svc.PurchaseFromTicketOption(suite.ctx, upcoming.ID, 1, "user")
+	assert.Equal(suite.T(), service.ErrSaleNotStarted, err)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Audit_Refund_Under_Its_Actor_And_Keep_Log_Append_Only() {
+	// Given
+	ctx := audit.WithOrigin(suite.ctx, audit.Origin{Actor: "support-agent", RequestID: "request-1"})
+
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example17", "sample description17", 5, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	purchase, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 2, "user")
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, err = suite.svc.RefundPurchase(ctx, purchase.ID)
+	assert.Nil(suite.T(), err)
+
+	// Then
+	entries, err := suite.svc.ListAuditEntries(suite.ctx, ticket2.AuditFilter{Target: fmt.Sprintf("purchase:%d", purchase.ID)})
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), entries, 2)
+
+	assert.Equal(suite.T(), ticket2.AuditPurchaseCreated, entries[0].Action)
+	assert.Equal(suite.T(), audit.SystemActor, entries[0].Actor)
+
+	refund := entries[1]
+	assert.Equal(suite.T(), ticket2.AuditPurchaseRefunded, refund.Action)
+	assert.Equal(suite.T(), "support-agent", refund.Actor)
+	assert.Equal(suite.T(), "request-1", refund.RequestID)
+	assert.Contains(suite.T(), string(refund.Before), `"RefundedAt":null`)
+	assert.NotContains(suite.T(), string(refund.After), `"RefundedAt":null`)
+
+	byActor, err := suite.svc.ListAuditEntries(suite.ctx, ticket2.AuditFilter{Actor: "support-agent"})
+	assert.Nil(suite.T(), err)
+	assert.NotEmpty(suite.T(), byActor)
+
+	err = suite.connectionPool.Model(&ticket2.AuditEntry{}).Where("id = ?", refund.ID).Update("actor", "someone-else").Error
+	assert.NotNil(suite.T(), err)
+	err = suite.connectionPool.Delete(&ticket2.AuditEntry{}, refund.ID).Error
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Authenticate_Only_With_Last_Token_Issued_To_Organizer() {
+	// Given
+	organizer, created, err := suite.svc.CreateOrganizer(suite.ctx, "reissued promoter")
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, defaultToken, defaultErr := suite.svc.IssueOrganizerToken(suite.ctx, ticket2.DefaultOrganizerID)
+	_, issued, issuedErr := suite.svc.IssueOrganizerToken(suite.ctx, organizer.ID)
+	_, _, missingErr := suite.svc.IssueOrganizerToken(suite.ctx, organizer.ID+1000)
+
+	// Then
+	assert.Nil(suite.T(), defaultErr)
+	assert.Nil(suite.T(), issuedErr)
+	assert.Equal(suite.T(), service.ErrOrganizerWasNotFound, missingErr)
+
+	authenticated, err := suite.svc.AuthenticateOrganizer(suite.ctx, defaultToken)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), ticket2.DefaultOrganizerID, authenticated.ID)
+
+	authenticated, err = suite.svc.AuthenticateOrganizer(suite.ctx, issued)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), organizer.ID, authenticated.ID)
+
+	_, err = suite.svc.AuthenticateOrganizer(suite.ctx, created)
+	assert.Equal(suite.T(), service.ErrOrganizerWasNotFound, err)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Keep_Ticket_Options_Of_Each_Organizer_Apart() {
+	// Given
+	other, token, err := suite.svc.CreateOrganizer(suite.ctx, "other promoter")
+	assert.Nil(suite.T(), err)
+	authenticated, err := suite.svc.AuthenticateOrganizer(suite.ctx, token)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), other.ID, authenticated.ID)
+	otherCtx := tenant.WithOrganizer(context.TODO(), other.ID)
+
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example18", "sample description18", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	purchase, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "user")
+	assert.Nil(suite.T(), err)
+	order, err := suite.svc.CreateOrder(suite.ctx, "user", []ticket2.OrderItem{{TicketID: option.ID, Quantity: 1}})
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, getErr := suite.svc.GetTicket(otherCtx, option.ID)
+	listed, listErr := suite.svc.ListTicketOptions(otherCtx, ticket2.TicketFilter{})
+	_, purchaseErr := suite.svc.PurchaseFromTicketOption(otherCtx, option.ID, 1, "intruder")
+	_, orderErr := suite.svc.CreateOrder(otherCtx, "intruder", []ticket2.OrderItem{{TicketID: option.ID, Quantity: 1}})
+	_, getOrderErr := suite.svc.GetOrder(otherCtx, order.ID)
+	_, refundErr := suite.svc.RefundPurchase(otherCtx, purchase.ID)
+	_, issuedErr := suite.svc.GetIssuedTicket(otherCtx, purchase.IssuedTickets[0].Code)
+	_, priceTierErr := suite.svc.CreatePriceTier(otherCtx, option.ID, ticket2.PriceTier{Kind: ticket2.PriceTierSoldStep, Price: 1, AfterSold: 1})
+
+	// Then
+	assert.Equal(suite.T(), service.ErrTicketWasNotFound, getErr)
+	assert.Nil(suite.T(), listErr)
+	assert.Empty(suite.T(), listed)
+	assert.Equal(suite.T(), service.ErrTicketWasNotFound, purchaseErr)
+	assert.Equal(suite.T(), service.ErrTicketWasNotFound, orderErr)
+	assert.Equal(suite.T(), service.ErrOrderWasNotFound, getOrderErr)
+	assert.Equal(suite.T(), service.ErrPurchaseWasNotFound, refundErr)
+	assert.Equal(suite.T(), service.ErrIssuedTicketWasNotFound, issuedErr)
+	assert.Equal(suite.T(), service.ErrTicketWasNotFound, priceTierErr)
+
+	unchanged, err := suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 8, unchanged.Available)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Refuse_Purchase_Of_Another_Organizers_Option_In_Repository() {
+	// Given
+	other, _, err := suite.svc.CreateOrganizer(suite.ctx, "another promoter")
+	assert.Nil(suite.T(), err)
+	otherCtx := tenant.WithOrganizer(context.TODO(), other.ID)
+
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example19", "sample description19", 10, 0, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	defaultRepository := repository.NewDefaultRepository(suite.connectionPool)
+	quote := func(sold int) ticket2.PriceQuote { return ticket2.PriceQuote{} }
+
+	// When
+	_, err = defaultRepository.PurchaseFromTicketOption(otherCtx, option.ID, 1, "intruder", []string{"code"}, quote)
+
+	// Then
+	assert.Equal(suite.T(), repository.ErrDBTicketNotFound, err)
+
+	unchanged, err := suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 10, unchanged.Available)
+
+	_, err = defaultRepository.GetTicket(context.TODO(), option.ID)
+	assert.Equal(suite.T(), repository.ErrDBOrganizerNotGiven, err)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Keep_Ticket_Names_Unique_Per_Organizer() {
+	// Given
+	other, _, err := suite.svc.CreateOrganizer(suite.ctx, "third promoter")
+	assert.Nil(suite.T(), err)
+	otherCtx := tenant.WithOrganizer(context.TODO(), other.ID)
+
+	_, err = suite.svc.CreateTicketOption(suite.ctx, "main stage", "sample description20", 10, 0, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, otherErr := suite.svc.CreateTicketOption(otherCtx, "main stage", "sample description20", 10, 0, nil, nil, nil)
+	_, sameErr := suite.svc.CreateTicketOption(suite.ctx, "main stage", "sample description20", 10, 0, nil, nil, nil)
+
+	// Then
+	assert.Nil(suite.T(), otherErr)
+	assert.Equal(suite.T(), service.ErrNameIsDuplicate, sameErr)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Deliver_Events_Only_To_Webhooks_Of_Their_Organizer() {
+	// Given
+	other, _, err := suite.svc.CreateOrganizer(suite.ctx, "fourth promoter")
+	assert.Nil(suite.T(), err)
+	otherCtx := tenant.WithOrganizer(context.TODO(), other.ID)
+
+	webhookSvc := service.NewDefaultWebhookService(repository.NewDefaultWebhookRepository(suite.connectionPool))
+	eventTypes := ticket2.EventTypes{ticket2.EventTicketOptionCreated}
+	own, err := webhookSvc.CreateWebhook(suite.ctx, "https://default.example/hook", "secret", eventTypes)
+	assert.Nil(suite.T(), err)
+	others, err := webhookSvc.CreateWebhook(otherCtx, "https://other.example/hook", "secret", eventTypes)
+	assert.Nil(suite.T(), err)
+
+	_, err = suite.svc.CreateTicketOption(otherCtx, "example45", "sample description45", 10, 0, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	// When
+	messages, err := repository.NewDefaultOutboxRepository(suite.connectionPool).FetchPendingEvents(context.TODO(), time.Now(), 100)
+	assert.Nil(suite.T(), err)
+	for _, message := range messages {
+		assert.Nil(suite.T(), webhookSvc.Publish(context.TODO(), message.Event()))
+	}
+
+	// Then
+	ownDeliveries, err := webhookSvc.GetWebhookDeliveries(suite.ctx, own.ID)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), ownDeliveries)
+
+	otherDeliveries, err := webhookSvc.GetWebhookDeliveries(otherCtx, others.ID)
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), otherDeliveries, 1)
+
+	_, err = webhookSvc.GetWebhookDeliveries(suite.ctx, others.ID)
+	assert.Equal(suite.T(), service.ErrWebhookWasNotFound, err)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Update_And_Adjust_Ticket_Option_With_Audit() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example21", "sample description21", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	price := 1500
+
+	// When
+	updated, updateErr := suite.svc.UpdateTicketOption(suite.ctx, option.ID, ticket2.TicketUpdate{Price: &price})
+	adjusted, adjustErr := suite.svc.AdjustAllocation(suite.ctx, option.ID, -4, "held back for guests")
+	_, negativeErr := suite.svc.AdjustAllocation(suite.ctx, option.ID, -7, "too many")
+
+	// Then
+	assert.Nil(suite.T(), updateErr)
+	assert.Equal(suite.T(), 1500, updated.Price)
+	assert.Equal(suite.T(), "example21", updated.Name)
+
+	assert.Nil(suite.T(), adjustErr)
+	assert.Equal(suite.T(), 6, adjusted.Available)
+	assert.Equal(suite.T(), service.ErrAllocationWouldBeNegative, negativeErr)
+
+	entries, err := suite.svc.ListAuditEntries(suite.ctx, ticket2.AuditFilter{Target: fmt.Sprintf("ticket_option:%d", option.ID)})
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), entries, 3)
+	assert.Equal(suite.T(), ticket2.AuditTicketOptionCreated, entries[0].Action)
+	assert.Equal(suite.T(), ticket2.AuditTicketOptionUpdated, entries[1].Action)
+	assert.Equal(suite.T(), ticket2.AuditAllocationAdjusted, entries[2].Action)
+	assert.Contains(suite.T(), string(entries[2].After), `"reason":"held back for guests"`)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Create_No_Ticket_Option_When_Any_Row_Of_Bulk_Import_Fails() {
+	// Given
+	_, err := suite.svc.CreateTicketOption(suite.ctx, "example22", "sample description22", 10, 0, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	drafts := []ticket2.TicketDraft{
+		{Name: "example23", Desc: "sample description23", Allocation: 10},
+		{Name: "example22", Desc: "sample description22", Allocation: 10},
+	}
+
+	// When
+	atOnce, atOnceErr := suite.svc.CreateTicketOptions(suite.ctx, drafts, ticket2.BulkAllOrNothing)
+	oneByOne, oneByOneErr := suite.svc.CreateTicketOptions(suite.ctx, drafts, ticket2.BulkBestEffort)
+
+	// Then
+	assert.Equal(suite.T(), service.ErrBulkRowsAreInvalid, atOnceErr)
+	assert.Nil(suite.T(), atOnce[0].Ticket)
+	assert.Equal(suite.T(), service.ErrNameIsDuplicate, atOnce[1].Err)
+
+	assert.Nil(suite.T(), oneByOneErr)
+	assert.Equal(suite.T(), "example23", oneByOne[0].Ticket.Name)
+	assert.Equal(suite.T(), service.ErrNameIsDuplicate, oneByOne[1].Err)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Report_Sales_And_Refunds_Of_Ticket_Option() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example24", "sample description24", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	_, err = suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 3, "user")
+	assert.Nil(suite.T(), err)
+	refunded, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "user")
+	assert.Nil(suite.T(), err)
+	_, err = suite.svc.RefundPurchase(suite.ctx, refunded.ID)
+	assert.Nil(suite.T(), err)
+
+	items := []ticket2.OrderItem{{TicketID: option.ID, Quantity: 2}}
+	paid, err := suite.svc.CreateOrder(suite.ctx, "user", items)
+	assert.Nil(suite.T(), err)
+	_, err = suite.svc.PayOrder(suite.ctx, paid.ID)
+	assert.Nil(suite.T(), err)
+	cancelled, err := suite.svc.CreateOrder(suite.ctx, "user", items)
+	assert.Nil(suite.T(), err)
+	_, err = suite.svc.CancelOrder(suite.ctx, cancelled.ID)
+	assert.Nil(suite.T(), err)
+	_, err = suite.svc.CreateOrder(suite.ctx, "user", items)
+	assert.Nil(suite.T(), err)
+
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+
+	// When
+	var total, hourly []ticket2.SalesRow
+	totalErr := suite.svc.StreamSalesReport(suite.ctx, ticket2.SalesFilter{TicketID: option.ID, From: &from, To: &to},
+		func(row ticket2.SalesRow) error {
+			total = append(total, row)
+			return nil
+		})
+	hourlyErr := suite.svc.StreamSalesReport(suite.ctx, ticket2.SalesFilter{TicketID: option.ID, Interval: ticket2.SalesByHour},
+		func(row ticket2.SalesRow) error {
+			hourly = append(hourly, row)
+			return nil
+		})
+
+	// Then
+	assert.Nil(suite.T(), totalErr)
+	assert.Equal(suite.T(), []ticket2.SalesRow{{
+		TicketID: option.ID, TicketName: "example24", Sold: 6, Revenue: 6000, Refunded: 1, Refunds: 1000,
+		Remaining: 3, Capacity: 10, SellThrough: 50,
+	}}, total)
+
+	assert.Nil(suite.T(), hourlyErr)
+	assert.NotEmpty(suite.T(), hourly)
+	assert.NotNil(suite.T(), hourly[0].Period)
+}
+
 func createContainer() (*dockertest.Resource, *gorm.DB) {
 	pool, err := dockertest.NewPool("")
 	if err != nil {
@@ -159,3 +917,278 @@ func createContainer() (*dockertest.Resource, *gorm.DB) {
 
 	return container, connectionPool
 }
+
+// createSQLiteDatabase makes a new SQLite database for t, without Docker.
+func (suite *IntegrationTestSuite) Test_Should_Sell_Tickets_While_Sales_Report_Is_Emitted() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example48", "sample description48", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	_, err = suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "user")
+	assert.Nil(suite.T(), err)
+
+	// When
+	var purchaseErr error
+	reportErr := suite.svc.StreamSalesReport(suite.ctx, ticket2.SalesFilter{TicketID: option.ID},
+		func(ticket2.SalesRow) error {
+			_, purchaseErr = suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "user")
+			return nil
+		})
+
+	// Then
+	assert.Nil(suite.T(), reportErr)
+	assert.Nil(suite.T(), purchaseErr)
+
+	option, _ = suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Equal(suite.T(), 2, option.Sold)
+}
+
+func createSQLiteDatabase(t *testing.T) *gorm.DB {
+	t.Setenv("DATABASE_DRIVER", database.DriverSQLite)
+	t.Setenv("SQLITE_PATH", filepath.Join(t.TempDir(), "ticket.db"))
+
+	connectionPool, err := database.Setup()
+	if err != nil {
+		log.Fatalf("Could not open SQLite: %s", err)
+	}
+
+	database.Migrate()
+
+	return connectionPool
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Keep_Allocation_And_Ledger_Together() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example25", "sample description25", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	// When
+	purchase, purchaseErr := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 3, "user")
+	_, refundErr := suite.svc.RefundPurchase(suite.ctx, purchase.ID)
+	adjusted, adjustErr := suite.svc.AdjustAllocation(suite.ctx, option.ID, -2, "held back for guests")
+
+	// Then
+	assert.Nil(suite.T(), purchaseErr)
+	assert.Nil(suite.T(), refundErr)
+	assert.Nil(suite.T(), adjustErr)
+
+	entries, err := suite.svc.ListLedgerEntries(suite.ctx, ticket2.LedgerFilter{TicketID: option.ID})
+	assert.Nil(suite.T(), err)
+	assert.Len(suite.T(), entries, 4)
+
+	kinds := make([]ticket2.LedgerKind, len(entries))
+	sum := 0
+	for i, entry := range entries {
+		kinds[i] = entry.Kind
+		sum += entry.Delta
+	}
+
+	assert.Equal(suite.T(), []ticket2.LedgerKind{
+		ticket2.LedgerInitial, ticket2.LedgerPurchase, ticket2.LedgerRefund, ticket2.LedgerAdjustment,
+	}, kinds)
+	assert.Equal(suite.T(), purchase.ID, *entries[1].PurchaseID)
+	assert.Equal(suite.T(), adjusted.Available, sum)
+
+	drifts, err := suite.svc.ReconcileAllocations(suite.ctx)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), drifts)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Count_Sold_And_Held_Tickets_Apart_From_Capacity() {
+	// Given
+	general, err := suite.svc.CreateTicketOption(suite.ctx, "example26", "sample description26", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	seated, err := suite.svc.CreateTicketOption(suite.ctx, "example27", "sample description27", 1, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	_, err = suite.svc.CreateSeatMap(suite.ctx, seated.ID, ticket2.SeatMapLayout{Sections: []ticket2.SectionLayout{
+		{Name: "Stalls", Rows: []ticket2.RowLayout{{Name: "A", Seats: 6}}},
+	}})
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, purchaseErr := suite.svc.PurchaseFromTicketOption(suite.ctx, general.ID, 3, "user")
+	_, holdErr := suite.svc.HoldBestAvailable(suite.ctx, seated.ID, 2, "user")
+
+	// Then
+	assert.Nil(suite.T(), purchaseErr)
+	assert.Nil(suite.T(), holdErr)
+
+	general, err = suite.svc.GetTicket(suite.ctx, general.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{10, 3, 0, 7}, []int{general.Capacity, general.Sold, general.Held, general.Available})
+
+	seated, err = suite.svc.GetTicket(suite.ctx, seated.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{6, 0, 2, 4}, []int{seated.Capacity, seated.Sold, seated.Held, seated.Available})
+
+	drifts, err := suite.svc.ReconcileAllocations(suite.ctx)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), drifts)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Sell_Sharded_Allocation_Once_And_Rebalance_It() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example28", "sample description28", 12, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	sharded, err := suite.svc.ShardAllocation(suite.ctx, option.ID, 4)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{4, 12}, []int{sharded.Shards, sharded.Available})
+
+	// When
+	errs := make([]error, 15)
+
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, fmt.Sprintf("user%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	// Then
+	sold := 0
+	for _, err := range errs {
+		if err == nil {
+			sold++
+			continue
+		}
+		assert.Equal(suite.T(), service.ErrPurchaseTicketMoreThanAvailable, err)
+	}
+	assert.Equal(suite.T(), 12, sold)
+
+	option, err = suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{12, 12, 0}, []int{option.Capacity, option.Sold, option.Available})
+
+	adjusted, err := suite.svc.AdjustAllocation(suite.ctx, option.ID, 8, "second release")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 8, adjusted.Available)
+
+	_, err = suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 3, "user")
+	assert.Nil(suite.T(), err)
+
+	rebalanced, err := suite.svc.RebalanceShards(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{20, 15, 5}, []int{rebalanced.Capacity, rebalanced.Sold, rebalanced.Available})
+
+	unsharded, err := suite.svc.ShardAllocation(suite.ctx, option.ID, 0)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{0, 15, 5}, []int{unsharded.Shards, unsharded.Sold, unsharded.Available})
+
+	drifts, err := suite.svc.ReconcileAllocations(suite.ctx)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), drifts)
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Refund_Purchase_Of_Sharded_Allocation() {
+	// Given
+	option, err := suite.svc.CreateTicketOption(suite.ctx, "example47", "sample description47", 8, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	_, err = suite.svc.ShardAllocation(suite.ctx, option.ID, 4)
+	assert.Nil(suite.T(), err)
+
+	purchase, err := suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 2, "user")
+	assert.Nil(suite.T(), err)
+	_, err = suite.svc.PurchaseFromTicketOption(suite.ctx, option.ID, 1, "user")
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, err = suite.svc.RefundPurchase(suite.ctx, purchase.ID)
+
+	// Then
+	assert.Nil(suite.T(), err)
+
+	option, err = suite.svc.GetTicket(suite.ctx, option.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{4, 8, 1, 7}, []int{option.Shards, option.Capacity, option.Sold, option.Available})
+
+	drifts, err := suite.svc.ReconcileAllocations(suite.ctx)
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), drifts)
+}
+
+// BenchmarkPurchaseFromTicketOption compares the throughput of concurrent purchases of one ticket
+// option counted in its row with that of the same ticket option split across shards.
+func BenchmarkPurchaseFromTicketOption(b *testing.B) {
+	container, connectionPool := createContainer()
+	defer container.Close() //nolint:errcheck
+
+	svc := service.NewDefaultService(repository.NewDefaultRepository(connectionPool))
+	ctx := tenant.WithOrganizer(context.TODO(), ticket2.DefaultOrganizerID)
+
+	for _, shards := range []int{0, 4, 16} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			name := fmt.Sprintf("benchmark-%d-%d", shards, b.N)
+			option, err := svc.CreateTicketOption(ctx, name, "benchmark", b.N, 1000, nil, nil, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if _, err = svc.ShardAllocation(ctx, option.ID, shards); err != nil {
+				b.Fatal(err)
+			}
+
+			var failed sync.Map
+
+			b.SetParallelism(8)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := svc.PurchaseFromTicketOption(ctx, option.ID, 1, "benchmark"); err != nil {
+						failed.Store(err.Error(), err)
+					}
+				}
+			})
+			b.StopTimer()
+
+			failed.Range(func(message, _ interface{}) bool {
+				b.Log(message)
+				return true
+			})
+		})
+	}
+}
+
+// unreachableReplica is a read replica every read from fails, to tell reads made on it apart.
+type unreachableReplica struct {
+	db *gorm.DB
+}
+
+func (r unreachableReplica) Reader() *gorm.DB {
+	return r.db
+}
+
+func (suite *IntegrationTestSuite) Test_Should_Read_From_Replica_Unless_Just_Purchased() {
+	// Given
+	replica, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1 connect_timeout=1"}),
+		&gorm.Config{DisableAutomaticPing: true})
+	assert.Nil(suite.T(), err)
+
+	defaultRepository := repository.NewDefaultRepository(suite.connectionPool,
+		repository.WithReadReplicas(unreachableReplica{db: replica}),
+		repository.WithReadYourWrites(time.Minute))
+	quote := func(sold int) ticket2.PriceQuote { return ticket2.PriceQuote{} }
+
+	purchased, err := suite.svc.CreateTicketOption(suite.ctx, "example29", "sample description29", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+	other, err := suite.svc.CreateTicketOption(suite.ctx, "example30", "sample description30", 10, 1000, nil, nil, nil)
+	assert.Nil(suite.T(), err)
+
+	// When
+	_, err = defaultRepository.PurchaseFromTicketOption(suite.ctx, purchased.ID, 2, "reader", []string{"code29a", "code29b"}, quote)
+	assert.Nil(suite.T(), err)
+
+	option, optionErr := defaultRepository.GetTicket(suite.ctx, purchased.ID)
+	purchases, purchasesErr := defaultRepository.ListPurchases(suite.ctx, ticket2.PurchaseFilter{UserID: "reader", Limit: 10})
+	_, otherErr := defaultRepository.GetTicket(suite.ctx, other.ID)
+
+	// Then
+	assert.Nil(suite.T(), optionErr)
+	assert.Equal(suite.T(), 8, option.Available)
+	assert.Nil(suite.T(), purchasesErr)
+	assert.Len(suite.T(), purchases, 1)
+	assert.NotNil(suite.T(), otherErr, "ticket options nobody purchased are read from the replica")
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/dilaragorum/ticket-api/internal/ticket"
 	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
 	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
 	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketcode"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,16 +18,16 @@ import (
 // Create Ticket Option Unit Tests
 func Test_Should_Return_Successfully_Create_When_TicketOption_Is_Valid(t *testing.T) {
 	// Given
-	ticketOption := ticket.Ticket{ID: 1, Name: "example", Desc: "sample description", Allocation: 100}
+	ticketOption := ticket.Ticket{ID: 1, Name: "example", Desc: "sample description", Available: 100}
 	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
 	mockRepository.
-		EXPECT().CreateTicketOption(gomock.Any(), "example", "sample description", 100).
+		EXPECT().CreateTicketOption(gomock.Any(), "example", "sample description", 100, 0, nil, nil, nil).
 		Return(&ticketOption, nil).Times(1)
 
 	ticketOptService := service.NewDefaultService(mockRepository)
 
 	// When
-	actualTicketOption, err := ticketOptService.CreateTicketOption(context.TODO(), "example", "sample description", 100)
+	actualTicketOption, err := ticketOptService.CreateTicketOption(context.TODO(), "example", "sample description", 100, 0, nil, nil, nil)
 
 	// Then
 	assert.Nil(t, err)
@@ -96,13 +98,13 @@ func Test_Should_Return_Error_When_Creating_TicketOption_Is_Not_Valid(t *testing
 			// Given
 			mockRepository := mocks.NewMockRepository(gomock.NewController(t))
 			mockRepository.EXPECT().
-				CreateTicketOption(gomock.Any(), test.ticketName, test.ticketDescription, test.ticketAllocation).
+				CreateTicketOption(gomock.Any(), test.ticketName, test.ticketDescription, test.ticketAllocation, 0, nil, nil, nil).
 				Return(nil, test.mockRepositoryErr).Times(test.mockRepositoryTimes)
 
 			svc := service.NewDefaultService(mockRepository)
 
 			// When
-			option, err := svc.CreateTicketOption(context.TODO(), test.ticketName, test.ticketDescription, test.ticketAllocation)
+			option, err := svc.CreateTicketOption(context.TODO(), test.ticketName, test.ticketDescription, test.ticketAllocation, 0, nil, nil, nil)
 
 			// Then
 			assert.Equal(t, test.expectedCreatingStatusErr, err)
@@ -115,10 +117,10 @@ func Test_Should_Return_Error_When_Creating_TicketOption_Is_Not_Valid(t *testing
 func Test_Should_Return_Success_When_Get_TicketOption(t *testing.T) {
 	// Given
 	expectedTicket := ticket.Ticket{
-		ID:         1,
-		Name:       "Example",
-		Desc:       "Sample Description",
-		Allocation: 100,
+		ID:        1,
+		Name:      "Example",
+		Desc:      "Sample Description",
+		Available: 100,
 	}
 
 	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
@@ -181,25 +183,27 @@ func Test_Should_Return_Error_Cases_When_Get_TicketOption(t *testing.T) {
 func Test_Should_Return_Success_When_User_Can_Purchase_Specified_Ticket(t *testing.T) {
 	// Given
 	expectedTicket := ticket.Ticket{
-		ID:         1,
-		Name:       "Example",
-		Desc:       "Sample Description",
-		Allocation: 100,
+		ID:        1,
+		Name:      "Example",
+		Desc:      "Sample Description",
+		Available: 100,
 	}
+	expectedPurchase := ticket.Purchase{ID: 7, UserID: "406c1d05-bbb2-4e94-b183-7d208c2692e1", TicketID: 1, Quantity: 20}
 
 	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
 	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&expectedTicket, nil).Times(1)
 	mockRepository.
-		EXPECT().PurchaseFromTicketOption(gomock.Any(), expectedTicket.ID, 20, "406c1d05-bbb2-4e94-b183-7d208c2692e1").
-		Return(nil).Times(1)
+		EXPECT().PurchaseFromTicketOption(gomock.Any(), expectedTicket.ID, 20, "406c1d05-bbb2-4e94-b183-7d208c2692e1", gomock.Len(20), gomock.Any()).
+		Return(&expectedPurchase, nil).Times(1)
 
 	ticketService := service.NewDefaultService(mockRepository)
 
 	// When
-	err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 20, "406c1d05-bbb2-4e94-b183-7d208c2692e1")
+	purchase, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 20, "406c1d05-bbb2-4e94-b183-7d208c2692e1")
 
 	// Then
 	assert.Nil(t, err)
+	assert.Equal(t, expectedPurchase, *purchase)
 }
 
 func Test_Should_Return_Error_When_User_Want_To_Purchase_Specified_Ticket(t *testing.T) {
@@ -208,7 +212,7 @@ func Test_Should_Return_Error_When_User_Want_To_Purchase_Specified_Ticket(t *tes
 		ticketService := service.NewDefaultService(nil)
 
 		// When
-		err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 0, "406c1d05-bbb2-4e94-b183-7d208c2692e1")
+		_, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 0, "406c1d05-bbb2-4e94-b183-7d208c2692e1")
 
 		// Then
 		assert.Equal(t, service.ErrQuantityLowerThanOne, err)
@@ -221,7 +225,7 @@ func Test_Should_Return_Error_When_User_Want_To_Purchase_Specified_Ticket(t *tes
 		ticketService := service.NewDefaultService(mockRepository)
 
 		// When
-		err := ticketService.PurchaseFromTicketOption(context.TODO(), 1999, 100, "userId")
+		_, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1999, 100, "userId")
 
 		// Then
 		assert.Error(t, err)
@@ -230,10 +234,10 @@ func Test_Should_Return_Error_When_User_Want_To_Purchase_Specified_Ticket(t *tes
 	t.Run("Test_Should_Return_Err_Purchase_Ticket_More_Than_Available", func(t *testing.T) {
 		// Given
 		ticketTest := ticket.Ticket{
-			ID:         1,
-			Name:       "sample",
-			Desc:       "example desc",
-			Allocation: 50,
+			ID:        1,
+			Name:      "sample",
+			Desc:      "example desc",
+			Available: 50,
 		}
 
 		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
@@ -242,7 +246,7 @@ func Test_Should_Return_Error_When_User_Want_To_Purchase_Specified_Ticket(t *tes
 		ticketService := service.NewDefaultService(mockRepository)
 
 		// When
-		err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 100, "test")
+		_, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 100, "test")
 
 		// Then
 		assert.Equal(t, service.ErrPurchaseTicketMoreThanAvailable, err)
@@ -250,18 +254,18 @@ func Test_Should_Return_Error_When_User_Want_To_Purchase_Specified_Ticket(t *tes
 
 	t.Run("Test_Should_Return_Error_When_User_Cannot_Purchase_Specified_Ticket", func(t *testing.T) {
 		getTicketResponse := ticket.Ticket{
-			ID:         1,
-			Name:       "sample getTicketResponse",
-			Desc:       "example getTicketResponse description",
-			Allocation: 100,
+			ID:        1,
+			Name:      "sample getTicketResponse",
+			Desc:      "example getTicketResponse description",
+			Available: 100,
 		}
 
 		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
 		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&getTicketResponse, nil).Times(1)
-		mockRepository.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 50, "test").Return(errors.New("test")).Times(1)
+		mockRepository.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 50, "test", gomock.Len(50), gomock.Any()).Return(nil, errors.New("test")).Times(1)
 
 		defaultService := service.NewDefaultService(mockRepository)
-		err := defaultService.PurchaseFromTicketOption(context.TODO(), 1, 50, "test")
+		_, err := defaultService.PurchaseFromTicketOption(context.TODO(), 1, 50, "test")
 
 		assert.Error(t, err)
 	})
@@ -271,8 +275,306 @@ func Test_Should_Return_Error_When_User_Want_To_Purchase_Specified_Ticket(t *tes
 		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(nil, errors.New("test")).Times(1)
 
 		defaultService := service.NewDefaultService(mockRepository)
-		err := defaultService.PurchaseFromTicketOption(context.TODO(), 1, 20, "testUserId")
+		_, err := defaultService.PurchaseFromTicketOption(context.TODO(), 1, 20, "testUserId")
 
 		assert.Error(t, err)
 	})
 }
+
+// Refund Purchase Unit Tests
+func Test_Should_Return_Refunded_Purchase_When_Refund_Purchase(t *testing.T) {
+	// Given
+	refundedAt := time.Now()
+	expectedPurchase := ticket.Purchase{ID: 3, UserID: "test", TicketID: 1, Quantity: 2, RefundedAt: &refundedAt}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().RefundPurchase(gomock.Any(), 3).Return(&expectedPurchase, nil).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	actualPurchase, err := ticketService.RefundPurchase(context.TODO(), 3)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPurchase, *actualPurchase)
+}
+
+func Test_Should_Return_Error_When_Refund_Purchase(t *testing.T) {
+	type testCase struct {
+		testName            string
+		purchaseID          int
+		mockRepositoryTimes int
+		mockRepositoryErr   error
+		expectedErr         error
+	}
+
+	dbErr := errors.New("test")
+	testCases := []testCase{
+		{
+			testName:            "Test_Should_Return_Error_When_ID_Lower_Than_One",
+			purchaseID:          0,
+			mockRepositoryTimes: 0,
+			expectedErr:         service.ErrIDLowerThanOne,
+		},
+		{
+			testName:            "Test_Should_Return_Error_When_Purchase_Not_Found",
+			purchaseID:          3,
+			mockRepositoryTimes: 1,
+			mockRepositoryErr:   repository.ErrDBPurchaseNotFound,
+			expectedErr:         service.ErrPurchaseWasNotFound,
+		},
+		{
+			testName:            "Test_Should_Return_Error_When_Purchase_Already_Refunded",
+			purchaseID:          3,
+			mockRepositoryTimes: 1,
+			mockRepositoryErr:   repository.ErrDBPurchaseAlreadyRefunded,
+			expectedErr:         service.ErrPurchaseAlreadyRefunded,
+		},
+		{
+			testName:            "Test_Should_Return_Database_Error",
+			purchaseID:          3,
+			mockRepositoryTimes: 1,
+			mockRepositoryErr:   dbErr,
+			expectedErr:         dbErr,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			// Given
+			mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+			mockRepository.EXPECT().RefundPurchase(gomock.Any(), test.purchaseID).
+				Return(nil, test.mockRepositoryErr).Times(test.mockRepositoryTimes)
+
+			ticketService := service.NewDefaultService(mockRepository)
+
+			// When
+			purchase, err := ticketService.RefundPurchase(context.TODO(), test.purchaseID)
+
+			// Then
+			assert.Nil(t, purchase)
+			assert.Equal(t, test.expectedErr, err)
+		})
+	}
+}
+
+func Test_Should_Notify_Availability_When_Purchase_Succeeds(t *testing.T) {
+	// Given
+	controller := gomock.NewController(t)
+	mockRepository := mocks.NewMockRepository(controller)
+	mockNotifier := mocks.NewMockAvailabilityNotifier(controller)
+
+	gomock.InOrder(
+		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 100}, nil),
+		mockRepository.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 20, "test", gomock.Len(20), gomock.Any()).Return(&ticket.Purchase{ID: 7}, nil),
+		mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 80}, nil),
+		mockNotifier.EXPECT().NotifyAvailability(ticket.Ticket{ID: 1, Available: 80}),
+	)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithAvailabilityNotifier(mockNotifier))
+
+	// When
+	_, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 20, "test")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func Test_Should_Notify_Availability_When_Refund_Succeeds(t *testing.T) {
+	// Given
+	controller := gomock.NewController(t)
+	mockRepository := mocks.NewMockRepository(controller)
+	mockNotifier := mocks.NewMockAvailabilityNotifier(controller)
+
+	mockRepository.EXPECT().RefundPurchase(gomock.Any(), 3).Return(&ticket.Purchase{ID: 3, TicketID: 1, Quantity: 20}, nil).Times(1)
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 100}, nil).Times(1)
+	mockNotifier.EXPECT().NotifyAvailability(ticket.Ticket{ID: 1, Available: 100}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithAvailabilityNotifier(mockNotifier))
+
+	// When
+	_, err := ticketService.RefundPurchase(context.TODO(), 3)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+// List Ticket Options Unit Tests
+func Test_Should_Normalize_Filter_When_List_Ticket_Options(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		testName       string
+		filter         ticket.TicketFilter
+		expectedFilter ticket.TicketFilter
+	}
+
+	testCases := []testCase{
+		{
+			testName:       "Test_Should_Use_Default_Limit_When_Limit_Is_Missing",
+			filter:         ticket.TicketFilter{},
+			expectedFilter: ticket.TicketFilter{Limit: service.DefaultListLimit},
+		},
+		{
+			testName:       "Test_Should_Cap_Limit",
+			filter:         ticket.TicketFilter{Limit: 1000, AfterID: 10},
+			expectedFilter: ticket.TicketFilter{Limit: service.MaxListLimit, AfterID: 10},
+		},
+		{
+			testName:       "Test_Should_Ignore_Negative_After_ID",
+			filter:         ticket.TicketFilter{Limit: 5, AfterID: -3},
+			expectedFilter: ticket.TicketFilter{Limit: 5},
+		},
+		{
+			testName:       "Test_Should_Filter_On_Sale_At_Current_Time",
+			filter:         ticket.TicketFilter{Limit: 5, OnSale: true},
+			expectedFilter: ticket.TicketFilter{Limit: 5, OnSale: true, At: now},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			// Given
+			expected := []ticket.Ticket{{ID: 11, Name: "example", Desc: "sample description", Available: 100}}
+			mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+			mockRepository.EXPECT().ListTicketOptions(gomock.Any(), test.expectedFilter).Return(expected, nil).Times(1)
+
+			ticketService := service.NewDefaultService(mockRepository, service.WithClock(func() time.Time { return now }))
+
+			// When
+			actual, err := ticketService.ListTicketOptions(context.TODO(), test.filter)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+// Sale Window Unit Tests
+
+func Test_Should_Purchase_Only_Within_Sale_Window(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+
+	type testCase struct {
+		testName      string
+		saleStartsAt  *time.Time
+		saleEndsAt    *time.Time
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{testName: "Test_Should_Purchase_Without_Sale_Window"},
+		{testName: "Test_Should_Purchase_When_Sale_Starts_Now", saleStartsAt: &now, saleEndsAt: &later},
+		{testName: "Test_Should_Purchase_Before_Sale_Ends", saleStartsAt: &earlier, saleEndsAt: &later},
+		{testName: "Test_Should_Reject_Purchase_Before_Sale_Starts", saleStartsAt: &later, expectedError: service.ErrSaleNotStarted},
+		{testName: "Test_Should_Reject_Purchase_When_Sale_Ends_Now", saleEndsAt: &now, expectedError: service.ErrSaleEnded},
+		{testName: "Test_Should_Reject_Purchase_After_Sale_Ended", saleStartsAt: &earlier, saleEndsAt: &earlier, expectedError: service.ErrSaleEnded},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			// Given
+			option := &ticket.Ticket{ID: 1, Available: 10, SaleStartsAt: test.saleStartsAt, SaleEndsAt: test.saleEndsAt}
+
+			mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+			mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(option, nil).Times(1)
+			if test.expectedError == nil {
+				mockRepository.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 1, "test", gomock.Len(1), gomock.Any()).
+					Return(&ticket.Purchase{ID: 1, TicketID: 1, Quantity: 1}, nil).Times(1)
+			}
+
+			ticketService := service.NewDefaultService(mockRepository, service.WithClock(func() time.Time { return now }))
+
+			// When
+			_, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 1, "test")
+
+			// Then
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_Should_Return_Error_When_Sale_Window_Is_Invalid(t *testing.T) {
+	// Given
+	starts := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	ends := starts.Add(-time.Minute)
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().CreateTicketOption(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	option, err := ticketService.CreateTicketOption(context.TODO(), "example", "sample description", 10, 0, nil, &starts, &ends)
+
+	// Then
+	assert.Nil(t, option)
+	assert.Equal(t, service.ErrSaleWindowIsInvalid, err)
+}
+
+// Issued Ticket Unit Tests
+func Test_Should_Issue_One_Verifiable_Code_Per_Ticket_When_Purchase(t *testing.T) {
+	// Given
+	signer := ticketcode.NewRandomSigner()
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 10}, nil).Times(1)
+	mockRepository.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 3, "test", gomock.Len(3), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id, quantity int, userID string, codes []string, _ ticket.PriceQuoter) (*ticket.Purchase, error) {
+			assert.NotEqual(t, codes[0], codes[1])
+			for _, code := range codes {
+				claims, err := signer.Verify(code)
+				assert.Nil(t, err)
+				assert.Equal(t, 1, claims.TicketID)
+			}
+			return &ticket.Purchase{ID: 7}, nil
+		}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithCodeIssuer(signer))
+
+	// When
+	_, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 3, "test")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func Test_Should_Return_Error_When_Get_Purchase_Tickets(t *testing.T) {
+	t.Run("Test_Should_Return_Error_When_ID_Lower_Than_One", func(t *testing.T) {
+		ticketService := service.NewDefaultService(nil)
+
+		issued, err := ticketService.GetPurchaseTickets(context.TODO(), 0)
+
+		assert.Nil(t, issued)
+		assert.Equal(t, service.ErrIDLowerThanOne, err)
+	})
+
+	t.Run("Test_Should_Return_Error_When_Purchase_Not_Found", func(t *testing.T) {
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		mockRepository.EXPECT().GetPurchaseTickets(gomock.Any(), 3).Return(nil, repository.ErrDBPurchaseNotFound).Times(1)
+		ticketService := service.NewDefaultService(mockRepository)
+
+		issued, err := ticketService.GetPurchaseTickets(context.TODO(), 3)
+
+		assert.Nil(t, issued)
+		assert.Equal(t, service.ErrPurchaseWasNotFound, err)
+	})
+}
+
+func Test_Should_Return_Error_When_Issued_Ticket_Not_Found(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetIssuedTicket(gomock.Any(), "code").Return(nil, repository.ErrDBIssuedTicketNotFound).Times(1)
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	issued, err := ticketService.GetIssuedTicket(context.TODO(), "code")
+
+	// Then
+	assert.Nil(t, issued)
+	assert.Equal(t, service.ErrIssuedTicketWasNotFound, err)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+// MaxAllocationShards is the most counter shards a ticket option can be split across.
+const MaxAllocationShards = 64
+
+var (
+	ErrShardsOutOfRange         = errors.New("shards should be between zero and 64")
+	ErrTicketOptionIsNotSharded = errors.New("ticket option is not sharded")
+)
+
+// ShardAllocation splits the tickets left of a ticket option across shards counters, so that
+// purchases of it during an on-sale do not all wait on one row. 0 stops sharding it. Seated ticket
+// options cannot be sharded.
+func (s *DefaultService) ShardAllocation(ctx context.Context, id, shards int) (*ticket.Ticket, error) {
+	if id < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	if shards < 0 || shards > MaxAllocationShards {
+		return nil, ErrShardsOutOfRange
+	}
+
+	option, err := s.repository.ShardAllocation(ctx, id, shards)
+	if err != nil {
+		switch err {
+		case repository.ErrDBTicketNotFound:
+			return nil, ErrTicketWasNotFound
+		case repository.ErrDBTicketOptionIsSeated:
+			return nil, ErrTicketOptionIsSeated
+		default:
+			return nil, err
+		}
+	}
+
+	return option, nil
+}
+
+// RebalanceShards spreads the tickets left of a sharded ticket option evenly across its shards
+// again, for when purchases emptied some of them.
+func (s *DefaultService) RebalanceShards(ctx context.Context, id int) (*ticket.Ticket, error) {
+	if id < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	option, err := s.repository.RebalanceShards(ctx, id)
+	if err != nil {
+		switch err {
+		case repository.ErrDBTicketNotFound:
+			return nil, ErrTicketWasNotFound
+		case repository.ErrDBTicketOptionIsNotSharded:
+			return nil, ErrTicketOptionIsNotSharded
+		default:
+			return nil, err
+		}
+	}
+
+	return option, nil
+}
@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Shard Unit Tests
+
+func Test_Should_Shard_Allocation(t *testing.T) {
+	// Given
+	expected := &ticket.Ticket{ID: 1, Capacity: 100, Available: 100, Shards: 8}
+
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().ShardAllocation(gomock.Any(), 1, 8).Return(expected, nil).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	option, err := ticketService.ShardAllocation(context.TODO(), 1, 8)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, expected, option)
+}
+
+func Test_Should_Return_Error_When_Shard_Allocation_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		id              int
+		shards          int
+		repositoryError error
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{id: 0, shards: 8, expectedError: service.ErrIDLowerThanOne},
+		{id: 1, shards: -1, expectedError: service.ErrShardsOutOfRange},
+		{id: 1, shards: service.MaxAllocationShards + 1, expectedError: service.ErrShardsOutOfRange},
+		{id: 1, shards: 8, repositoryError: repository.ErrDBTicketNotFound, expectedError: service.ErrTicketWasNotFound},
+		{id: 1, shards: 8, repositoryError: repository.ErrDBTicketOptionIsSeated, expectedError: service.ErrTicketOptionIsSeated},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		if test.repositoryError != nil {
+			mockRepository.EXPECT().ShardAllocation(gomock.Any(), test.id, test.shards).Return(nil, test.repositoryError).Times(1)
+		}
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		option, err := ticketService.ShardAllocation(context.TODO(), test.id, test.shards)
+
+		// Then
+		assert.Nil(t, option)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Return_Error_When_Rebalance_Shards_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		id              int
+		repositoryError error
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{id: 0, expectedError: service.ErrIDLowerThanOne},
+		{id: 1, repositoryError: repository.ErrDBTicketNotFound, expectedError: service.ErrTicketWasNotFound},
+		{id: 1, repositoryError: repository.ErrDBTicketOptionIsNotSharded, expectedError: service.ErrTicketOptionIsNotSharded},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		if test.repositoryError != nil {
+			mockRepository.EXPECT().RebalanceShards(gomock.Any(), test.id).Return(nil, test.repositoryError).Times(1)
+		}
+
+		ticketService := service.NewDefaultService(mockRepository)
+
+		// When
+		option, err := ticketService.RebalanceShards(context.TODO(), test.id)
+
+		// Then
+		assert.Nil(t, option)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Return_Error_When_Tickets_Left_Were_Sold_During_Purchase(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, Available: 2, Shards: 8}, nil).Times(1)
+	mockRepository.EXPECT().PurchaseFromTicketOption(gomock.Any(), 1, 2, "test", gomock.Len(2), gomock.Any()).
+		Return(nil, repository.ErrDBNotEnoughAllocation).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	purchase, err := ticketService.PurchaseFromTicketOption(context.TODO(), 1, 2, "test")
+
+	// Then
+	assert.Nil(t, purchase)
+	assert.Equal(t, service.ErrPurchaseTicketMoreThanAvailable, err)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+// TransferIssuedTicket gives the ticket with code to toUserID. The old code is replaced by a newly
+// issued one, so whoever still holds it cannot get in. Used and refunded tickets cannot be
+// transferred, nor can any ticket once its event is within the transfer cutoff.
+func (s *DefaultService) TransferIssuedTicket(ctx context.Context, code, toUserID string) (*ticket.IssuedTicket, error) {
+	if toUserID == "" {
+		return nil, ErrUserIDIsEmpty
+	}
+
+	issued, err := s.GetIssuedTicket(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkTransferable(ctx, issued, toUserID); err != nil {
+		return nil, err
+	}
+
+	newCode, err := s.issuer.Issue(issued.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	transferred, err := s.repository.TransferIssuedTicket(ctx, code, toUserID, newCode)
+	if err != nil {
+		switch err {
+		case repository.ErrDBIssuedTicketNotFound:
+			return nil, ErrIssuedTicketWasNotFound
+		case repository.ErrDBIssuedTicketAlreadyUsed:
+			return nil, ErrIssuedTicketAlreadyUsed
+		case repository.ErrDBIssuedTicketRefunded:
+			return nil, ErrIssuedTicketRefunded
+		default:
+			return nil, err
+		}
+	}
+
+	return transferred, nil
+}
+
+func (s *DefaultService) GetIssuedTicketTransfers(ctx context.Context, code string) ([]ticket.TicketTransfer, error) {
+	if code == "" {
+		return nil, ErrIssuedTicketWasNotFound
+	}
+
+	transfers, err := s.repository.GetIssuedTicketTransfers(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrDBIssuedTicketNotFound) {
+			return nil, ErrIssuedTicketWasNotFound
+		}
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// checkTransferable tells whether issued may change hands to toUserID now.
+func (s *DefaultService) checkTransferable(ctx context.Context, issued *ticket.IssuedTicket, toUserID string) error {
+	switch {
+	case issued.Status == ticket.IssuedTicketUsed:
+		return ErrIssuedTicketAlreadyUsed
+	case issued.Status == ticket.IssuedTicketRefunded:
+		return ErrIssuedTicketRefunded
+	case issued.UserID == toUserID:
+		return ErrTransferToSameUser
+	}
+
+	option, err := s.GetTicket(ctx, issued.TicketID)
+	if err != nil {
+		return err
+	}
+
+	if option.StartsAt != nil && !s.now().Before(option.StartsAt.Add(-s.transferCutoff)) {
+		return ErrTransferWindowClosed
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketcode"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Transfer Unit Tests
+
+func Test_Should_Transfer_Issued_Ticket_Under_A_New_Code(t *testing.T) {
+	// Given
+	signer := ticketcode.NewRandomSigner()
+	startsAt := time.Now().Add(48 * time.Hour)
+
+	issued := &ticket.IssuedTicket{ID: 1, Code: "old", TicketID: 1, UserID: "alice", Status: ticket.IssuedTicketValid}
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetIssuedTicket(gomock.Any(), "old").Return(issued, nil).Times(1)
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, StartsAt: &startsAt}, nil).Times(1)
+
+	var newCode string
+	mockRepository.EXPECT().TransferIssuedTicket(gomock.Any(), "old", "bob", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, toUserID, code string) (*ticket.IssuedTicket, error) {
+			newCode = code
+			return &ticket.IssuedTicket{ID: 1, Code: code, TicketID: 1, UserID: toUserID, Status: ticket.IssuedTicketValid}, nil
+		}).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository, service.WithCodeIssuer(signer))
+
+	// When
+	actual, err := ticketService.TransferIssuedTicket(context.TODO(), "old", "bob")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "bob", actual.UserID)
+	assert.NotEqual(t, "old", newCode)
+
+	claims, err := signer.Verify(newCode)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, claims.TicketID)
+}
+
+func Test_Should_Reject_Transfer_When_Ticket_Is_Not_Transferable(t *testing.T) {
+	soon := time.Now().Add(time.Hour)
+
+	type testCase struct {
+		toUserID      string
+		issued        *ticket.IssuedTicket
+		startsAt      *time.Time
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{
+			toUserID:      "",
+			expectedError: service.ErrUserIDIsEmpty,
+		},
+		{
+			toUserID:      "bob",
+			issued:        &ticket.IssuedTicket{TicketID: 1, UserID: "alice", Status: ticket.IssuedTicketUsed},
+			expectedError: service.ErrIssuedTicketAlreadyUsed,
+		},
+		{
+			toUserID:      "bob",
+			issued:        &ticket.IssuedTicket{TicketID: 1, UserID: "alice", Status: ticket.IssuedTicketRefunded},
+			expectedError: service.ErrIssuedTicketRefunded,
+		},
+		{
+			toUserID:      "alice",
+			issued:        &ticket.IssuedTicket{TicketID: 1, UserID: "alice", Status: ticket.IssuedTicketValid},
+			expectedError: service.ErrTransferToSameUser,
+		},
+		{
+			toUserID:      "bob",
+			issued:        &ticket.IssuedTicket{TicketID: 1, UserID: "alice", Status: ticket.IssuedTicketValid},
+			startsAt:      &soon,
+			expectedError: service.ErrTransferWindowClosed,
+		},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+		if test.issued != nil {
+			mockRepository.EXPECT().GetIssuedTicket(gomock.Any(), "old").Return(test.issued, nil).Times(1)
+		}
+		if test.startsAt != nil {
+			mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1, StartsAt: test.startsAt}, nil).Times(1)
+		}
+		mockRepository.EXPECT().TransferIssuedTicket(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ticketService := service.NewDefaultService(mockRepository, service.WithTransferCutoff(2*time.Hour))
+
+		// When
+		actual, err := ticketService.TransferIssuedTicket(context.TODO(), "old", test.toUserID)
+
+		// Then
+		assert.Nil(t, actual)
+		assert.Equal(t, test.expectedError, err)
+	}
+}
+
+func Test_Should_Return_Error_When_Ticket_Is_Used_While_Transferring(t *testing.T) {
+	// Given
+	issued := &ticket.IssuedTicket{ID: 1, Code: "old", TicketID: 1, UserID: "alice", Status: ticket.IssuedTicketValid}
+	mockRepository := mocks.NewMockRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetIssuedTicket(gomock.Any(), "old").Return(issued, nil).Times(1)
+	mockRepository.EXPECT().GetTicket(gomock.Any(), 1).Return(&ticket.Ticket{ID: 1}, nil).Times(1)
+	mockRepository.EXPECT().TransferIssuedTicket(gomock.Any(), "old", "bob", gomock.Any()).
+		Return(nil, repository.ErrDBIssuedTicketAlreadyUsed).Times(1)
+
+	ticketService := service.NewDefaultService(mockRepository)
+
+	// When
+	actual, err := ticketService.TransferIssuedTicket(context.TODO(), "old", "bob")
+
+	// Then
+	assert.Nil(t, actual)
+	assert.Equal(t, service.ErrIssuedTicketAlreadyUsed, err)
+}
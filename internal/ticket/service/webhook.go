@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+)
+
+var (
+	ErrWebhookURLIsInvalid        = errors.New("webhook url must be an absolute http or https url")
+	ErrWebhookEventTypesIsEmpty   = errors.New("webhook must subscribe to at least one event type")
+	ErrWebhookEventTypeIsUnknown  = errors.New("webhook event type is unknown")
+	ErrWebhookSecretIsEmpty       = errors.New("webhook secret should not be empty")
+	ErrWebhookWasNotFound         = errors.New("webhook does not exist")
+	ErrWebhookDeliveryWasNotFound = errors.New("webhook delivery does not exist")
+)
+
+var webhookEventTypes = ticket.EventTypes{
+	ticket.EventTicketOptionCreated,
+	ticket.EventTicketPurchased,
+	ticket.EventTicketSoldOut,
+	ticket.EventPurchaseRefunded,
+	ticket.EventTicketTransferred,
+	ticket.EventResaleListingSold,
+}
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, url, secret string, eventTypes ticket.EventTypes) (*ticket.WebhookSubscription, error)
+	GetWebhookDeliveries(ctx context.Context, webhookID int) ([]ticket.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID int) (*ticket.WebhookDelivery, error)
+	Publish(ctx context.Context, event ticket.Event) error
+}
+
+type DefaultWebhookService struct {
+	repository repository.WebhookRepository
+	now        func() time.Time
+}
+
+func NewDefaultWebhookService(repository repository.WebhookRepository) *DefaultWebhookService {
+	return &DefaultWebhookService{repository: repository, now: time.Now}
+}
+
+func (s *DefaultWebhookService) CreateWebhook(ctx context.Context, rawURL, secret string,
+	eventTypes ticket.EventTypes) (*ticket.WebhookSubscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrWebhookURLIsInvalid
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, ErrWebhookEventTypesIsEmpty
+	}
+
+	for _, eventType := range eventTypes {
+		if !webhookEventTypes.Contains(eventType) {
+			return nil, ErrWebhookEventTypeIsUnknown
+		}
+	}
+
+	if secret == "" {
+		return nil, ErrWebhookSecretIsEmpty
+	}
+
+	return s.repository.CreateWebhookSubscription(ctx, rawURL, secret, eventTypes)
+}
+
+func (s *DefaultWebhookService) GetWebhookDeliveries(ctx context.Context, webhookID int) ([]ticket.WebhookDelivery, error) {
+	if webhookID < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	if _, err := s.repository.GetWebhookSubscription(ctx, webhookID); err != nil {
+		if errors.Is(err, repository.ErrDBWebhookNotFound) {
+			return nil, ErrWebhookWasNotFound
+		}
+		return nil, err
+	}
+
+	return s.repository.ListWebhookDeliveries(ctx, webhookID)
+}
+
+func (s *DefaultWebhookService) Redeliver(ctx context.Context, webhookID, deliveryID int) (*ticket.WebhookDelivery, error) {
+	if webhookID < 1 || deliveryID < 1 {
+		return nil, ErrIDLowerThanOne
+	}
+
+	delivery, err := s.repository.ResetWebhookDelivery(ctx, webhookID, deliveryID, s.now())
+	if err != nil {
+		if errors.Is(err, repository.ErrDBWebhookDeliveryNotFound) {
+			return nil, ErrWebhookDeliveryWasNotFound
+		}
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Publish queues a delivery of event for every webhook of its organizer subscribed to its type. It
+// implements outbox.EventPublisher, so the outbox relay feeds webhooks without knowing about them.
+func (s *DefaultWebhookService) Publish(ctx context.Context, event ticket.Event) error {
+	ctx = tenant.WithOrganizer(ctx, event.OrganizerID)
+
+	subscriptions, err := s.repository.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []ticket.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !subscription.EventTypes.Contains(event.Type) {
+			continue
+		}
+
+		deliveries = append(deliveries, ticket.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        event.Payload,
+			OccurredAt:     event.OccurredAt,
+			Status:         ticket.WebhookDeliveryPending,
+			NextAttemptAt:  s.now(),
+		})
+	}
+
+	return s.repository.CreateWebhookDeliveries(ctx, deliveries)
+}
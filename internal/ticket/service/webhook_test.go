@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Create Webhook Unit Tests
+func Test_Should_Create_Webhook_When_Request_Is_Valid(t *testing.T) {
+	// Given
+	eventTypes := ticket.EventTypes{ticket.EventTicketPurchased, ticket.EventTicketSoldOut}
+	expected := ticket.WebhookSubscription{ID: 1, URL: "https://partner.example/hook", EventTypes: eventTypes}
+
+	mockRepository := mocks.NewMockWebhookRepository(gomock.NewController(t))
+	mockRepository.EXPECT().CreateWebhookSubscription(gomock.Any(), "https://partner.example/hook", "secret", eventTypes).
+		Return(&expected, nil).Times(1)
+
+	webhookService := service.NewDefaultWebhookService(mockRepository)
+
+	// When
+	actual, err := webhookService.CreateWebhook(context.TODO(), "https://partner.example/hook", "secret", eventTypes)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, expected, *actual)
+}
+
+func Test_Should_Return_Error_When_Creating_Webhook_Is_Not_Valid(t *testing.T) {
+	type testCase struct {
+		testName    string
+		url         string
+		secret      string
+		eventTypes  ticket.EventTypes
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			testName:    "Test_Should_Return_Error_When_URL_Is_Relative",
+			url:         "/hook",
+			secret:      "secret",
+			eventTypes:  ticket.EventTypes{ticket.EventTicketPurchased},
+			expectedErr: service.ErrWebhookURLIsInvalid,
+		},
+		{
+			testName:    "Test_Should_Return_Error_When_URL_Scheme_Is_Not_HTTP",
+			url:         "ftp://partner.example/hook",
+			secret:      "secret",
+			eventTypes:  ticket.EventTypes{ticket.EventTicketPurchased},
+			expectedErr: service.ErrWebhookURLIsInvalid,
+		},
+		{
+			testName:    "Test_Should_Return_Error_When_Event_Types_Are_Empty",
+			url:         "https://partner.example/hook",
+			secret:      "secret",
+			expectedErr: service.ErrWebhookEventTypesIsEmpty,
+		},
+		{
+			testName:    "Test_Should_Return_Error_When_Event_Type_Is_Unknown",
+			url:         "https://partner.example/hook",
+			secret:      "secret",
+			eventTypes:  ticket.EventTypes{"TicketDeleted"},
+			expectedErr: service.ErrWebhookEventTypeIsUnknown,
+		},
+		{
+			testName:    "Test_Should_Return_Error_When_Secret_Is_Empty",
+			url:         "https://partner.example/hook",
+			eventTypes:  ticket.EventTypes{ticket.EventTicketPurchased},
+			expectedErr: service.ErrWebhookSecretIsEmpty,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			// Given
+			webhookService := service.NewDefaultWebhookService(mocks.NewMockWebhookRepository(gomock.NewController(t)))
+
+			// When
+			actual, err := webhookService.CreateWebhook(context.TODO(), test.url, test.secret, test.eventTypes)
+
+			// Then
+			assert.Nil(t, actual)
+			assert.Equal(t, test.expectedErr, err)
+		})
+	}
+}
+
+// Publish Unit Tests
+func Test_Should_Queue_Delivery_Only_For_Subscribed_Webhooks_When_Publish(t *testing.T) {
+	// Given
+	event := ticket.Event{ID: 9, Type: ticket.EventTicketSoldOut, Payload: []byte(`{"ticket_id":1}`)}
+	subscriptions := []ticket.WebhookSubscription{
+		{ID: 1, EventTypes: ticket.EventTypes{ticket.EventTicketPurchased}},
+		{ID: 2, EventTypes: ticket.EventTypes{ticket.EventTicketPurchased, ticket.EventTicketSoldOut}},
+	}
+
+	mockRepository := mocks.NewMockWebhookRepository(gomock.NewController(t))
+	mockRepository.EXPECT().ListWebhookSubscriptions(gomock.Any()).Return(subscriptions, nil).Times(1)
+	mockRepository.EXPECT().CreateWebhookDeliveries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, deliveries []ticket.WebhookDelivery) error {
+			assert.Len(t, deliveries, 1)
+			assert.Equal(t, 2, deliveries[0].SubscriptionID)
+			assert.Equal(t, 9, deliveries[0].EventID)
+			assert.Equal(t, ticket.WebhookDeliveryPending, deliveries[0].Status)
+			return nil
+		}).Times(1)
+
+	webhookService := service.NewDefaultWebhookService(mockRepository)
+
+	// When
+	err := webhookService.Publish(context.TODO(), event)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+// Webhook Deliveries Unit Tests
+func Test_Should_List_Webhooks_Of_Event_Organizer_When_Publish(t *testing.T) {
+	// Given
+	event := ticket.Event{ID: 9, OrganizerID: 2, Type: ticket.EventTicketPurchased}
+
+	mockRepository := mocks.NewMockWebhookRepository(gomock.NewController(t))
+	mockRepository.EXPECT().ListWebhookSubscriptions(gomock.Any()).
+		DoAndReturn(func(ctx context.Context) ([]ticket.WebhookSubscription, error) {
+			organizerID, _ := tenant.OrganizerFrom(ctx)
+			assert.Equal(t, 2, organizerID)
+			return nil, nil
+		}).Times(1)
+	mockRepository.EXPECT().CreateWebhookDeliveries(gomock.Any(), gomock.Len(0)).Return(nil).Times(1)
+
+	webhookService := service.NewDefaultWebhookService(mockRepository)
+
+	// When
+	err := webhookService.Publish(tenant.WithOrganizer(context.TODO(), 1), event)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func Test_Should_Return_Error_When_Get_Deliveries_Of_Unknown_Webhook(t *testing.T) {
+	// Given
+	mockRepository := mocks.NewMockWebhookRepository(gomock.NewController(t))
+	mockRepository.EXPECT().GetWebhookSubscription(gomock.Any(), 4).Return(nil, repository.ErrDBWebhookNotFound).Times(1)
+
+	webhookService := service.NewDefaultWebhookService(mockRepository)
+
+	// When
+	deliveries, err := webhookService.GetWebhookDeliveries(context.TODO(), 4)
+
+	// Then
+	assert.Nil(t, deliveries)
+	assert.Equal(t, service.ErrWebhookWasNotFound, err)
+}
+
+func Test_Should_Reset_Delivery_When_Redeliver(t *testing.T) {
+	t.Run("Test_Should_Return_Delivery_When_It_Exists", func(t *testing.T) {
+		// Given
+		expected := ticket.WebhookDelivery{ID: 3, SubscriptionID: 4, Status: ticket.WebhookDeliveryPending}
+		mockRepository := mocks.NewMockWebhookRepository(gomock.NewController(t))
+		mockRepository.EXPECT().ResetWebhookDelivery(gomock.Any(), 4, 3, gomock.Any()).Return(&expected, nil).Times(1)
+
+		webhookService := service.NewDefaultWebhookService(mockRepository)
+
+		// When
+		actual, err := webhookService.Redeliver(context.TODO(), 4, 3)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, expected, *actual)
+	})
+
+	t.Run("Test_Should_Return_Error_When_Delivery_Does_Not_Exist", func(t *testing.T) {
+		// Given
+		mockRepository := mocks.NewMockWebhookRepository(gomock.NewController(t))
+		mockRepository.EXPECT().ResetWebhookDelivery(gomock.Any(), 4, 3, gomock.Any()).
+			Return(nil, repository.ErrDBWebhookDeliveryNotFound).Times(1)
+
+		webhookService := service.NewDefaultWebhookService(mockRepository)
+
+		// When
+		actual, err := webhookService.Redeliver(context.TODO(), 4, 3)
+
+		// Then
+		assert.Nil(t, actual)
+		assert.Equal(t, service.ErrWebhookDeliveryWasNotFound, err)
+	})
+}
@@ -0,0 +1,11 @@
+package ticket
+
+// AllocationShard is one of the counters the available tickets of a sharded ticket option are
+// split across, so that purchases taking from different shards do not wait for each other. The
+// tickets a ticket option has available and sold are those of its row and of its shards together.
+type AllocationShard struct {
+	TicketID  int `gorm:"primaryKey;autoIncrement:false" json:"ticket_id"`
+	Shard     int `gorm:"primaryKey;autoIncrement:false" json:"shard"`
+	Available int `gorm:"not null;check:chk_allocation_shards_available_non_negative,available >= 0" json:"available"`
+	Sold      int `gorm:"not null;default:0" json:"sold"`
+}
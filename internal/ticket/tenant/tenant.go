@@ -0,0 +1,44 @@
+// Package tenant carries the organizer a request acts for through the context and handles the
+// bearer tokens organizers authenticate with.
+package tenant
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const tokenSize = 32
+
+type organizerKey struct{}
+
+// WithOrganizer returns a copy of ctx acting for the organizer organizerID.
+func WithOrganizer(ctx context.Context, organizerID int) context.Context {
+	return context.WithValue(ctx, organizerKey{}, organizerID)
+}
+
+// OrganizerFrom returns the organizer ctx acts for, and false if it acts for none.
+func OrganizerFrom(ctx context.Context) (int, bool) {
+	organizerID, ok := ctx.Value(organizerKey{}).(int)
+
+	return organizerID, ok && organizerID > 0
+}
+
+// NewToken returns a random bearer token for an organizer.
+func NewToken() (string, error) {
+	token := make([]byte, tokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// HashToken returns what is stored of token to recognise it later.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,45 @@
+package tenant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Carry_Organizer_Through_Context(t *testing.T) {
+	// Given
+	ctx := tenant.WithOrganizer(context.TODO(), 7)
+
+	// When
+	organizerID, ok := tenant.OrganizerFrom(ctx)
+
+	// Then
+	assert.True(t, ok)
+	assert.Equal(t, 7, organizerID)
+}
+
+func Test_Should_Act_For_No_Organizer_When_Context_Has_None(t *testing.T) {
+	for _, ctx := range []context.Context{context.TODO(), tenant.WithOrganizer(context.TODO(), 0)} {
+		// When
+		_, ok := tenant.OrganizerFrom(ctx)
+
+		// Then
+		assert.False(t, ok)
+	}
+}
+
+func Test_Should_Issue_Distinct_Tokens_With_Stable_Hashes(t *testing.T) {
+	// When
+	first, err := tenant.NewToken()
+	assert.Nil(t, err)
+	second, err := tenant.NewToken()
+	assert.Nil(t, err)
+
+	// Then
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, tenant.HashToken(first), tenant.HashToken(first))
+	assert.NotEqual(t, tenant.HashToken(first), tenant.HashToken(second))
+	assert.NotContains(t, tenant.HashToken(first), first)
+}
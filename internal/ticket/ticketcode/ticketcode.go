@@ -0,0 +1,100 @@
+// Package ticketcode issues and verifies the codes printed on issued tickets.
+//
+// A code carries the ticket option id and a random serial, signed with Ed25519. Scanners only
+// need the public key to tell a genuine code from a forged one, so they can work offline; the
+// database is still the source of truth for whether a genuine code has been used or refunded.
+package ticketcode
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	version    = 1
+	serialSize = 12
+	claimsSize = 1 + 4 + serialSize
+	codeSize   = claimsSize + ed25519.SignatureSize
+)
+
+var (
+	ErrInvalidCode = errors.New("ticket code is not valid")
+	ErrInvalidSeed = errors.New("ticket code signing seed must be 32 bytes")
+)
+
+var encoding = base64.RawURLEncoding
+
+// Claims is what a verified code says about itself.
+type Claims struct {
+	TicketID int
+	Serial   []byte
+}
+
+type Signer struct {
+	privateKey ed25519.PrivateKey
+}
+
+func NewSigner(seed []byte) (*Signer, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, ErrInvalidSeed
+	}
+
+	return &Signer{privateKey: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// NewRandomSigner returns a signer with a throwaway key. Codes it issues cannot be verified
+// after a restart, so it is only meant for tests and local runs.
+func NewRandomSigner() *Signer {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Signer{privateKey: privateKey}
+}
+
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.privateKey.Public().(ed25519.PublicKey)
+}
+
+// Issue returns a new, unguessable code for a ticket of the given ticket option.
+func (s *Signer) Issue(ticketID int) (string, error) {
+	if ticketID < 0 || int64(ticketID) > math.MaxUint32 {
+		return "", ErrInvalidCode
+	}
+
+	claims := make([]byte, claimsSize, codeSize)
+	claims[0] = version
+	binary.BigEndian.PutUint32(claims[1:5], uint32(ticketID))
+	if _, err := rand.Read(claims[5:]); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(append(claims, ed25519.Sign(s.privateKey, claims)...)), nil
+}
+
+func (s *Signer) Verify(code string) (Claims, error) {
+	return Verify(s.PublicKey(), code)
+}
+
+// Verify checks the signature of code against publicKey and returns its claims.
+func Verify(publicKey ed25519.PublicKey, code string) (Claims, error) {
+	raw, err := encoding.DecodeString(code)
+	if err != nil || len(raw) != codeSize || raw[0] != version {
+		return Claims{}, ErrInvalidCode
+	}
+
+	claims, signature := raw[:claimsSize], raw[claimsSize:]
+	if !ed25519.Verify(publicKey, claims, signature) {
+		return Claims{}, ErrInvalidCode
+	}
+
+	return Claims{
+		TicketID: int(binary.BigEndian.Uint32(claims[1:5])),
+		Serial:   claims[5:],
+	}, nil
+}
@@ -0,0 +1,79 @@
+package ticketcode_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketcode"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Verify_Issued_Code_With_Public_Key(t *testing.T) {
+	// Given
+	signer, err := ticketcode.NewSigner(bytes.Repeat([]byte{7}, 32))
+	assert.Nil(t, err)
+
+	// When
+	code, err := signer.Issue(42)
+	assert.Nil(t, err)
+	claims, err := ticketcode.Verify(signer.PublicKey(), code)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 42, claims.TicketID)
+	assert.Len(t, claims.Serial, 12)
+}
+
+func Test_Should_Issue_Unique_Codes(t *testing.T) {
+	// Given
+	signer := ticketcode.NewRandomSigner()
+	seen := map[string]bool{}
+
+	for i := 0; i < 1000; i++ {
+		// When
+		code, err := signer.Issue(1)
+
+		// Then
+		assert.Nil(t, err)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func Test_Should_Reject_Code_When_It_Is_Not_Genuine(t *testing.T) {
+	signer := ticketcode.NewRandomSigner()
+	code, _ := signer.Issue(42)
+
+	t.Run("Test_Should_Reject_Code_Signed_With_Another_Key", func(t *testing.T) {
+		forged, _ := ticketcode.NewRandomSigner().Issue(42)
+
+		_, err := signer.Verify(forged)
+
+		assert.Equal(t, ticketcode.ErrInvalidCode, err)
+	})
+
+	t.Run("Test_Should_Reject_Tampered_Code", func(t *testing.T) {
+		tampered := []byte(code)
+		if tampered[3] == 'A' {
+			tampered[3] = 'B'
+		} else {
+			tampered[3] = 'A'
+		}
+
+		_, err := signer.Verify(string(tampered))
+
+		assert.Equal(t, ticketcode.ErrInvalidCode, err)
+	})
+
+	t.Run("Test_Should_Reject_Malformed_Code", func(t *testing.T) {
+		_, err := signer.Verify("not-a-code")
+
+		assert.Equal(t, ticketcode.ErrInvalidCode, err)
+	})
+}
+
+func Test_Should_Return_Error_When_Seed_Has_Wrong_Size(t *testing.T) {
+	_, err := ticketcode.NewSigner([]byte("short"))
+
+	assert.Equal(t, ticketcode.ErrInvalidSeed, err)
+}
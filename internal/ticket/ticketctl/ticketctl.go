@@ -0,0 +1,612 @@
+// Package ticketctl runs the commands of the ticketctl admin tool. Commands call the service layer
+// directly, so they are validated and audited like calls to the API.
+package ticketctl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/audit"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+)
+
+const usage = `usage: ticketctl [-organizer id] [-actor name] [-output table|json] <command> [flags]
+
+commands:
+  organizers create -name NAME
+  organizers issue-token [-id N]
+  options create -name NAME -desc DESC -allocation N [-price N] [-starts-at T] [-sale-starts-at T] [-sale-ends-at T]
+  options list [-limit N] [-after-id N] [-on-sale]
+  options get -id N
+  options update -id N [-name NAME] [-desc DESC] [-price N] [-starts-at T] [-sale-starts-at T] [-sale-ends-at T]
+  options adjust -id N -by N -reason REASON
+  options ledger -id N [-limit N] [-after-id N]
+  options shard -id N -shards N
+  options rebalance -id N
+  purchases list [-option N] [-user ID] [-limit N] [-after-id N]
+  purchases get -id N
+  purchases refund -id N
+  export [-file PATH]
+  import -file PATH
+  reconcile
+
+Times are in RFC 3339.`
+
+var (
+	ErrUnknownCommand  = errors.New("unknown command")
+	ErrUnknownOutput   = errors.New("output must be table or json")
+	ErrFileIsMissing   = errors.New("file is needed")
+	ErrAllocationDrift = errors.New("allocations drifted from the ledger")
+)
+
+// Export is the document export writes and import reads.
+type Export struct {
+	TicketOptions []ticket.Ticket   `json:"ticket_options"`
+	Purchases     []ticket.Purchase `json:"purchases,omitempty"`
+}
+
+type CLI struct {
+	service service.Service
+	out     io.Writer
+	json    bool
+}
+
+func New(service service.Service, out io.Writer) *CLI {
+	return &CLI{service: service, out: out}
+}
+
+// Run runs the command in args, without the program name.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	flags := newFlagSet("ticketctl")
+	organizerID := flags.Int("organizer", ticket.DefaultOrganizerID, "organizer to act for")
+	actor := flags.String("actor", "", "who runs the command, for the audit log")
+	output := flags.String("output", "table", "table or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	switch *output {
+	case "table":
+		c.json = false
+	case "json":
+		c.json = true
+	default:
+		return ErrUnknownOutput
+	}
+
+	ctx = tenant.WithOrganizer(ctx, *organizerID)
+	origin := audit.Origin{Actor: "ticketctl"}
+	if *actor != "" {
+		origin.Actor += ":" + *actor
+	}
+	ctx = audit.WithOrigin(ctx, origin)
+
+	args = flags.Args()
+	if len(args) == 0 {
+		return fmt.Errorf("%w\n%s", ErrUnknownCommand, usage)
+	}
+
+	switch args[0] {
+	case "export":
+		return c.export(ctx, args[1:])
+	case "import":
+		return c.importOptions(ctx, args[1:])
+	case "reconcile":
+		return c.reconcile(ctx, args[1:])
+	}
+
+	if len(args) < 2 { //nolint:gomnd
+		return fmt.Errorf("%w\n%s", ErrUnknownCommand, usage)
+	}
+
+	switch args[0] + " " + args[1] {
+	case "organizers create":
+		return c.createOrganizer(ctx, args[2:])
+	case "organizers issue-token":
+		return c.issueOrganizerToken(ctx, args[2:])
+	case "options create":
+		return c.createOption(ctx, args[2:])
+	case "options list":
+		return c.listOptions(ctx, args[2:])
+	case "options get":
+		return c.getOption(ctx, args[2:])
+	case "options update":
+		return c.updateOption(ctx, args[2:])
+	case "options adjust":
+		return c.adjustAllocation(ctx, args[2:])
+	case "options shard":
+		return c.shardAllocation(ctx, args[2:])
+	case "options rebalance":
+		return c.rebalanceShards(ctx, args[2:])
+	case "options ledger":
+		return c.listLedgerEntries(ctx, args[2:])
+	case "purchases list":
+		return c.listPurchases(ctx, args[2:])
+	case "purchases get":
+		return c.getPurchase(ctx, args[2:])
+	case "purchases refund":
+		return c.refundPurchase(ctx, args[2:])
+	default:
+		return fmt.Errorf("%w\n%s", ErrUnknownCommand, usage)
+	}
+}
+
+func (c *CLI) createOrganizer(ctx context.Context, args []string) error {
+	flags := newFlagSet("organizers create")
+	name := flags.String("name", "", "name of the organizer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	organizer, token, err := c.service.CreateOrganizer(ctx, *name)
+	if err != nil {
+		return err
+	}
+
+	created := struct {
+		ticket.Organizer
+		Token string `json:"token"`
+	}{*organizer, token}
+
+	return c.print(created, []string{"ID", "NAME", "TOKEN"},
+		[][]string{{strconv.Itoa(organizer.ID), organizer.Name, token}})
+}
+
+// issueOrganizerToken gives an organizer, the default one unless -id names another, a new token in
+// place of its old one.
+func (c *CLI) issueOrganizerToken(ctx context.Context, args []string) error {
+	flags := newFlagSet("organizers issue-token")
+	id := flags.Int("id", ticket.DefaultOrganizerID, "id of the organizer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	organizer, token, err := c.service.IssueOrganizerToken(ctx, *id)
+	if err != nil {
+		return err
+	}
+
+	issued := struct {
+		ticket.Organizer
+		Token string `json:"token"`
+	}{*organizer, token}
+
+	return c.print(issued, []string{"ID", "NAME", "TOKEN"},
+		[][]string{{strconv.Itoa(organizer.ID), organizer.Name, token}})
+}
+
+func (c *CLI) createOption(ctx context.Context, args []string) error {
+	flags := newFlagSet("options create")
+	name := flags.String("name", "", "name of the ticket option")
+	desc := flags.String("desc", "", "description of the ticket option")
+	allocation := flags.Int("allocation", 0, "tickets for sale")
+	price := flags.Int("price", 0, "face value in minor units")
+	var startsAt, saleStartsAt, saleEndsAt *time.Time
+	flags.Var(timeValue{&startsAt}, "starts-at", "when the event starts")
+	flags.Var(timeValue{&saleStartsAt}, "sale-starts-at", "when the sale starts")
+	flags.Var(timeValue{&saleEndsAt}, "sale-ends-at", "when the sale ends")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	option, err := c.service.CreateTicketOption(ctx, *name, *desc, *allocation, *price, startsAt, saleStartsAt, saleEndsAt)
+	if err != nil {
+		return err
+	}
+
+	return c.printOptions(option, *option)
+}
+
+func (c *CLI) listOptions(ctx context.Context, args []string) error {
+	flags := newFlagSet("options list")
+	filter := ticket.TicketFilter{}
+	flags.IntVar(&filter.Limit, "limit", service.DefaultListLimit, "page size")
+	flags.IntVar(&filter.AfterID, "after-id", 0, "list ticket options with a greater id")
+	flags.BoolVar(&filter.OnSale, "on-sale", false, "list only ticket options on sale now")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	options, err := c.service.ListTicketOptions(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return c.printOptions(options, options...)
+}
+
+func (c *CLI) getOption(ctx context.Context, args []string) error {
+	flags := newFlagSet("options get")
+	id := flags.Int("id", 0, "ticket option id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	option, err := c.service.GetTicket(ctx, *id)
+	if err != nil {
+		return err
+	}
+
+	return c.printOptions(option, *option)
+}
+
+func (c *CLI) updateOption(ctx context.Context, args []string) error {
+	flags := newFlagSet("options update")
+	id := flags.Int("id", 0, "ticket option id")
+	name := flags.String("name", "", "new name")
+	desc := flags.String("desc", "", "new description")
+	price := flags.Int("price", 0, "new face value in minor units")
+	update := ticket.TicketUpdate{}
+	flags.Var(timeValue{&update.StartsAt}, "starts-at", "when the event starts")
+	flags.Var(timeValue{&update.SaleStartsAt}, "sale-starts-at", "when the sale starts")
+	flags.Var(timeValue{&update.SaleEndsAt}, "sale-ends-at", "when the sale ends")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Only the flags given change the ticket option, so that a name can be set without clearing the price.
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			update.Name = name
+		case "desc":
+			update.Desc = desc
+		case "price":
+			update.Price = price
+		}
+	})
+
+	option, err := c.service.UpdateTicketOption(ctx, *id, update)
+	if err != nil {
+		return err
+	}
+
+	return c.printOptions(option, *option)
+}
+
+func (c *CLI) adjustAllocation(ctx context.Context, args []string) error {
+	flags := newFlagSet("options adjust")
+	id := flags.Int("id", 0, "ticket option id")
+	by := flags.Int("by", 0, "tickets to add, or take away when negative")
+	reason := flags.String("reason", "", "why the allocation changes")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	option, err := c.service.AdjustAllocation(ctx, *id, *by, *reason)
+	if err != nil {
+		return err
+	}
+
+	return c.printOptions(option, *option)
+}
+
+func (c *CLI) shardAllocation(ctx context.Context, args []string) error {
+	flags := newFlagSet("options shard")
+	id := flags.Int("id", 0, "ticket option id")
+	shards := flags.Int("shards", 0, "counters to split the tickets left across, or 0 to stop sharding")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	option, err := c.service.ShardAllocation(ctx, *id, *shards)
+	if err != nil {
+		return err
+	}
+
+	return c.printOptions(option, *option)
+}
+
+func (c *CLI) rebalanceShards(ctx context.Context, args []string) error {
+	flags := newFlagSet("options rebalance")
+	id := flags.Int("id", 0, "ticket option id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	option, err := c.service.RebalanceShards(ctx, *id)
+	if err != nil {
+		return err
+	}
+
+	return c.printOptions(option, *option)
+}
+
+func (c *CLI) listLedgerEntries(ctx context.Context, args []string) error {
+	flags := newFlagSet("options ledger")
+	filter := ticket.LedgerFilter{}
+	flags.IntVar(&filter.TicketID, "id", 0, "ticket option id")
+	flags.IntVar(&filter.Limit, "limit", service.DefaultListLimit, "page size")
+	flags.IntVar(&filter.AfterID, "after-id", 0, "list ledger entries with a greater id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	entries, err := c.service.ListLedgerEntries(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(entries))
+	for i, entry := range entries {
+		purchaseID := ""
+		if entry.PurchaseID != nil {
+			purchaseID = strconv.Itoa(*entry.PurchaseID)
+		}
+
+		rows[i] = []string{
+			strconv.Itoa(entry.ID), string(entry.Kind), strconv.Itoa(entry.Delta), purchaseID, entry.Reason,
+			entry.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return c.print(entries, []string{"ID", "KIND", "DELTA", "PURCHASE", "REASON", "CREATED"}, rows)
+}
+
+// reconcile prints the ticket options whose counters do not add up, and fails when there are any.
+func (c *CLI) reconcile(ctx context.Context, args []string) error {
+	flags := newFlagSet("reconcile")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	drifts, err := c.service.ReconcileAllocations(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(drifts))
+	for i, drift := range drifts {
+		rows[i] = []string{
+			strconv.Itoa(drift.TicketID), strconv.Itoa(drift.Capacity), strconv.Itoa(drift.Sold),
+			strconv.Itoa(drift.Held), strconv.Itoa(drift.Available), strconv.Itoa(drift.Ledger),
+		}
+	}
+
+	if err = c.print(drifts, []string{"OPTION", "CAPACITY", "SOLD", "HELD", "AVAILABLE", "LEDGER"}, rows); err != nil {
+		return err
+	}
+
+	if len(drifts) > 0 {
+		return fmt.Errorf("%w: %d ticket options", ErrAllocationDrift, len(drifts))
+	}
+
+	return nil
+}
+
+func (c *CLI) listPurchases(ctx context.Context, args []string) error {
+	flags := newFlagSet("purchases list")
+	filter := ticket.PurchaseFilter{}
+	flags.IntVar(&filter.TicketID, "option", 0, "ticket option id")
+	flags.StringVar(&filter.UserID, "user", "", "user id")
+	flags.IntVar(&filter.Limit, "limit", service.DefaultListLimit, "page size")
+	flags.IntVar(&filter.AfterID, "after-id", 0, "list purchases with a greater id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	purchases, err := c.service.ListPurchases(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return c.printPurchases(purchases, purchases...)
+}
+
+func (c *CLI) getPurchase(ctx context.Context, args []string) error {
+	flags := newFlagSet("purchases get")
+	id := flags.Int("id", 0, "purchase id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	purchase, err := c.service.GetPurchase(ctx, *id)
+	if err != nil {
+		return err
+	}
+
+	return c.printPurchases(purchase, *purchase)
+}
+
+func (c *CLI) refundPurchase(ctx context.Context, args []string) error {
+	flags := newFlagSet("purchases refund")
+	id := flags.Int("id", 0, "purchase id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	purchase, err := c.service.RefundPurchase(ctx, *id)
+	if err != nil {
+		return err
+	}
+
+	return c.printPurchases(purchase, *purchase)
+}
+
+// export writes every ticket option of the organizer and their purchases as JSON, to the file or
+// to the output when no file is given.
+func (c *CLI) export(ctx context.Context, args []string) error {
+	flags := newFlagSet("export")
+	file := flags.String("file", "", "file to write to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	document := Export{TicketOptions: []ticket.Ticket{}}
+	for filter := (ticket.TicketFilter{Limit: service.MaxListLimit}); ; {
+		options, err := c.service.ListTicketOptions(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		document.TicketOptions = append(document.TicketOptions, options...)
+		if len(options) < filter.Limit {
+			break
+		}
+		filter.AfterID = options[len(options)-1].ID
+	}
+
+	for filter := (ticket.PurchaseFilter{Limit: service.MaxListLimit}); ; {
+		purchases, err := c.service.ListPurchases(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		document.Purchases = append(document.Purchases, purchases...)
+		if len(purchases) < filter.Limit {
+			break
+		}
+		filter.AfterID = purchases[len(purchases)-1].ID
+	}
+
+	out := c.out
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(document)
+}
+
+// importOptions creates the ticket options of an export document, with their price tiers. Purchases
+// in the document are not imported; they were made against the ticket options they were exported from.
+func (c *CLI) importOptions(ctx context.Context, args []string) error {
+	flags := newFlagSet("import")
+	file := flags.String("file", "", "file to read from")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return ErrFileIsMissing
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var document Export
+	if err = json.NewDecoder(f).Decode(&document); err != nil {
+		return err
+	}
+
+	created := make([]ticket.Ticket, 0, len(document.TicketOptions))
+	for _, o := range document.TicketOptions {
+		option, err := c.service.CreateTicketOption(ctx, o.Name, o.Desc, o.Available, o.Price,
+			o.StartsAt, o.SaleStartsAt, o.SaleEndsAt)
+		if err != nil {
+			return fmt.Errorf("ticket option %q: %w", o.Name, err)
+		}
+
+		for _, tier := range o.PriceTiers {
+			if _, err = c.service.CreatePriceTier(ctx, option.ID, tier); err != nil {
+				return fmt.Errorf("price tier of ticket option %q: %w", o.Name, err)
+			}
+		}
+
+		created = append(created, *option)
+	}
+
+	return c.printOptions(created, created...)
+}
+
+func (c *CLI) printOptions(v interface{}, options ...ticket.Ticket) error {
+	rows := make([][]string, len(options))
+	for i, option := range options {
+		rows[i] = []string{
+			strconv.Itoa(option.ID), option.Name, strconv.Itoa(option.Capacity), strconv.Itoa(option.Sold),
+			strconv.Itoa(option.Held), strconv.Itoa(option.Available), strconv.Itoa(option.Price),
+			formatTime(option.SaleStartsAt), formatTime(option.SaleEndsAt),
+		}
+	}
+
+	return c.print(v, []string{"ID", "NAME", "CAPACITY", "SOLD", "HELD", "AVAILABLE", "PRICE", "SALE STARTS", "SALE ENDS"}, rows)
+}
+
+func (c *CLI) printPurchases(v interface{}, purchases ...ticket.Purchase) error {
+	rows := make([][]string, len(purchases))
+	for i, purchase := range purchases {
+		rows[i] = []string{
+			strconv.Itoa(purchase.ID), strconv.Itoa(purchase.TicketID), purchase.UserID,
+			strconv.Itoa(purchase.Quantity), strconv.Itoa(purchase.TotalPrice),
+			purchase.CreatedAt.Format(time.RFC3339), formatTime(purchase.RefundedAt),
+		}
+	}
+
+	return c.print(v, []string{"ID", "OPTION", "USER", "QUANTITY", "TOTAL", "CREATED", "REFUNDED"}, rows)
+}
+
+// print writes v as JSON, or rows under header as a table.
+func (c *CLI) print(v interface{}, header []string, rows [][]string) error {
+	if c.json {
+		encoder := json.NewEncoder(c.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0) //nolint:gomnd
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return w.Flush()
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	return flags
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// timeValue is a flag holding an optional RFC 3339 time.
+type timeValue struct {
+	t **time.Time
+}
+
+func (v timeValue) String() string {
+	if v.t == nil || *v.t == nil {
+		return ""
+	}
+
+	return (*v.t).Format(time.RFC3339)
+}
+
+func (v timeValue) Set(s string) error {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	*v.t = &t
+
+	return nil
+}
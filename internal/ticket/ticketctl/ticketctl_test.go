@@ -0,0 +1,292 @@
+package ticketctl_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/audit"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/tenant"
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketctl"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Print_Ticket_Options_As_Table(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().ListTicketOptions(gomock.Any(), ticket.TicketFilter{Limit: 10}).
+		Return([]ticket.Ticket{{ID: 1, Name: "example", Capacity: 500, Sold: 370, Held: 10, Available: 120, Price: 2500}}, nil).Times(1)
+
+	// When
+	err := ticketctl.New(mockService, &out).Run(context.TODO(), []string{"options", "list", "-limit", "10"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "ID  NAME     CAPACITY  SOLD  HELD  AVAILABLE  PRICE  SALE STARTS  SALE ENDS\n"+
+		"1   example  500       370   10    120        2500   -            -\n", out.String())
+}
+
+func Test_Should_Print_Ticket_Option_As_JSON(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+	expected := ticket.Ticket{ID: 1, Name: "example", Desc: "sample description", Available: 100}
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().GetTicket(gomock.Any(), 1).Return(&expected, nil).Times(1)
+
+	// When
+	err := ticketctl.New(mockService, &out).Run(context.TODO(), []string{"-output", "json", "options", "get", "-id", "1"})
+
+	// Then
+	assert.Nil(t, err)
+
+	var actual ticket.Ticket
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &actual))
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Should_Act_For_Organizer_And_Actor_Given(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().RefundPurchase(gomock.Any(), 7).
+		DoAndReturn(func(ctx context.Context, id int) (*ticket.Purchase, error) {
+			organizerID, _ := tenant.OrganizerFrom(ctx)
+			assert.Equal(t, 3, organizerID)
+			assert.Equal(t, "ticketctl:alice", audit.OriginFrom(ctx).Actor)
+			return &ticket.Purchase{ID: id}, nil
+		}).Times(1)
+
+	// When
+	err := ticketctl.New(mockService, &bytes.Buffer{}).
+		Run(context.TODO(), []string{"-organizer", "3", "-actor", "alice", "purchases", "refund", "-id", "7"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func Test_Should_Update_Only_Fields_Given(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().UpdateTicketOption(gomock.Any(), 1, gomock.Any()).
+		DoAndReturn(func(_ context.Context, id int, update ticket.TicketUpdate) (*ticket.Ticket, error) {
+			assert.Equal(t, 0, *update.Price)
+			assert.Nil(t, update.Name)
+			assert.Nil(t, update.Desc)
+			assert.Equal(t, "2026-06-01T00:00:00Z", update.SaleEndsAt.UTC().Format("2006-01-02T15:04:05Z07:00"))
+			return &ticket.Ticket{ID: id}, nil
+		}).Times(1)
+
+	// When
+	err := ticketctl.New(mockService, &bytes.Buffer{}).
+		Run(context.TODO(), []string{"options", "update", "-id", "1", "-price", "0", "-sale-ends-at", "2026-06-01T00:00:00Z"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func Test_Should_Adjust_Allocation_With_Reason(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().AdjustAllocation(gomock.Any(), 1, -20, "held back for guests").
+		Return(&ticket.Ticket{ID: 1, Available: 80}, nil).Times(1)
+
+	// When
+	err := ticketctl.New(mockService, &bytes.Buffer{}).
+		Run(context.TODO(), []string{"options", "adjust", "-id", "1", "-by", "-20", "-reason", "held back for guests"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func Test_Should_Shard_And_Rebalance_Allocation(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	gomock.InOrder(
+		mockService.EXPECT().ShardAllocation(gomock.Any(), 1, 8).
+			Return(&ticket.Ticket{ID: 1, Capacity: 100, Available: 100, Shards: 8}, nil).Times(1),
+		mockService.EXPECT().RebalanceShards(gomock.Any(), 1).
+			Return(&ticket.Ticket{ID: 1, Capacity: 100, Available: 100, Shards: 8}, nil).Times(1),
+	)
+
+	cli := ticketctl.New(mockService, &bytes.Buffer{})
+
+	// When
+	shardErr := cli.Run(context.TODO(), []string{"options", "shard", "-id", "1", "-shards", "8"})
+	rebalanceErr := cli.Run(context.TODO(), []string{"options", "rebalance", "-id", "1"})
+
+	// Then
+	assert.Nil(t, shardErr)
+	assert.Nil(t, rebalanceErr)
+}
+
+func Test_Should_Print_Ledger_Entries_As_Table(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+	purchaseID := 3
+	createdAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().ListLedgerEntries(gomock.Any(), ticket.LedgerFilter{TicketID: 1, Limit: service.DefaultListLimit}).
+		Return([]ticket.LedgerEntry{
+			{ID: 1, TicketID: 1, Kind: ticket.LedgerInitial, Delta: 100, CreatedAt: createdAt},
+			{ID: 2, TicketID: 1, Kind: ticket.LedgerPurchase, Delta: -2, PurchaseID: &purchaseID, CreatedAt: createdAt},
+		}, nil).Times(1)
+
+	// When
+	err := ticketctl.New(mockService, &out).Run(context.TODO(), []string{"options", "ledger", "-id", "1"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "ID  KIND      DELTA  PURCHASE  REASON  CREATED\n"+
+		"1   initial   100                      2026-06-01T12:00:00Z\n"+
+		"2   purchase  -2     3                 2026-06-01T12:00:00Z\n", out.String())
+}
+
+func Test_Should_Return_Error_When_Allocations_Drifted(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().ReconcileAllocations(gomock.Any()).
+		Return([]ticket.AllocationDrift{{TicketID: 1, Capacity: 100, Sold: 2, Available: 98, Ledger: 100}}, nil).Times(1)
+
+	// When
+	err := ticketctl.New(mockService, &out).Run(context.TODO(), []string{"reconcile"})
+
+	// Then
+	assert.ErrorIs(t, err, ticketctl.ErrAllocationDrift)
+	assert.Equal(t, "OPTION  CAPACITY  SOLD  HELD  AVAILABLE  LEDGER\n"+
+		"1       100       2     0     98         100\n", out.String())
+}
+
+func Test_Should_Reconcile_When_Allocations_Match_Ledger(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().ReconcileAllocations(gomock.Any()).Return(nil, nil).Times(1)
+
+	// When
+	err := ticketctl.New(mockService, &bytes.Buffer{}).Run(context.TODO(), []string{"reconcile"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func Test_Should_Issue_Token_To_Default_Organizer_Unless_Another_Is_Given(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().IssueOrganizerToken(gomock.Any(), ticket.DefaultOrganizerID).
+		Return(&ticket.Organizer{ID: ticket.DefaultOrganizerID, Name: "default"}, "token1", nil).Times(1)
+	mockService.EXPECT().IssueOrganizerToken(gomock.Any(), 2).
+		Return(&ticket.Organizer{ID: 2, Name: "promoter"}, "token2", nil).Times(1)
+
+	cli := ticketctl.New(mockService, &out)
+
+	// When
+	defaultErr := cli.Run(context.TODO(), []string{"organizers", "issue-token"})
+	otherErr := cli.Run(context.TODO(), []string{"organizers", "issue-token", "-id", "2"})
+
+	// Then
+	assert.Nil(t, defaultErr)
+	assert.Nil(t, otherErr)
+	assert.Equal(t, "ID  NAME     TOKEN\n1   default  token1\n"+
+		"ID  NAME      TOKEN\n2   promoter  token2\n", out.String())
+}
+
+func Test_Should_Return_Error_When_Command_Is_Not_Possible(t *testing.T) {
+	type testCase struct {
+		args          []string
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{args: nil, expectedError: ticketctl.ErrUnknownCommand},
+		{args: []string{"options"}, expectedError: ticketctl.ErrUnknownCommand},
+		{args: []string{"options", "delete"}, expectedError: ticketctl.ErrUnknownCommand},
+		{args: []string{"-output", "yaml", "options", "list"}, expectedError: ticketctl.ErrUnknownOutput},
+		{args: []string{"import"}, expectedError: ticketctl.ErrFileIsMissing},
+	}
+
+	for _, test := range testCases {
+		// Given
+		mockService := mocks.NewMockService(gomock.NewController(t))
+
+		// When
+		err := ticketctl.New(mockService, &bytes.Buffer{}).Run(context.TODO(), test.args)
+
+		// Then
+		assert.ErrorIs(t, err, test.expectedError)
+	}
+}
+
+func Test_Should_Pass_Service_Error_Through(t *testing.T) {
+	// Given
+	mockService := mocks.NewMockService(gomock.NewController(t))
+	mockService.EXPECT().AdjustAllocation(gomock.Any(), 1, 5, "").Return(nil, service.ErrAdjustmentReasonIsEmpty).Times(1)
+
+	// When
+	err := ticketctl.New(mockService, &bytes.Buffer{}).Run(context.TODO(), []string{"options", "adjust", "-id", "1", "-by", "5"})
+
+	// Then
+	assert.Equal(t, service.ErrAdjustmentReasonIsEmpty, err)
+}
+
+func Test_Should_Import_What_Was_Exported(t *testing.T) {
+	// Given
+	file := filepath.Join(t.TempDir(), "export.json")
+
+	firstPage := make([]ticket.Ticket, service.MaxListLimit)
+	for i := range firstPage {
+		firstPage[i] = ticket.Ticket{ID: i + 1, Name: "option", Desc: "sample description", Available: 10}
+	}
+	lastOption := ticket.Ticket{ID: service.MaxListLimit + 1, Name: "last", Desc: "sample description", Available: 5, Price: 1000,
+		PriceTiers: []ticket.PriceTier{{Kind: ticket.PriceTierSoldStep, Price: 1500, AfterSold: 2}}}
+
+	exporting := mocks.NewMockService(gomock.NewController(t))
+	gomock.InOrder(
+		exporting.EXPECT().ListTicketOptions(gomock.Any(), ticket.TicketFilter{Limit: service.MaxListLimit}).Return(firstPage, nil),
+		exporting.EXPECT().ListTicketOptions(gomock.Any(), ticket.TicketFilter{Limit: service.MaxListLimit, AfterID: service.MaxListLimit}).
+			Return([]ticket.Ticket{lastOption}, nil),
+	)
+	exporting.EXPECT().ListPurchases(gomock.Any(), ticket.PurchaseFilter{Limit: service.MaxListLimit}).
+		Return([]ticket.Purchase{{ID: 1, TicketID: 1, UserID: "user", Quantity: 1}}, nil).Times(1)
+
+	importing := mocks.NewMockService(gomock.NewController(t))
+	importing.EXPECT().CreateTicketOption(gomock.Any(), "option", "sample description", 10, 0, nil, nil, nil).
+		Return(&ticket.Ticket{ID: 500}, nil).Times(service.MaxListLimit)
+	importing.EXPECT().CreateTicketOption(gomock.Any(), "last", "sample description", 5, 1000, nil, nil, nil).
+		Return(&ticket.Ticket{ID: 501}, nil).Times(1)
+	importing.EXPECT().CreatePriceTier(gomock.Any(), 501, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, tier ticket.PriceTier) (*ticket.PriceTier, error) {
+			assert.Equal(t, ticket.PriceTierSoldStep, tier.Kind)
+			assert.Equal(t, 1500, tier.Price)
+			return &tier, nil
+		}).Times(1)
+
+	// When
+	exportErr := ticketctl.New(exporting, &bytes.Buffer{}).Run(context.TODO(), []string{"export", "-file", file})
+	importErr := ticketctl.New(importing, &bytes.Buffer{}).Run(context.TODO(), []string{"import", "-file", file})
+
+	// Then
+	assert.Nil(t, exportErr)
+	assert.Nil(t, importErr)
+
+	raw, err := os.ReadFile(file)
+	assert.Nil(t, err)
+
+	var document ticketctl.Export
+	assert.Nil(t, json.Unmarshal(raw, &document))
+	assert.Len(t, document.TicketOptions, service.MaxListLimit+1)
+	assert.Len(t, document.Purchases, 1)
+}
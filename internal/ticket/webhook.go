@@ -0,0 +1,95 @@
+package ticket
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// EventTypes is stored as a comma separated list so it works on every supported database.
+type EventTypes []EventType
+
+func (e EventTypes) Value() (driver.Value, error) {
+	values := make([]string, len(e))
+	for i, eventType := range e {
+		values[i] = string(eventType)
+	}
+
+	return strings.Join(values, ","), nil
+}
+
+func (e *EventTypes) Scan(src interface{}) error {
+	var raw string
+	switch v := src.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case nil:
+		raw = ""
+	default:
+		return fmt.Errorf("cannot scan %T into EventTypes", src)
+	}
+
+	*e = EventTypes{}
+	for _, value := range strings.Split(raw, ",") {
+		if value != "" {
+			*e = append(*e, EventType(value))
+		}
+	}
+
+	return nil
+}
+
+func (e EventTypes) Contains(eventType EventType) bool {
+	for _, t := range e {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type WebhookSubscription struct {
+	ID          int        `gorm:"primaryKey" json:"id"`
+	OrganizerID int        `gorm:"not null;default:1;index" json:"organizer_id"`
+	URL         string     `gorm:"not null" json:"url"`
+	EventTypes  EventTypes `gorm:"type:text;not null" json:"event_types"`
+	Secret      string     `gorm:"not null" json:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int                      `gorm:"primaryKey" json:"id"`
+	SubscriptionID int                      `gorm:"not null;uniqueIndex:idx_webhook_deliveries_subscription_event" json:"subscription_id"`
+	EventID        int                      `gorm:"not null;uniqueIndex:idx_webhook_deliveries_subscription_event" json:"event_id"`
+	EventType      EventType                `gorm:"not null" json:"event_type"`
+	Payload        []byte                   `gorm:"not null" json:"payload"`
+	OccurredAt     time.Time                `gorm:"not null" json:"occurred_at"`
+	Status         WebhookDeliveryStatus    `gorm:"not null;index:idx_webhook_deliveries_status_next_attempt" json:"status"`
+	Attempts       int                      `gorm:"not null" json:"attempts"`
+	NextAttemptAt  time.Time                `gorm:"not null;index:idx_webhook_deliveries_status_next_attempt" json:"next_attempt_at"`
+	DeliveredAt    *time.Time               `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	Subscription   *WebhookSubscription     `json:"-"`
+	AttemptLog     []WebhookDeliveryAttempt `gorm:"foreignKey:DeliveryID" json:"attempt_log"`
+}
+
+type WebhookDeliveryAttempt struct {
+	ID          int       `gorm:"primaryKey" json:"id"`
+	DeliveryID  int       `gorm:"not null;index" json:"delivery_id"`
+	StatusCode  int       `json:"status_code"`
+	Error       string    `json:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+	AttemptedAt time.Time `gorm:"not null" json:"attempted_at"`
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/outbox"
+	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+)
+
+// Payload is the JSON body posted to webhook receivers.
+type Payload struct {
+	ID         int              `json:"id"`
+	Type       ticket.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       json.RawMessage  `json:"data"`
+}
+
+// Dispatcher sends pending webhook deliveries. Every try is recorded as an attempt; a non-2xx
+// response or transport error is retried with exponential backoff until MaxAttempts, after which
+// the delivery is marked failed and only a manual redelivery will send it again.
+type Dispatcher struct {
+	repository repository.WebhookRepository
+	client     *http.Client
+
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Now          func() time.Time
+}
+
+func NewDispatcher(repository repository.WebhookRepository, client *http.Client) *Dispatcher {
+	return &Dispatcher{
+		repository:   repository,
+		client:       client,
+		PollInterval: time.Second,
+		BatchSize:    50,               //nolint:gomnd
+		MaxAttempts:  8,                //nolint:gomnd
+		BaseBackoff:  10 * time.Second, //nolint:gomnd
+		MaxBackoff:   time.Hour,
+		Now:          time.Now,
+	}
+}
+
+// Run dispatches deliveries until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.ProcessBatch(ctx); err != nil && ctx.Err() == nil {
+			log.Error(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch sends one batch of due deliveries and returns how many succeeded.
+func (d *Dispatcher) ProcessBatch(ctx context.Context) (int, error) {
+	deliveries, err := d.repository.FetchDueWebhookDeliveries(ctx, d.Now(), d.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	succeeded := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		attempt := d.send(ctx, delivery)
+
+		delivery.Attempts++
+		switch {
+		case attempt.Error == "":
+			deliveredAt := attempt.AttemptedAt
+			delivery.Status = ticket.WebhookDeliverySucceeded
+			delivery.DeliveredAt = &deliveredAt
+			succeeded++
+		case delivery.Attempts >= d.MaxAttempts:
+			delivery.Status = ticket.WebhookDeliveryFailed
+		default:
+			delivery.NextAttemptAt = d.Now().Add(outbox.Backoff(d.BaseBackoff, d.MaxBackoff, delivery.Attempts))
+		}
+
+		if err := d.repository.RecordWebhookDeliveryAttempt(ctx, delivery, attempt); err != nil {
+			return succeeded, err
+		}
+	}
+
+	return succeeded, nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery *ticket.WebhookDelivery) ticket.WebhookDeliveryAttempt {
+	attempt := ticket.WebhookDeliveryAttempt{DeliveryID: delivery.ID, AttemptedAt: d.Now()}
+
+	if delivery.Subscription == nil {
+		attempt.Error = "webhook subscription does not exist"
+		return attempt
+	}
+
+	body, err := json.Marshal(Payload{
+		ID:         delivery.EventID,
+		Type:       delivery.EventType,
+		OccurredAt: delivery.OccurredAt,
+		Data:       delivery.Payload,
+	})
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderEventType, string(delivery.EventType))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(attempt.AttemptedAt.Unix(), 10)) //nolint:gomnd
+	req.Header.Set(HeaderSignature, Sign(delivery.Subscription.Secret, attempt.AttemptedAt, body))
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	attempt.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck
+
+	attempt.StatusCode = resp.StatusCode
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		attempt.Error = fmt.Sprintf("receiver responded with status %d", resp.StatusCode)
+	}
+
+	return attempt
+}
@@ -0,0 +1,134 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dilaragorum/ticket-api/internal/ticket"
+	"github.com/dilaragorum/ticket-api/internal/ticket/mocks"
+	"github.com/dilaragorum/ticket-api/internal/ticket/webhook"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newDelivery(url string, attempts int) ticket.WebhookDelivery {
+	return ticket.WebhookDelivery{
+		ID:             11,
+		SubscriptionID: 2,
+		EventID:        5,
+		EventType:      ticket.EventTicketPurchased,
+		Payload:        []byte(`{"ticket_id":1,"quantity":2}`),
+		Status:         ticket.WebhookDeliveryPending,
+		Attempts:       attempts,
+		Subscription:   &ticket.WebhookSubscription{ID: 2, URL: url, Secret: "s3cr3t"},
+	}
+}
+
+func Test_Should_Deliver_Signed_Payload_To_Receiver(t *testing.T) {
+	// Given
+	var received *http.Request
+	var receivedBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	mockRepository := mocks.NewMockWebhookRepository(gomock.NewController(t))
+	dispatcher := webhook.NewDispatcher(mockRepository, receiver.Client())
+	dispatcher.Now = func() time.Time { return now }
+
+	delivery := newDelivery(receiver.URL, 0)
+	mockRepository.EXPECT().FetchDueWebhookDeliveries(gomock.Any(), now, dispatcher.BatchSize).
+		Return([]ticket.WebhookDelivery{delivery}, nil).Times(1)
+	mockRepository.EXPECT().RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *ticket.WebhookDelivery, attempt ticket.WebhookDeliveryAttempt) error {
+			assert.Equal(t, ticket.WebhookDeliverySucceeded, d.Status)
+			assert.Equal(t, 1, d.Attempts)
+			assert.Equal(t, now, *d.DeliveredAt)
+			assert.Equal(t, http.StatusNoContent, attempt.StatusCode)
+			assert.Empty(t, attempt.Error)
+			return nil
+		}).Times(1)
+
+	// When
+	succeeded, err := dispatcher.ProcessBatch(context.TODO())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 1, succeeded)
+
+	assert.Equal(t, "11", received.Header.Get(webhook.HeaderDeliveryID))
+	assert.Equal(t, string(ticket.EventTicketPurchased), received.Header.Get(webhook.HeaderEventType))
+	assert.True(t, webhook.Verify("s3cr3t", received.Header.Get(webhook.HeaderTimestamp),
+		received.Header.Get(webhook.HeaderSignature), receivedBody))
+	assert.False(t, webhook.Verify("wrong", received.Header.Get(webhook.HeaderTimestamp),
+		received.Header.Get(webhook.HeaderSignature), receivedBody))
+
+	var payload webhook.Payload
+	assert.Nil(t, json.Unmarshal(receivedBody, &payload))
+	assert.Equal(t, 5, payload.ID)
+	assert.Equal(t, ticket.EventTicketPurchased, payload.Type)
+	assert.JSONEq(t, `{"ticket_id":1,"quantity":2}`, string(payload.Data))
+}
+
+func Test_Should_Retry_With_Backoff_When_Receiver_Fails(t *testing.T) {
+	type testCase struct {
+		name             string
+		previousAttempts int
+		expectedStatus   ticket.WebhookDeliveryStatus
+		expectedNext     time.Time
+	}
+
+	testCases := []testCase{
+		{name: "first failure is retried after base backoff", previousAttempts: 0, expectedStatus: ticket.WebhookDeliveryPending,
+			expectedNext: now.Add(10 * time.Second)},
+		{name: "third failure is retried after doubled backoff", previousAttempts: 2, expectedStatus: ticket.WebhookDeliveryPending,
+			expectedNext: now.Add(40 * time.Second)},
+		{name: "last failure marks delivery failed", previousAttempts: 7, expectedStatus: ticket.WebhookDeliveryFailed},
+	}
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Given
+			mockRepository := mocks.NewMockWebhookRepository(gomock.NewController(t))
+			dispatcher := webhook.NewDispatcher(mockRepository, receiver.Client())
+			dispatcher.Now = func() time.Time { return now }
+
+			mockRepository.EXPECT().FetchDueWebhookDeliveries(gomock.Any(), now, dispatcher.BatchSize).
+				Return([]ticket.WebhookDelivery{newDelivery(receiver.URL, test.previousAttempts)}, nil).Times(1)
+			mockRepository.EXPECT().RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, d *ticket.WebhookDelivery, attempt ticket.WebhookDeliveryAttempt) error {
+					assert.Equal(t, test.expectedStatus, d.Status)
+					assert.Equal(t, test.previousAttempts+1, d.Attempts)
+					if test.expectedStatus == ticket.WebhookDeliveryPending {
+						assert.Equal(t, test.expectedNext, d.NextAttemptAt)
+					}
+					assert.Nil(t, d.DeliveredAt)
+					assert.Equal(t, http.StatusInternalServerError, attempt.StatusCode)
+					assert.NotEmpty(t, attempt.Error)
+					return nil
+				}).Times(1)
+
+			// When
+			succeeded, err := dispatcher.ProcessBatch(context.TODO())
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, 0, succeeded)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	HeaderEventType  = "X-Webhook-Event"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the value of the signature header for body sent at timestamp. The signed message is
+// "<unix timestamp>.<body>" so a receiver can reject replayed requests with an old timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10))) //nolint:gomnd
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature was produced by Sign with the same secret, timestamp and body.
+// Receivers written in Go can use it directly.
+func Verify(secret, timestampHeader, signature string, body []byte) bool {
+	unix, err := strconv.ParseInt(timestampHeader, 10, 64) //nolint:gomnd
+	if err != nil {
+		return false
+	}
+
+	expected := Sign(secret, time.Unix(unix, 0), body)
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
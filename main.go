@@ -2,22 +2,33 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/dilaragorum/ticket-api/internal/ticket/database"
 
 	_ "github.com/dilaragorum/ticket-api/docs"
+	"github.com/dilaragorum/ticket-api/internal/ticket/availability"
 	"github.com/dilaragorum/ticket-api/internal/ticket/handler"
+	"github.com/dilaragorum/ticket-api/internal/ticket/outbox"
 	"github.com/dilaragorum/ticket-api/internal/ticket/repository"
+	"github.com/dilaragorum/ticket-api/internal/ticket/rpc"
 	"github.com/dilaragorum/ticket-api/internal/ticket/service"
+	"github.com/dilaragorum/ticket-api/internal/ticket/ticketcode"
+	"github.com/dilaragorum/ticket-api/internal/ticket/webhook"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/log"
 	echoSwagger "github.com/swaggo/echo-swagger"
+	"google.golang.org/grpc"
 )
 
 // @title Ticket API
@@ -30,6 +41,7 @@ import (
 // @host localhost:3000
 func main() {
 	e := echo.New()
+	e.Use(middleware.RequestID(), handler.AuditOrigin())
 
 	err := godotenv.Load(".env.dev")
 	if err != nil {
@@ -42,26 +54,186 @@ func main() {
 	}
 	database.Migrate()
 
-	ticketRepo := repository.NewDefaultRepository(connectionPool)
-	ticketSvc := service.NewDefaultService(ticketRepo)
+	replicas, err := database.SetupReplicas()
+	if err != nil {
+		log.Fatal(err)
+	}
+	cluster := database.NewCluster(connectionPool, replicas...)
+
+	ephemeralSigningKey := false
+	if ephemeral := os.Getenv("TICKET_CODE_SIGNING_EPHEMERAL"); ephemeral != "" {
+		if ephemeralSigningKey, err = strconv.ParseBool(ephemeral); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// The signing seed is key material and is never committed, so TICKET_CODE_SIGNING_SEED must be
+	// set outside the repo. Local runs may set TICKET_CODE_SIGNING_EPHEMERAL instead.
+	var signer *ticketcode.Signer
+	if ephemeralSigningKey && os.Getenv("TICKET_CODE_SIGNING_SEED") == "" {
+		log.Warn("TICKET_CODE_SIGNING_SEED is not set, ticket codes will not verify after a restart")
+		signer = ticketcode.NewRandomSigner()
+	} else {
+		seed, err := base64.StdEncoding.DecodeString(os.Getenv("TICKET_CODE_SIGNING_SEED"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if signer, err = ticketcode.NewSigner(seed); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	transferCutoff := service.DefaultTransferCutoff
+	if cutoff := os.Getenv("TICKET_TRANSFER_CUTOFF"); cutoff != "" {
+		if transferCutoff, err = time.ParseDuration(cutoff); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	resalePriceCap := service.DefaultResalePriceCap
+	if priceCap := os.Getenv("TICKET_RESALE_PRICE_CAP_PERCENT"); priceCap != "" {
+		if resalePriceCap, err = strconv.Atoi(priceCap); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	seatHoldTTL := service.DefaultSeatHoldTTL
+	if ttl := os.Getenv("TICKET_SEAT_HOLD_TTL"); ttl != "" {
+		if seatHoldTTL, err = time.ParseDuration(ttl); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	orderTTL := service.DefaultOrderTTL
+	if ttl := os.Getenv("TICKET_ORDER_TTL"); ttl != "" {
+		if orderTTL, err = time.ParseDuration(ttl); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	trustOrganizerHeader := false
+	if trust := os.Getenv("TICKET_TRUST_ORGANIZER_HEADER"); trust != "" {
+		if trustOrganizerHeader, err = strconv.ParseBool(trust); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cacheTTL := repository.DefaultCacheTTL
+	if ttl := os.Getenv("TICKET_CACHE_TTL"); ttl != "" {
+		if cacheTTL, err = time.ParseDuration(ttl); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	readYourWritesWindow := repository.DefaultReadYourWritesWindow
+	if window := os.Getenv("TICKET_READ_YOUR_WRITES_WINDOW"); window != "" {
+		if readYourWritesWindow, err = time.ParseDuration(window); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Reads go to the replicas listed in POSTGRES_REPLICA_HOSTS, if any, except for the ticket options
+	// and users purchased for within TICKET_READ_YOUR_WRITES_WINDOW, unless it is 0.
+	repositoryOptions := []repository.Option{}
+	if len(replicas) > 0 {
+		repositoryOptions = append(repositoryOptions, repository.WithReadReplicas(cluster))
+		if readYourWritesWindow > 0 {
+			repositoryOptions = append(repositoryOptions, repository.WithReadYourWrites(readYourWritesWindow))
+		}
+	}
+
+	// Ticket options are cached unless TICKET_CACHE_TTL is 0.
+	var ticketRepo repository.Repository = repository.NewDefaultRepository(connectionPool, repositoryOptions...)
+	if cacheTTL > 0 {
+		ticketRepo = repository.NewCachedRepository(ticketRepo, cacheTTL)
+	}
+	broadcaster := availability.NewBroadcaster(100) //nolint:gomnd
+	ticketSvc := service.NewDefaultService(ticketRepo,
+		service.WithAvailabilityNotifier(broadcaster),
+		service.WithCodeIssuer(signer),
+		service.WithTransferCutoff(transferCutoff),
+		service.WithResalePriceCap(resalePriceCap),
+		service.WithSeatHoldTTL(seatHoldTTL),
+		service.WithOrderTTL(orderTTL))
+	// The signing key is the operator's, shared by all organizers.
+	e.Use(handler.ResolveOrganizer(ticketSvc, trustOrganizerHeader, func(c echo.Context) bool {
+		return strings.HasPrefix(c.Path(), "/swagger/") || c.Path() == "/issued_tickets/signing_key"
+	}))
 	handler.NewDefaultTicketHandler(e, ticketSvc)
+	handler.NewDefaultIssuedTicketHandler(e, ticketSvc, signer.PublicKey())
+	handler.NewDefaultCheckinHandler(e, ticketSvc)
+	handler.NewDefaultResaleHandler(e, ticketSvc)
+	handler.NewDefaultPricingHandler(e, ticketSvc)
+	handler.NewDefaultSeatingHandler(e, ticketSvc)
+	handler.NewDefaultOrderHandler(e, ticketSvc)
+	handler.NewDefaultAuditHandler(e, ticketSvc)
+	handler.NewDefaultReportHandler(e, ticketSvc)
+	handler.NewDefaultLedgerHandler(e, ticketSvc)
+	availabilityHandler := handler.NewDefaultAvailabilityHandler(e, ticketSvc, broadcaster)
+	e.Server.RegisterOnShutdown(availabilityHandler.Close)
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	webhookRepo := repository.NewDefaultWebhookRepository(connectionPool)
+	webhookSvc := service.NewDefaultWebhookService(webhookRepo)
+	handler.NewDefaultWebhookHandler(e, webhookSvc)
+
+	relay := outbox.NewRelay(repository.NewDefaultOutboxRepository(connectionPool), webhookSvc)
+	go relay.Run(workerCtx)
+
+	dispatcher := webhook.NewDispatcher(webhookRepo, &http.Client{Timeout: 10 * time.Second}) //nolint:gomnd
+	go dispatcher.Run(workerCtx)
+
+	go ticketSvc.ReleaseExpiredHoldsEvery(workerCtx, service.HoldReleaseInterval)
+	go cluster.CheckReplicasEvery(workerCtx, database.ReplicaCheckInterval)
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(rpc.AuditOrigin(), rpc.ResolveOrganizer(ticketSvc, trustOrganizerHeader)))
+	rpc.NewDefaultTicketServer(grpcServer, ticketSvc)
+
 	go func() {
 		if err := e.Start(":3000"); err != nil && err != http.ErrServerClosed {
 			e.Logger.Fatal("shutting down the server")
 		}
 	}()
 
+	go func() {
+		listener, err := net.Listen("tcp", ":3001")
+		if err != nil {
+			e.Logger.Fatal(err)
+		}
+		if err := grpcServer.Serve(listener); err != nil {
+			e.Logger.Fatal("shutting down the grpc server")
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds.
 	// Use a buffered channel to avoid missing signals as recommended for signal.Notify
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	stopWorkers()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second) //nolint:gomnd
 	defer cancel()
+
+	// Both servers drain within the same deadline; grpc GracefulStop has no deadline of its own.
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
 	if err := e.Shutdown(ctx); err != nil {
 		e.Logger.Fatal(err)
 	}
+
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
 }